@@ -0,0 +1,22 @@
+package constants
+
+// Metrics that achievement conditions can be written against.
+const (
+	MetricSessionScore  = "session_score"
+	MetricTotalScore    = "total_score"
+	MetricBestScore     = "best_score"
+	MetricGamesPlayed   = "games_played"
+	MetricGamesToday    = "games_today"
+	MetricWins          = "wins"
+	MetricCurrentStreak = "current_streak"
+	MetricRank          = "rank"
+)
+
+// Comparison operators supported by achievement conditions.
+const (
+	OpGreaterOrEqual = "gte"
+	OpGreater        = "gt"
+	OpLessOrEqual    = "lte"
+	OpLess           = "lt"
+	OpEqual          = "eq"
+)
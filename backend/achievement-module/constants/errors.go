@@ -0,0 +1,7 @@
+package constants
+
+const (
+	ErrUserNotFound   = "USER_NOT_FOUND"
+	ErrInternalServer = "INTERNAL_SERVER_ERROR"
+	ErrInvalidRequest = "INVALID_REQUEST"
+)
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/achievement-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/achievement-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/achievement-module/repository"
+	leaderboardConstants "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	leaderboardModel "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+)
+
+type IAchievementCore interface {
+	GetUserAchievements(ctx context.Context, userID int64) (*model.UserAchievementsResponse, error)
+	OnScoreSubmitted(ctx context.Context, event *leaderboardModel.ScoreSubmittedEvent)
+}
+
+type AchievementCore struct {
+	repo        *repository.AchievementRepository
+	definitions []model.AchievementDefinition
+	logger      *providers.ConsoleLogger
+}
+
+func NewAchievementCore(
+	repo *repository.AchievementRepository,
+	definitions []model.AchievementDefinition,
+	logger *providers.ConsoleLogger,
+) (*AchievementCore, error) {
+	if err := validateDefinitions(definitions); err != nil {
+		return nil, err
+	}
+
+	return &AchievementCore{
+		repo:        repo,
+		definitions: definitions,
+		logger:      logger,
+	}, nil
+}
+
+// OnScoreSubmitted evaluates every achievement the player does not hold yet
+// against the submitted session, their stats and their rank.
+func (c *AchievementCore) OnScoreSubmitted(ctx context.Context, event *leaderboardModel.ScoreSubmittedEvent) {
+	unlocked, err := c.repo.GetUnlocked(ctx, event.UserID)
+	if err != nil {
+		c.logger.Warnf("Failed to load achievements | user_id=%d error=%v", event.UserID, err)
+		return
+	}
+
+	held := make(map[string]bool, len(unlocked))
+	for _, u := range unlocked {
+		held[u.AchievementID] = true
+	}
+
+	pending := make([]model.AchievementDefinition, 0, len(c.definitions))
+	for _, def := range c.definitions {
+		if !held[def.ID] {
+			pending = append(pending, def)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	metrics, err := c.collectMetrics(ctx, event, pending)
+	if err != nil {
+		c.logger.Warnf("Failed to collect achievement metrics | user_id=%d error=%v", event.UserID, err)
+		return
+	}
+
+	var earned []string
+	for _, def := range pending {
+		if satisfied(def, metrics) {
+			earned = append(earned, def.ID)
+		}
+	}
+
+	awarded, err := c.repo.Unlock(ctx, event.UserID, earned, event.Timestamp)
+	if err != nil {
+		c.logger.Warnf("Failed to store achievements | user_id=%d error=%v", event.UserID, err)
+		return
+	}
+
+	for _, id := range awarded {
+		c.logger.Infof("Achievement unlocked | user_id=%d achievement=%s", event.UserID, id)
+	}
+}
+
+// collectMetrics only queries the sources the pending definitions refer to.
+func (c *AchievementCore) collectMetrics(
+	ctx context.Context,
+	event *leaderboardModel.ScoreSubmittedEvent,
+	pending []model.AchievementDefinition,
+) (map[string]int64, error) {
+	needed := make(map[string]bool)
+	for _, def := range pending {
+		for _, cond := range def.Conditions {
+			needed[cond.Metric] = true
+		}
+	}
+
+	metrics := map[string]int64{
+		constants.MetricSessionScore: event.Score,
+	}
+	// Total score and rank conditions are evaluated against the global board
+	if global := event.Board(leaderboardConstants.DefaultBoardID); global != nil {
+		metrics[constants.MetricTotalScore] = global.TotalScore
+		if global.Rank > 0 {
+			metrics[constants.MetricRank] = int64(global.Rank)
+		}
+	}
+
+	if needed[constants.MetricGamesPlayed] || needed[constants.MetricBestScore] ||
+		needed[constants.MetricWins] || needed[constants.MetricCurrentStreak] {
+		stats, err := c.repo.GetPlayerStats(ctx, event.UserID)
+		if err != nil {
+			return nil, err
+		}
+		metrics[constants.MetricGamesPlayed] = stats.GamesPlayed
+		metrics[constants.MetricBestScore] = stats.BestScore
+		metrics[constants.MetricWins] = stats.Wins
+		metrics[constants.MetricCurrentStreak] = stats.CurrentStreak
+	}
+
+	if needed[constants.MetricGamesToday] {
+		dayStart := event.Timestamp.UTC().Truncate(24 * time.Hour)
+		count, err := c.repo.CountGamesSince(ctx, event.UserID, dayStart)
+		if err != nil {
+			return nil, err
+		}
+		metrics[constants.MetricGamesToday] = count
+	}
+
+	return metrics, nil
+}
+
+func (c *AchievementCore) GetUserAchievements(ctx context.Context, userID int64) (*model.UserAchievementsResponse, error) {
+	if userID <= 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	exists, err := c.repo.UserExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &model.UserAchievementsResponse{
+			Success: false,
+			Error:   "User not found",
+			Code:    constants.ErrUserNotFound,
+		}, nil
+	}
+
+	unlocked, err := c.repo.GetUnlocked(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlockedAt := make(map[string]time.Time, len(unlocked))
+	for _, u := range unlocked {
+		unlockedAt[u.AchievementID] = u.UnlockedAt
+	}
+
+	data := &model.UserAchievementsData{
+		UserID:       userID,
+		Total:        len(c.definitions),
+		Achievements: make([]model.UserAchievement, 0, len(c.definitions)),
+	}
+
+	for _, def := range c.definitions {
+		achievement := model.UserAchievement{
+			ID:          def.ID,
+			Name:        def.Name,
+			Description: def.Description,
+		}
+		if at, ok := unlockedAt[def.ID]; ok {
+			achievement.Unlocked = true
+			achievement.UnlockedAt = &at
+			data.Unlocked++
+		}
+		data.Achievements = append(data.Achievements, achievement)
+	}
+
+	return &model.UserAchievementsResponse{
+		Success: true,
+		Data:    data,
+	}, nil
+}
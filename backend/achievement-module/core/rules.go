@@ -0,0 +1,128 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/achievement-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/achievement-module/model"
+)
+
+var knownMetrics = map[string]bool{
+	constants.MetricSessionScore:  true,
+	constants.MetricTotalScore:    true,
+	constants.MetricBestScore:     true,
+	constants.MetricGamesPlayed:   true,
+	constants.MetricGamesToday:    true,
+	constants.MetricWins:          true,
+	constants.MetricCurrentStreak: true,
+	constants.MetricRank:          true,
+}
+
+func DefaultAchievementDefinitions() []model.AchievementDefinition {
+	return []model.AchievementDefinition{
+		{
+			ID:          "first_game",
+			Name:        "First Steps",
+			Description: "Play your first game",
+			Conditions:  []model.Condition{{Metric: constants.MetricGamesPlayed, Op: constants.OpGreaterOrEqual, Value: 1}},
+		},
+		{
+			ID:          "points_10k",
+			Name:        "Five Figures",
+			Description: "Reach 10,000 total points",
+			Conditions:  []model.Condition{{Metric: constants.MetricTotalScore, Op: constants.OpGreaterOrEqual, Value: 10000}},
+		},
+		{
+			ID:          "daily_grinder",
+			Name:        "Daily Grinder",
+			Description: "Play 10 games in a single day",
+			Conditions:  []model.Condition{{Metric: constants.MetricGamesToday, Op: constants.OpGreaterOrEqual, Value: 10}},
+		},
+		{
+			ID:          "top_100",
+			Name:        "Contender",
+			Description: "Reach the top 100",
+			Conditions:  []model.Condition{{Metric: constants.MetricRank, Op: constants.OpLessOrEqual, Value: 100}},
+		},
+		{
+			ID:          "win_streak_5",
+			Name:        "On Fire",
+			Description: "Win 5 games in a row",
+			Conditions:  []model.Condition{{Metric: constants.MetricCurrentStreak, Op: constants.OpGreaterOrEqual, Value: 5}},
+		},
+	}
+}
+
+// LoadAchievementDefinitions reads achievement definitions from a JSON file.
+func LoadAchievementDefinitions(path string) ([]model.AchievementDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read achievements config: %w", err)
+	}
+
+	var defs []model.AchievementDefinition
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("failed to parse achievements config: %w", err)
+	}
+	return defs, nil
+}
+
+func validateDefinitions(defs []model.AchievementDefinition) error {
+	seen := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		if def.ID == "" || len(def.ID) > 64 {
+			return fmt.Errorf("achievement id must be between 1 and 64 characters")
+		}
+		if seen[def.ID] {
+			return fmt.Errorf("duplicate achievement %q", def.ID)
+		}
+		seen[def.ID] = true
+
+		if len(def.Conditions) == 0 {
+			return fmt.Errorf("achievement %q has no conditions", def.ID)
+		}
+		for _, cond := range def.Conditions {
+			if !knownMetrics[cond.Metric] {
+				return fmt.Errorf("achievement %q uses unknown metric %q", def.ID, cond.Metric)
+			}
+			if _, err := compare(0, cond.Op, 0); err != nil {
+				return fmt.Errorf("achievement %q: %w", def.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// satisfied reports whether every condition holds. Metrics that were not
+// collected (e.g. rank for an unranked player) fail their condition.
+func satisfied(def model.AchievementDefinition, metrics map[string]int64) bool {
+	for _, cond := range def.Conditions {
+		value, ok := metrics[cond.Metric]
+		if !ok {
+			return false
+		}
+		if matched, _ := compare(value, cond.Op, cond.Value); !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func compare(value int64, op string, target int64) (bool, error) {
+	switch op {
+	case constants.OpGreaterOrEqual:
+		return value >= target, nil
+	case constants.OpGreater:
+		return value > target, nil
+	case constants.OpLessOrEqual:
+		return value <= target, nil
+	case constants.OpLess:
+		return value < target, nil
+	case constants.OpEqual:
+		return value == target, nil
+	default:
+		return false, fmt.Errorf("unknown operator %q", op)
+	}
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// AchievementDefinition is a declarative rule; every condition must hold for
+// the achievement to unlock.
+type AchievementDefinition struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Conditions  []Condition `json:"conditions"`
+}
+
+type Condition struct {
+	Metric string `json:"metric"`
+	Op     string `json:"op"`
+	Value  int64  `json:"value"`
+}
+
+type UserAchievement struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Unlocked    bool       `json:"unlocked"`
+	UnlockedAt  *time.Time `json:"unlocked_at,omitempty"`
+}
+
+type UserAchievementsData struct {
+	UserID       int64             `json:"user_id"`
+	Unlocked     int               `json:"unlocked"`
+	Total        int               `json:"total"`
+	Achievements []UserAchievement `json:"achievements"`
+}
+
+type UserAchievementsResponse struct {
+	Success bool                  `json:"success"`
+	Data    *UserAchievementsData `json:"data,omitempty"`
+	Error   string                `json:"error,omitempty"`
+	Code    string                `json:"code,omitempty"`
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"gorm.io/gorm"
+)
+
+type UnlockedAchievement struct {
+	AchievementID string    `gorm:"column:achievement_id"`
+	UnlockedAt    time.Time `gorm:"column:unlocked_at"`
+}
+
+type PlayerStats struct {
+	GamesPlayed   int64 `gorm:"column:games_played"`
+	BestScore     int64 `gorm:"column:best_score"`
+	Wins          int64 `gorm:"column:wins"`
+	CurrentStreak int64 `gorm:"column:current_streak"`
+}
+
+type IAchievementRepository interface {
+	UserExists(ctx context.Context, userID int64) (bool, error)
+	GetUnlocked(ctx context.Context, userID int64) ([]UnlockedAchievement, error)
+	GetPlayerStats(ctx context.Context, userID int64) (*PlayerStats, error)
+	CountGamesSince(ctx context.Context, userID int64, since time.Time) (int64, error)
+	Unlock(ctx context.Context, userID int64, achievementIDs []string, at time.Time) ([]string, error)
+}
+
+type AchievementRepository struct {
+	db     *gorm.DB
+	logger *providers.ConsoleLogger
+}
+
+func NewAchievementRepository(db *gorm.DB, logger *providers.ConsoleLogger) *AchievementRepository {
+	return &AchievementRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *AchievementRepository) UserExists(ctx context.Context, userID int64) (bool, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	if err := r.db.WithContext(ctx).Raw(
+		`SELECT EXISTS (SELECT 1 FROM gaming.users WHERE game_id = ? AND id = ?)`,
+		gameID,
+		userID,
+	).Scan(&exists).Error; err != nil {
+		return false, fmt.Errorf("failed to check user: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *AchievementRepository) GetUnlocked(ctx context.Context, userID int64) ([]UnlockedAchievement, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var unlocked []UnlockedAchievement
+	err = r.db.WithContext(ctx).
+		Table("gaming.user_achievements").
+		Select("achievement_id, unlocked_at").
+		Where("game_id = ? AND user_id = ?", gameID, userID).
+		Order("unlocked_at").
+		Find(&unlocked).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unlocked achievements: %w", err)
+	}
+	return unlocked, nil
+}
+
+// GetPlayerStats reads the maintained stats row; players without one get zeros.
+func (r *AchievementRepository) GetPlayerStats(ctx context.Context, userID int64) (*PlayerStats, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []PlayerStats
+	err = r.db.WithContext(ctx).
+		Table("gaming.player_stats").
+		Select("games_played, best_score, wins, current_streak").
+		Where("game_id = ? AND user_id = ?", gameID, userID).
+		Find(&stats).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player stats: %w", err)
+	}
+	if len(stats) == 0 {
+		return &PlayerStats{}, nil
+	}
+	return &stats[0], nil
+}
+
+func (r *AchievementRepository) CountGamesSince(ctx context.Context, userID int64, since time.Time) (int64, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = r.db.WithContext(ctx).
+		Table("gaming.game_sessions").
+		Where("game_id = ? AND user_id = ? AND timestamp >= ?", gameID, userID, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count games: %w", err)
+	}
+	return count, nil
+}
+
+// Unlock stores the given achievements and returns the ids that were newly
+// awarded. Ids the user already holds are skipped by the primary key.
+func (r *AchievementRepository) Unlock(
+	ctx context.Context,
+	userID int64,
+	achievementIDs []string,
+	at time.Time,
+) ([]string, error) {
+	if len(achievementIDs) == 0 {
+		return nil, nil
+	}
+
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var awarded []string
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, id := range achievementIDs {
+			result := tx.Exec(`
+				INSERT INTO gaming.user_achievements (game_id, user_id, achievement_id, unlocked_at)
+				VALUES (?, ?, ?, ?)
+				ON CONFLICT (user_id, achievement_id) DO NOTHING
+			`, gameID, userID, id, at)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				awarded = append(awarded, id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlock achievements: %w", err)
+	}
+	return awarded, nil
+}
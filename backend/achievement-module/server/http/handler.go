@@ -0,0 +1,92 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"go.uber.org/zap"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/achievement-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/achievement-module/core"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+)
+
+type AchievementHandler struct {
+	core     *core.AchievementCore
+	logger   *providers.ConsoleLogger
+	newrelic *newrelic.Application
+}
+
+func NewAchievementHandler(core *core.AchievementCore, logger *providers.ConsoleLogger, newrelic *newrelic.Application) *AchievementHandler {
+	return &AchievementHandler{
+		core:     core,
+		logger:   logger,
+		newrelic: newrelic,
+	}
+}
+
+func (h *AchievementHandler) GetUserAchievements(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	userID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil || userID <= 0 {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid user ID",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	resp, err := h.core.GetUserAchievements(ctx, userID)
+	if err != nil {
+		h.logger.Error(
+			"GetUserAchievements failed",
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch achievements",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *AchievementHandler) respondWithJSON(
+	w http.ResponseWriter,
+	status int,
+	payload interface{},
+) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func (h *AchievementHandler) respondWithError(
+	w http.ResponseWriter,
+	status int,
+	message string,
+	code string,
+) {
+	h.respondWithJSON(w, status, map[string]interface{}{
+		"success": false,
+		"error":   message,
+		"code":    code,
+	})
+}
@@ -0,0 +1,14 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+func (h *AchievementHandler) RegisterRoutes(router *mux.Router) {
+	// Get user achievements endpoint
+	_, achievementsHandler := newrelic.WrapHandle(h.newrelic, "api/users/{id}/achievements", http.HandlerFunc(h.GetUserAchievements))
+	router.Handle("/api/users/{id}/achievements", achievementsHandler).Methods(http.MethodGet)
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/data-migration-module/repository"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
 	"gorm.io/gorm"
 )
 
@@ -23,20 +24,29 @@ func NewMigrationCore(repo *repository.MigrationRepository, db *gorm.DB) *Migrat
 }
 
 func (c *MigrationCore) PopulateSampleData(ctx context.Context, userLimit, sessionLimit int) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
 	tx := c.db.Begin()
 	if userLimit > 0 {
-		if err := c.repository.BulkInsertUsers(tx, userLimit); err != nil {
+		if err := c.repository.BulkInsertUsers(tx, gameID, userLimit); err != nil {
 			return err
 		}
 	}
 
 	if sessionLimit > 0 {
-		if err := c.repository.BulkInsertGameSessions(tx, sessionLimit); err != nil {
+		if err := c.repository.BulkInsertGameSessions(tx, gameID, sessionLimit); err != nil {
 			return err
 		}
 	}
 
-	if err := c.repository.UpdateLeaderboard(tx); err != nil {
+	if err := c.repository.UpdateLeaderboard(tx, gameID); err != nil {
+		return err
+	}
+
+	if err := c.repository.RebuildPlayerStats(tx, gameID); err != nil {
 		return err
 	}
 
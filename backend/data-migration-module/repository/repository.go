@@ -6,10 +6,11 @@ import (
 )
 
 type IMigrationRepository interface {
-	BulkInsertUsers(tx *gorm.DB, limit int) error
-	BulkInsertGameSessions(tx *gorm.DB, limit int) error
-	UpdateLeaderboard(tx *gorm.DB) error
-	GetMaxUserID(tx *gorm.DB) (int64, error)
+	BulkInsertUsers(tx *gorm.DB, gameID string, limit int) error
+	BulkInsertGameSessions(tx *gorm.DB, gameID string, limit int) error
+	UpdateLeaderboard(tx *gorm.DB, gameID string) error
+	RebuildPlayerStats(tx *gorm.DB, gameID string) error
+	GetMaxUserID(tx *gorm.DB, gameID string) (int64, error)
 }
 
 type MigrationRepository struct {
@@ -20,52 +21,149 @@ func NewMigrationRepository(db *gorm.DB) *MigrationRepository {
 	return &MigrationRepository{db: db}
 }
 
-func (r *MigrationRepository) BulkInsertUsers(tx *gorm.DB, limit int) error {
+// BulkInsertUsers numbers new usernames after the highest user id across all
+// games, which keeps them unique without reading other games' usernames.
+func (r *MigrationRepository) BulkInsertUsers(tx *gorm.DB, gameID string, limit int) error {
 	sql := `
-		INSERT INTO gaming.users (username)
-		SELECT 'user_' || (SELECT COALESCE(MAX(id),0) FROM gaming.users) + generate_series(1, ?);
+		INSERT INTO gaming.users (game_id, username)
+		SELECT ?, 'user_' || (SELECT COALESCE(MAX(id),0) FROM gaming.users) + generate_series(1, ?);
 	`
-	return tx.Exec(sql, limit).Error
+	return tx.Exec(sql, gameID, limit).Error
 }
 
-func (r *MigrationRepository) BulkInsertGameSessions(tx *gorm.DB, limit int) error {
+func (r *MigrationRepository) BulkInsertGameSessions(tx *gorm.DB, gameID string, limit int) error {
 	sql := `
-		INSERT INTO gaming.game_sessions (user_id, score, game_mode, timestamp)
+		INSERT INTO gaming.game_sessions (game_id, user_id, score, game_mode, timestamp)
 		SELECT
+			u.game_id,
 			u.id,
 			floor(random() * 10000 + 1)::int,
 			CASE WHEN random() > 0.5 THEN 'solo' ELSE 'team' END,
 			NOW() - INTERVAL '1 day' * floor(random() * 365)
 		FROM (
-			SELECT id
+			SELECT game_id, id
 			FROM gaming.users
+			WHERE game_id = ?
 			ORDER BY random()
 			LIMIT ?
 		) u;
 	`
-	return tx.Exec(sql, limit).Error
+	return tx.Exec(sql, gameID, limit).Error
 }
 
-func (r *MigrationRepository) UpdateLeaderboard(tx *gorm.DB) error {
+func (r *MigrationRepository) UpdateLeaderboard(tx *gorm.DB, gameID string) error {
 	sql := `
-		INSERT INTO gaming.leaderboard (user_id, total_score, rank)
+		INSERT INTO gaming.leaderboard (game_id, board_id, period_start, user_id, total_score, rank)
 		SELECT
+			game_id,
+			'global',
+			'1970-01-01 00:00:00',
 			user_id,
 			SUM(score) AS total_score,
 			RANK() OVER (ORDER BY SUM(score) DESC)
 		FROM gaming.game_sessions
-		GROUP BY user_id
-		ON CONFLICT (user_id) DO UPDATE
+		WHERE game_id = ?
+		GROUP BY game_id, user_id
+		ON CONFLICT (game_id, board_id, period_start, user_id) DO UPDATE
 		SET
 			total_score = EXCLUDED.total_score,
 			rank = EXCLUDED.rank;
 	`
-	return tx.Exec(sql).Error
+	return tx.Exec(sql, gameID).Error
+}
+
+// RebuildPlayerStats recomputes the stats tables from game_sessions, since bulk
+// inserted sessions bypass the incremental updates done on score submission.
+// Streaks depend on session order and are rebuilt with a gaps-and-islands pass.
+func (r *MigrationRepository) RebuildPlayerStats(tx *gorm.DB, gameID string) error {
+	statsSQL := `
+		WITH ordered AS (
+			SELECT
+				user_id,
+				outcome,
+				SUM(CASE WHEN outcome IS DISTINCT FROM 'win' AND outcome IS NOT NULL THEN 1 ELSE 0 END)
+					OVER (PARTITION BY user_id ORDER BY timestamp, id) AS streak_group
+			FROM gaming.game_sessions
+			WHERE game_id = ?
+		),
+		streaks AS (
+			SELECT user_id, streak_group, COUNT(*) AS streak_length
+			FROM ordered
+			WHERE outcome = 'win'
+			GROUP BY user_id, streak_group
+		),
+		latest AS (
+			SELECT user_id, MAX(streak_group) AS streak_group
+			FROM ordered
+			GROUP BY user_id
+		)
+		INSERT INTO gaming.player_stats (
+			game_id, user_id, games_played, total_score, best_score, wins, decided_games,
+			current_streak, longest_streak, last_played_at
+		)
+		SELECT
+			gs.game_id,
+			gs.user_id,
+			COUNT(*),
+			SUM(gs.score),
+			MAX(gs.score),
+			COUNT(*) FILTER (WHERE gs.outcome = 'win'),
+			COUNT(*) FILTER (WHERE gs.outcome IS NOT NULL),
+			COALESCE((
+				SELECT s.streak_length FROM streaks s JOIN latest l
+					ON l.user_id = s.user_id AND l.streak_group = s.streak_group
+				WHERE s.user_id = gs.user_id
+			), 0),
+			COALESCE((SELECT MAX(s.streak_length) FROM streaks s WHERE s.user_id = gs.user_id), 0),
+			MAX(gs.timestamp)
+		FROM gaming.game_sessions gs
+		WHERE gs.game_id = ?
+		GROUP BY gs.game_id, gs.user_id
+		ON CONFLICT (user_id) DO UPDATE
+		SET
+			games_played = EXCLUDED.games_played,
+			total_score = EXCLUDED.total_score,
+			best_score = EXCLUDED.best_score,
+			wins = EXCLUDED.wins,
+			decided_games = EXCLUDED.decided_games,
+			current_streak = EXCLUDED.current_streak,
+			longest_streak = EXCLUDED.longest_streak,
+			last_played_at = EXCLUDED.last_played_at;
+	`
+	if err := tx.Exec(statsSQL, gameID, gameID).Error; err != nil {
+		return err
+	}
+
+	modeStatsSQL := `
+		INSERT INTO gaming.player_mode_stats (
+			game_id, user_id, game_mode, games_played, total_score, best_score, wins, decided_games
+		)
+		SELECT
+			game_id,
+			user_id,
+			game_mode,
+			COUNT(*),
+			SUM(score),
+			MAX(score),
+			COUNT(*) FILTER (WHERE outcome = 'win'),
+			COUNT(*) FILTER (WHERE outcome IS NOT NULL)
+		FROM gaming.game_sessions
+		WHERE game_id = ?
+		GROUP BY game_id, user_id, game_mode
+		ON CONFLICT (user_id, game_mode) DO UPDATE
+		SET
+			games_played = EXCLUDED.games_played,
+			total_score = EXCLUDED.total_score,
+			best_score = EXCLUDED.best_score,
+			wins = EXCLUDED.wins,
+			decided_games = EXCLUDED.decided_games;
+	`
+	return tx.Exec(modeStatsSQL, gameID).Error
 }
 
-func (r *MigrationRepository) GetMaxUserID(tx *gorm.DB) (int64, error) {
+func (r *MigrationRepository) GetMaxUserID(tx *gorm.DB, gameID string) (int64, error) {
 	var maxUserID int64
-	err := tx.Raw("SELECT COALESCE(MAX(id), 0) FROM gaming.users").Scan(&maxUserID).Error
+	err := tx.Raw("SELECT COALESCE(MAX(id), 0) FROM gaming.users WHERE game_id = ?", gameID).Scan(&maxUserID).Error
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,73 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Optional result of a session; NULL when the game mode has no winner.
+-- Scores are int64 in the API, so sessions and totals are widened to match.
+ALTER TABLE gaming.game_sessions
+    ADD COLUMN IF NOT EXISTS outcome VARCHAR(10),
+    ALTER COLUMN score TYPE BIGINT;
+
+ALTER TABLE gaming.leaderboard
+    ALTER COLUMN total_score TYPE BIGINT;
+
+CREATE TABLE IF NOT EXISTS gaming.player_stats (
+    user_id INT PRIMARY KEY,
+    games_played INT NOT NULL DEFAULT 0,
+    total_score BIGINT NOT NULL DEFAULT 0,
+    best_score BIGINT NOT NULL DEFAULT 0,
+    wins INT NOT NULL DEFAULT 0,
+    decided_games INT NOT NULL DEFAULT 0,
+    current_streak INT NOT NULL DEFAULT 0,
+    longest_streak INT NOT NULL DEFAULT 0,
+    last_played_at TIMESTAMP,
+    CONSTRAINT fk_player_stats_user
+        FOREIGN KEY (user_id)
+            REFERENCES gaming.users(id)
+            ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS gaming.player_mode_stats (
+    user_id INT NOT NULL,
+    game_mode VARCHAR(50) NOT NULL,
+    games_played INT NOT NULL DEFAULT 0,
+    total_score BIGINT NOT NULL DEFAULT 0,
+    best_score BIGINT NOT NULL DEFAULT 0,
+    wins INT NOT NULL DEFAULT 0,
+    decided_games INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, game_mode),
+    CONSTRAINT fk_player_mode_stats_user
+        FOREIGN KEY (user_id)
+            REFERENCES gaming.users(id)
+            ON DELETE CASCADE
+);
+
+-- Backfill from existing sessions (historic sessions carry no outcome)
+INSERT INTO gaming.player_stats (user_id, games_played, total_score, best_score, last_played_at)
+SELECT user_id, COUNT(*), SUM(score), MAX(score), MAX(timestamp)
+FROM gaming.game_sessions
+GROUP BY user_id
+ON CONFLICT (user_id) DO NOTHING;
+
+INSERT INTO gaming.player_mode_stats (user_id, game_mode, games_played, total_score, best_score)
+SELECT user_id, game_mode, COUNT(*), SUM(score), MAX(score)
+FROM gaming.game_sessions
+GROUP BY user_id, game_mode
+ON CONFLICT (user_id, game_mode) DO NOTHING;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS gaming.player_mode_stats;
+DROP TABLE IF EXISTS gaming.player_stats;
+
+ALTER TABLE gaming.leaderboard
+    ALTER COLUMN total_score TYPE INT;
+
+ALTER TABLE gaming.game_sessions
+    DROP COLUMN IF EXISTS outcome,
+    ALTER COLUMN score TYPE INT;
+
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Periodic rank snapshots per player. Recent snapshots are hourly and get
+-- compacted into one daily row per player once they age out.
+CREATE TABLE IF NOT EXISTS gaming.rank_history (
+    user_id INT NOT NULL,
+    captured_at TIMESTAMP NOT NULL,
+    granularity VARCHAR(10) NOT NULL DEFAULT 'hourly',
+    rank INT NOT NULL,
+    total_score BIGINT NOT NULL,
+    PRIMARY KEY (user_id, captured_at),
+    CONSTRAINT fk_rank_history_user
+        FOREIGN KEY (user_id)
+            REFERENCES gaming.users(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_rank_history_granularity_captured_at
+    ON gaming.rank_history(granularity, captured_at);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS gaming.idx_rank_history_granularity_captured_at;
+DROP TABLE IF EXISTS gaming.rank_history;
+
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Achievements unlocked per user; the primary key prevents double awards
+CREATE TABLE IF NOT EXISTS gaming.user_achievements (
+    user_id INT NOT NULL,
+    achievement_id VARCHAR(64) NOT NULL,
+    unlocked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, achievement_id),
+    CONSTRAINT fk_user_achievements_user
+        FOREIGN KEY (user_id)
+            REFERENCES gaming.users(id)
+            ON DELETE CASCADE
+);
+
+-- Supports per-day session counts for "games in a day" style rules
+CREATE INDEX IF NOT EXISTS idx_game_sessions_user_timestamp
+    ON gaming.game_sessions(user_id, timestamp);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS gaming.idx_game_sessions_user_timestamp;
+DROP TABLE IF EXISTS gaming.user_achievements;
+
+-- +goose StatementEnd
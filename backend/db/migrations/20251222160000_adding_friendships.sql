@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Each relationship is stored from both sides so a user's friends are a
+-- primary-key range scan. status is from user_id's point of view:
+--   requested - user_id sent a request to friend_id
+--   pending   - user_id received a request from friend_id
+--   accepted  - mutual friends
+--   blocked   - user_id blocked friend_id (no mirror row is kept)
+CREATE TABLE IF NOT EXISTS gaming.friendships (
+    user_id INT NOT NULL,
+    friend_id INT NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, friend_id),
+    CONSTRAINT chk_friendships_not_self CHECK (user_id <> friend_id),
+    CONSTRAINT fk_friendships_user
+        FOREIGN KEY (user_id)
+            REFERENCES gaming.users(id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_friendships_friend
+        FOREIGN KEY (friend_id)
+            REFERENCES gaming.users(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_friendships_user_status
+    ON gaming.friendships(user_id, status);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS gaming.idx_friendships_user_status;
+DROP TABLE IF EXISTS gaming.friendships;
+
+-- +goose StatementEnd
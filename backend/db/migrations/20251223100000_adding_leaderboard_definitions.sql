@@ -0,0 +1,96 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Leaderboards become first-class resources. Every score row belongs to a
+-- board and to the board's current period (epoch for boards that never reset).
+CREATE TABLE IF NOT EXISTS gaming.leaderboards (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    aggregation VARCHAR(16) NOT NULL DEFAULT 'sum',
+    sort_order VARCHAR(4) NOT NULL DEFAULT 'desc',
+    reset_schedule VARCHAR(16) NOT NULL DEFAULT 'none',
+    game_modes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_leaderboards_aggregation CHECK (aggregation IN ('sum', 'max', 'min', 'latest', 'count')),
+    CONSTRAINT chk_leaderboards_sort_order CHECK (sort_order IN ('desc', 'asc')),
+    CONSTRAINT chk_leaderboards_reset_schedule CHECK (reset_schedule IN ('none', 'daily', 'weekly', 'monthly'))
+);
+
+INSERT INTO gaming.leaderboards (id, name)
+VALUES ('global', 'Global')
+ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE gaming.leaderboard
+    ADD COLUMN IF NOT EXISTS board_id VARCHAR(64) NOT NULL DEFAULT 'global',
+    ADD COLUMN IF NOT EXISTS period_start TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00',
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+ALTER TABLE gaming.leaderboard
+    ADD CONSTRAINT fk_leaderboard_board
+        FOREIGN KEY (board_id)
+            REFERENCES gaming.leaderboards(id)
+            ON DELETE CASCADE;
+
+ALTER TABLE gaming.leaderboard
+    DROP CONSTRAINT IF EXISTS uq_leaderboard_user;
+
+ALTER TABLE gaming.leaderboard
+    ADD CONSTRAINT uq_leaderboard_board_period_user UNIQUE (board_id, period_start, user_id);
+
+DROP INDEX IF EXISTS gaming.idx_leaderboard_total_score;
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_board_period_score
+    ON gaming.leaderboard(board_id, period_start, total_score DESC);
+
+-- Rank history is kept per board as well
+ALTER TABLE gaming.rank_history
+    ADD COLUMN IF NOT EXISTS board_id VARCHAR(64) NOT NULL DEFAULT 'global';
+
+ALTER TABLE gaming.rank_history
+    DROP CONSTRAINT IF EXISTS rank_history_pkey;
+
+ALTER TABLE gaming.rank_history
+    ADD PRIMARY KEY (board_id, user_id, captured_at);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM gaming.rank_history WHERE board_id <> 'global';
+
+ALTER TABLE gaming.rank_history
+    DROP CONSTRAINT IF EXISTS rank_history_pkey;
+
+ALTER TABLE gaming.rank_history
+    ADD PRIMARY KEY (user_id, captured_at);
+
+ALTER TABLE gaming.rank_history
+    DROP COLUMN IF EXISTS board_id;
+
+DELETE FROM gaming.leaderboard
+WHERE board_id <> 'global' OR period_start <> '1970-01-01 00:00:00';
+
+DROP INDEX IF EXISTS gaming.idx_leaderboard_board_period_score;
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_total_score
+    ON gaming.leaderboard(total_score DESC);
+
+ALTER TABLE gaming.leaderboard
+    DROP CONSTRAINT IF EXISTS uq_leaderboard_board_period_user;
+
+ALTER TABLE gaming.leaderboard
+    ADD CONSTRAINT uq_leaderboard_user UNIQUE (user_id);
+
+ALTER TABLE gaming.leaderboard
+    DROP CONSTRAINT IF EXISTS fk_leaderboard_board;
+
+ALTER TABLE gaming.leaderboard
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS period_start,
+    DROP COLUMN IF EXISTS board_id;
+
+DROP TABLE IF EXISTS gaming.leaderboards;
+
+-- +goose StatementEnd
@@ -0,0 +1,199 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Each game (tenant) served by the deployment. Requests authenticate with an
+-- API key, stored only as its SHA-256 hex digest, or name the game directly
+-- through the X-Game-Id header when the game has no key configured.
+CREATE TABLE IF NOT EXISTS gaming.games (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    api_key_hash CHAR(64) UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO gaming.games (id, name)
+VALUES ('default', 'Default')
+ON CONFLICT (id) DO NOTHING;
+
+-- Users belong to exactly one game; usernames are unique per game
+ALTER TABLE gaming.users
+    ADD COLUMN IF NOT EXISTS game_id VARCHAR(64) NOT NULL DEFAULT 'default'
+        REFERENCES gaming.games(id) ON DELETE CASCADE;
+
+ALTER TABLE gaming.users
+    DROP CONSTRAINT IF EXISTS users_username_key;
+
+ALTER TABLE gaming.users
+    ADD CONSTRAINT uq_users_game_username UNIQUE (game_id, username),
+    ADD CONSTRAINT uq_users_game_id UNIQUE (game_id, id);
+
+-- Every user-owned row carries the game and references (game_id, user_id),
+-- so rows can never point at a user of another game.
+ALTER TABLE gaming.game_sessions
+    ADD COLUMN IF NOT EXISTS game_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    ADD CONSTRAINT fk_game_sessions_game_user
+        FOREIGN KEY (game_id, user_id) REFERENCES gaming.users(game_id, id) ON DELETE CASCADE;
+
+ALTER TABLE gaming.player_stats
+    ADD COLUMN IF NOT EXISTS game_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    ADD CONSTRAINT fk_player_stats_game_user
+        FOREIGN KEY (game_id, user_id) REFERENCES gaming.users(game_id, id) ON DELETE CASCADE;
+
+ALTER TABLE gaming.player_mode_stats
+    ADD COLUMN IF NOT EXISTS game_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    ADD CONSTRAINT fk_player_mode_stats_game_user
+        FOREIGN KEY (game_id, user_id) REFERENCES gaming.users(game_id, id) ON DELETE CASCADE;
+
+ALTER TABLE gaming.user_achievements
+    ADD COLUMN IF NOT EXISTS game_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    ADD CONSTRAINT fk_user_achievements_game_user
+        FOREIGN KEY (game_id, user_id) REFERENCES gaming.users(game_id, id) ON DELETE CASCADE;
+
+ALTER TABLE gaming.friendships
+    ADD COLUMN IF NOT EXISTS game_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    ADD CONSTRAINT fk_friendships_game_user
+        FOREIGN KEY (game_id, user_id) REFERENCES gaming.users(game_id, id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_friendships_game_friend
+        FOREIGN KEY (game_id, friend_id) REFERENCES gaming.users(game_id, id) ON DELETE CASCADE;
+
+-- Board ids only need to be unique within a game
+ALTER TABLE gaming.leaderboard
+    DROP CONSTRAINT IF EXISTS fk_leaderboard_board;
+
+ALTER TABLE gaming.leaderboards
+    ADD COLUMN IF NOT EXISTS game_id VARCHAR(64) NOT NULL DEFAULT 'default'
+        REFERENCES gaming.games(id) ON DELETE CASCADE;
+
+ALTER TABLE gaming.leaderboards
+    DROP CONSTRAINT IF EXISTS leaderboards_pkey;
+
+ALTER TABLE gaming.leaderboards
+    ADD PRIMARY KEY (game_id, id);
+
+-- Every game gets its own 'global' board, the board requests fall back to
+-- when they name none. Games are provisioned with a plain INSERT into
+-- gaming.games, so the board is created by a trigger in the same transaction.
+CREATE OR REPLACE FUNCTION gaming.create_game_default_board() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO gaming.leaderboards (game_id, id, name)
+    VALUES (NEW.id, 'global', 'Global')
+    ON CONFLICT (game_id, id) DO NOTHING;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_games_default_board
+    AFTER INSERT ON gaming.games
+    FOR EACH ROW
+    EXECUTE FUNCTION gaming.create_game_default_board();
+
+ALTER TABLE gaming.leaderboard
+    ADD COLUMN IF NOT EXISTS game_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    ADD CONSTRAINT fk_leaderboard_game_board
+        FOREIGN KEY (game_id, board_id) REFERENCES gaming.leaderboards(game_id, id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_leaderboard_game_user
+        FOREIGN KEY (game_id, user_id) REFERENCES gaming.users(game_id, id) ON DELETE CASCADE;
+
+ALTER TABLE gaming.leaderboard
+    DROP CONSTRAINT IF EXISTS uq_leaderboard_board_period_user;
+
+ALTER TABLE gaming.leaderboard
+    ADD CONSTRAINT uq_leaderboard_game_board_period_user UNIQUE (game_id, board_id, period_start, user_id);
+
+DROP INDEX IF EXISTS gaming.idx_leaderboard_board_period_score;
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_game_board_period_score
+    ON gaming.leaderboard(game_id, board_id, period_start, total_score DESC);
+
+ALTER TABLE gaming.rank_history
+    ADD COLUMN IF NOT EXISTS game_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    ADD CONSTRAINT fk_rank_history_game_user
+        FOREIGN KEY (game_id, user_id) REFERENCES gaming.users(game_id, id) ON DELETE CASCADE;
+
+ALTER TABLE gaming.rank_history
+    DROP CONSTRAINT IF EXISTS rank_history_pkey;
+
+ALTER TABLE gaming.rank_history
+    ADD PRIMARY KEY (game_id, board_id, user_id, captured_at);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS trg_games_default_board ON gaming.games;
+DROP FUNCTION IF EXISTS gaming.create_game_default_board();
+
+DELETE FROM gaming.users WHERE game_id <> 'default';
+DELETE FROM gaming.leaderboards WHERE game_id <> 'default';
+
+ALTER TABLE gaming.rank_history
+    DROP CONSTRAINT IF EXISTS rank_history_pkey;
+
+ALTER TABLE gaming.rank_history
+    ADD PRIMARY KEY (board_id, user_id, captured_at);
+
+ALTER TABLE gaming.rank_history
+    DROP CONSTRAINT IF EXISTS fk_rank_history_game_user,
+    DROP COLUMN IF EXISTS game_id;
+
+DROP INDEX IF EXISTS gaming.idx_leaderboard_game_board_period_score;
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_board_period_score
+    ON gaming.leaderboard(board_id, period_start, total_score DESC);
+
+ALTER TABLE gaming.leaderboard
+    DROP CONSTRAINT IF EXISTS uq_leaderboard_game_board_period_user,
+    DROP CONSTRAINT IF EXISTS fk_leaderboard_game_board,
+    DROP CONSTRAINT IF EXISTS fk_leaderboard_game_user,
+    DROP COLUMN IF EXISTS game_id;
+
+ALTER TABLE gaming.leaderboard
+    ADD CONSTRAINT uq_leaderboard_board_period_user UNIQUE (board_id, period_start, user_id);
+
+ALTER TABLE gaming.leaderboards
+    DROP CONSTRAINT IF EXISTS leaderboards_pkey;
+
+ALTER TABLE gaming.leaderboards
+    DROP COLUMN IF EXISTS game_id;
+
+ALTER TABLE gaming.leaderboards
+    ADD PRIMARY KEY (id);
+
+ALTER TABLE gaming.leaderboard
+    ADD CONSTRAINT fk_leaderboard_board
+        FOREIGN KEY (board_id) REFERENCES gaming.leaderboards(id) ON DELETE CASCADE;
+
+ALTER TABLE gaming.friendships
+    DROP CONSTRAINT IF EXISTS fk_friendships_game_user,
+    DROP CONSTRAINT IF EXISTS fk_friendships_game_friend,
+    DROP COLUMN IF EXISTS game_id;
+
+ALTER TABLE gaming.user_achievements
+    DROP CONSTRAINT IF EXISTS fk_user_achievements_game_user,
+    DROP COLUMN IF EXISTS game_id;
+
+ALTER TABLE gaming.player_mode_stats
+    DROP CONSTRAINT IF EXISTS fk_player_mode_stats_game_user,
+    DROP COLUMN IF EXISTS game_id;
+
+ALTER TABLE gaming.player_stats
+    DROP CONSTRAINT IF EXISTS fk_player_stats_game_user,
+    DROP COLUMN IF EXISTS game_id;
+
+ALTER TABLE gaming.game_sessions
+    DROP CONSTRAINT IF EXISTS fk_game_sessions_game_user,
+    DROP COLUMN IF EXISTS game_id;
+
+ALTER TABLE gaming.users
+    DROP CONSTRAINT IF EXISTS uq_users_game_id,
+    DROP CONSTRAINT IF EXISTS uq_users_game_username,
+    DROP COLUMN IF EXISTS game_id;
+
+ALTER TABLE gaming.users
+    ADD CONSTRAINT users_username_key UNIQUE (username);
+
+DROP TABLE IF EXISTS gaming.games;
+
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Boards can rank lower-is-better scores such as lap times in milliseconds.
+-- 'best' keeps the maximum on descending boards and the minimum on ascending ones.
+ALTER TABLE gaming.leaderboards
+    ADD COLUMN IF NOT EXISTS score_unit VARCHAR(16) NOT NULL DEFAULT 'points',
+    ADD CONSTRAINT chk_leaderboards_score_unit CHECK (score_unit IN ('points', 'ms'));
+
+ALTER TABLE gaming.leaderboards
+    DROP CONSTRAINT IF EXISTS chk_leaderboards_aggregation;
+
+ALTER TABLE gaming.leaderboards
+    ADD CONSTRAINT chk_leaderboards_aggregation
+        CHECK (aggregation IN ('sum', 'max', 'min', 'latest', 'count', 'best'));
+
+-- Ascending boards need no index of their own: top lists ordering by
+-- total_score ASC and ranks counting rows with a lower total_score are both
+-- served by idx_leaderboard_game_board_period_score scanned backwards.
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+UPDATE gaming.leaderboards
+SET aggregation = CASE WHEN sort_order = 'asc' THEN 'min' ELSE 'max' END
+WHERE aggregation = 'best';
+
+ALTER TABLE gaming.leaderboards
+    DROP CONSTRAINT IF EXISTS chk_leaderboards_aggregation;
+
+ALTER TABLE gaming.leaderboards
+    ADD CONSTRAINT chk_leaderboards_aggregation
+        CHECK (aggregation IN ('sum', 'max', 'min', 'latest', 'count'));
+
+ALTER TABLE gaming.leaderboards
+    DROP CONSTRAINT IF EXISTS chk_leaderboards_score_unit,
+    DROP COLUMN IF EXISTS score_unit;
+
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Optional per-board decay of inactive players' scores
+ALTER TABLE gaming.leaderboards
+    ADD COLUMN IF NOT EXISTS decay_policy VARCHAR(16) NOT NULL DEFAULT 'none',
+    ADD COLUMN IF NOT EXISTS decay_inactive_days INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS decay_half_life_days INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS decay_weekly_deduction BIGINT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_leaderboards_decay_policy CHECK (decay_policy IN ('none', 'half_life', 'weekly'));
+
+-- How far decay has been applied to a row; NULL until it first decays.
+-- raw_score keeps the undecayed score while decay has lowered total_score;
+-- decay is recomputed from it on every run, so it never compounds and the
+-- next submit aggregates on the real score
+ALTER TABLE gaming.leaderboard
+    ADD COLUMN IF NOT EXISTS decayed_through TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS raw_score BIGINT;
+
+-- The decay job looks for rows by last activity
+CREATE INDEX IF NOT EXISTS idx_leaderboard_game_board_updated_at
+    ON gaming.leaderboard(game_id, board_id, period_start, updated_at);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS gaming.idx_leaderboard_game_board_updated_at;
+
+ALTER TABLE gaming.leaderboard
+    DROP COLUMN IF EXISTS raw_score,
+    DROP COLUMN IF EXISTS decayed_through;
+
+ALTER TABLE gaming.leaderboards
+    DROP CONSTRAINT IF EXISTS chk_leaderboards_decay_policy,
+    DROP COLUMN IF EXISTS decay_weekly_deduction,
+    DROP COLUMN IF EXISTS decay_half_life_days,
+    DROP COLUMN IF EXISTS decay_inactive_days,
+    DROP COLUMN IF EXISTS decay_policy;
+
+-- +goose StatementEnd
@@ -0,0 +1,96 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Event boards end at a fixed time: they take no submits from then on, and
+-- rewards are distributed for them once it passed
+ALTER TABLE gaming.leaderboards
+    ADD COLUMN IF NOT EXISTS ends_at TIMESTAMP;
+
+-- Reward tables map final rank bands of a board period to rewards. A table
+-- with a NULL period_start applies to every period of the board; a table for
+-- a specific period overrides it. bands is an ordered JSON array, the first
+-- band matching a player's final rank wins.
+CREATE TABLE IF NOT EXISTS gaming.reward_tables (
+    id SERIAL PRIMARY KEY,
+    game_id VARCHAR(64) NOT NULL,
+    board_id VARCHAR(64) NOT NULL,
+    period_start TIMESTAMP,
+    bands JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_reward_tables_board
+        FOREIGN KEY (game_id, board_id)
+            REFERENCES gaming.leaderboards(game_id, id)
+            ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_reward_tables_period
+    ON gaming.reward_tables(game_id, board_id, period_start)
+    WHERE period_start IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_reward_tables_default
+    ON gaming.reward_tables(game_id, board_id)
+    WHERE period_start IS NULL;
+
+-- One row per distributed period, written in the same transaction as its
+-- grants so a period is distributed exactly once.
+CREATE TABLE IF NOT EXISTS gaming.reward_distributions (
+    game_id VARCHAR(64) NOT NULL,
+    board_id VARCHAR(64) NOT NULL,
+    period_start TIMESTAMP NOT NULL,
+    reward_table_id INT NOT NULL REFERENCES gaming.reward_tables(id) ON DELETE CASCADE,
+    players INT NOT NULL,
+    grants INT NOT NULL,
+    distributed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (game_id, board_id, period_start),
+    CONSTRAINT fk_reward_distributions_board
+        FOREIGN KEY (game_id, board_id)
+            REFERENCES gaming.leaderboards(game_id, id)
+            ON DELETE CASCADE
+);
+
+-- A player receives at most one grant per board period
+CREATE TABLE IF NOT EXISTS gaming.reward_grants (
+    id BIGSERIAL PRIMARY KEY,
+    game_id VARCHAR(64) NOT NULL,
+    board_id VARCHAR(64) NOT NULL,
+    period_start TIMESTAMP NOT NULL,
+    user_id INT NOT NULL,
+    reward_table_id INT NOT NULL REFERENCES gaming.reward_tables(id) ON DELETE CASCADE,
+    rank INT NOT NULL,
+    score BIGINT NOT NULL,
+    item VARCHAR(64) NOT NULL,
+    quantity BIGINT NOT NULL,
+    granted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    claimed_at TIMESTAMP,
+    CONSTRAINT uq_reward_grants_period_user
+        UNIQUE (game_id, board_id, period_start, user_id),
+    CONSTRAINT fk_reward_grants_user
+        FOREIGN KEY (game_id, user_id)
+            REFERENCES gaming.users(game_id, id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_reward_grants_board
+        FOREIGN KEY (game_id, board_id)
+            REFERENCES gaming.leaderboards(game_id, id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reward_grants_user
+    ON gaming.reward_grants(game_id, user_id, granted_at DESC);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS gaming.idx_reward_grants_user;
+DROP TABLE IF EXISTS gaming.reward_grants;
+DROP TABLE IF EXISTS gaming.reward_distributions;
+DROP INDEX IF EXISTS gaming.uq_reward_tables_default;
+DROP INDEX IF EXISTS gaming.uq_reward_tables_period;
+DROP TABLE IF EXISTS gaming.reward_tables;
+
+ALTER TABLE gaming.leaderboards
+    DROP COLUMN IF EXISTS ends_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,73 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Subsystem managing a board; empty for boards created through the API
+ALTER TABLE gaming.leaderboards
+    ADD COLUMN IF NOT EXISTS owner VARCHAR(16) NOT NULL DEFAULT '';
+
+-- Time-limited events. Every tournament scores on its own board. The status
+-- follows from the timestamps: scheduled until registration opens, open
+-- until it starts, running until it ends, then finalizing until the final
+-- standings have been written (finalized_at) and it is closed.
+CREATE TABLE IF NOT EXISTS gaming.tournaments (
+    game_id VARCHAR(64) NOT NULL REFERENCES gaming.games(id) ON DELETE CASCADE,
+    id VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    board_id VARCHAR(64) NOT NULL,
+    registration_opens_at TIMESTAMP NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    max_attempts INT NOT NULL DEFAULT 0,
+    max_entrants INT NOT NULL DEFAULT 0,
+    entrants INT NOT NULL DEFAULT 0,
+    finalized_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (game_id, id),
+    CONSTRAINT uq_tournaments_board UNIQUE (game_id, board_id),
+    CONSTRAINT chk_tournaments_window
+        CHECK (registration_opens_at <= starts_at AND starts_at < ends_at),
+    CONSTRAINT fk_tournaments_board
+        FOREIGN KEY (game_id, board_id)
+            REFERENCES gaming.leaderboards(game_id, id)
+            ON DELETE CASCADE
+);
+
+-- The finalize job looks for ended tournaments that are not closed yet
+CREATE INDEX IF NOT EXISTS idx_tournaments_unfinalized
+    ON gaming.tournaments(game_id, ends_at)
+    WHERE finalized_at IS NULL;
+
+-- Registered players; attempts counts accepted submits
+CREATE TABLE IF NOT EXISTS gaming.tournament_entries (
+    game_id VARCHAR(64) NOT NULL,
+    tournament_id VARCHAR(64) NOT NULL,
+    user_id INT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    registered_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    final_rank INT,
+    final_score BIGINT,
+    PRIMARY KEY (game_id, tournament_id, user_id),
+    CONSTRAINT fk_tournament_entries_tournament
+        FOREIGN KEY (game_id, tournament_id)
+            REFERENCES gaming.tournaments(game_id, id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_tournament_entries_user
+        FOREIGN KEY (game_id, user_id)
+            REFERENCES gaming.users(game_id, id)
+            ON DELETE CASCADE
+);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS gaming.tournament_entries;
+DROP INDEX IF EXISTS gaming.idx_tournaments_unfinalized;
+DROP TABLE IF EXISTS gaming.tournaments;
+
+ALTER TABLE gaming.leaderboards
+    DROP COLUMN IF EXISTS owner;
+
+-- +goose StatementEnd
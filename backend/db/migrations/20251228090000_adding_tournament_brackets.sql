@@ -0,0 +1,73 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Elimination bracket of a tournament, seeded from a leaderboard
+CREATE TABLE IF NOT EXISTS gaming.brackets (
+    game_id VARCHAR(64) NOT NULL,
+    tournament_id VARCHAR(64) NOT NULL,
+    format VARCHAR(16) NOT NULL,
+    seed_board_id VARCHAR(64) NOT NULL,
+    size INT NOT NULL,
+    participants INT NOT NULL,
+    champion_id INT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    PRIMARY KEY (game_id, tournament_id),
+    CONSTRAINT chk_brackets_format CHECK (format IN ('single', 'double')),
+    CONSTRAINT fk_brackets_tournament
+        FOREIGN KEY (game_id, tournament_id)
+            REFERENCES gaming.tournaments(game_id, id)
+            ON DELETE CASCADE
+);
+
+-- Matches are numbered per bracket. next_match_id/next_slot is where the
+-- winner moves on to, loser_match_id/loser_slot where the loser drops into
+-- the losers bracket of a double-elimination bracket.
+CREATE TABLE IF NOT EXISTS gaming.bracket_matches (
+    game_id VARCHAR(64) NOT NULL,
+    tournament_id VARCHAR(64) NOT NULL,
+    id INT NOT NULL,
+    side VARCHAR(16) NOT NULL,
+    round INT NOT NULL,
+    position INT NOT NULL,
+    player1_id INT,
+    player1_seed INT,
+    player1_score BIGINT,
+    player1_session_id INT,
+    player2_id INT,
+    player2_seed INT,
+    player2_score BIGINT,
+    player2_session_id INT,
+    winner_id INT,
+    status VARCHAR(16) NOT NULL,
+    next_match_id INT,
+    next_slot SMALLINT,
+    loser_match_id INT,
+    loser_slot SMALLINT,
+    scheduled_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    PRIMARY KEY (game_id, tournament_id, id),
+    CONSTRAINT chk_bracket_matches_status
+        CHECK (status IN ('pending', 'ready', 'completed', 'bye', 'void', 'cancelled')),
+    CONSTRAINT fk_bracket_matches_bracket
+        FOREIGN KEY (game_id, tournament_id)
+            REFERENCES gaming.brackets(game_id, tournament_id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_bracket_matches_player1
+        FOREIGN KEY (game_id, player1_id)
+            REFERENCES gaming.users(game_id, id),
+    CONSTRAINT fk_bracket_matches_player2
+        FOREIGN KEY (game_id, player2_id)
+            REFERENCES gaming.users(game_id, id)
+);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS gaming.bracket_matches;
+DROP TABLE IF EXISTS gaming.brackets;
+
+-- +goose StatementEnd
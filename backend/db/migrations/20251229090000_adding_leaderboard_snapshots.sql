@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Point-in-time copies of a board period. The standings are stored as a
+-- single compressed blob (gzip of varint user id / score pairs in rank
+-- order) so a snapshot of a large board stays a single small row.
+CREATE TABLE IF NOT EXISTS gaming.leaderboard_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    game_id VARCHAR(64) NOT NULL,
+    board_id VARCHAR(64) NOT NULL,
+    period_start TIMESTAMP NOT NULL,
+    captured_at TIMESTAMP NOT NULL,
+    trigger VARCHAR(16) NOT NULL,
+    players INT NOT NULL,
+    data BYTEA NOT NULL,
+    CONSTRAINT chk_leaderboard_snapshots_trigger CHECK (trigger IN ('scheduled', 'manual')),
+    CONSTRAINT fk_leaderboard_snapshots_board
+        FOREIGN KEY (game_id, board_id)
+            REFERENCES gaming.leaderboards(game_id, id)
+            ON DELETE CASCADE
+);
+
+-- Scheduled runs are keyed by their slot, so reruns do not duplicate them
+CREATE UNIQUE INDEX IF NOT EXISTS idx_leaderboard_snapshots_scheduled
+    ON gaming.leaderboard_snapshots(game_id, board_id, captured_at)
+    WHERE trigger = 'scheduled';
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_snapshots_captured_at
+    ON gaming.leaderboard_snapshots(game_id, board_id, captured_at DESC);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS gaming.idx_leaderboard_snapshots_captured_at;
+DROP INDEX IF EXISTS gaming.idx_leaderboard_snapshots_scheduled;
+DROP TABLE IF EXISTS gaming.leaderboard_snapshots;
+
+-- +goose StatementEnd
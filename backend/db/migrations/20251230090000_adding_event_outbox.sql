@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Domain events written in the same transaction as the change they describe.
+-- The outbox relay publishes pending rows and marks them published; a row
+-- whose lease (next_attempt_at) runs out unpublished is picked up again, so
+-- delivery is at least once. ScoreSubmitted events are written without
+-- ranks (ranks_pending), which the submit records right after it commits;
+-- the relay only counts them for submits that did not.
+CREATE TABLE IF NOT EXISTS gaming.event_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    game_id VARCHAR(64) NOT NULL,
+    event_type VARCHAR(32) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    next_attempt_at TIMESTAMP NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    published_at TIMESTAMP,
+    ranks_pending BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_pending
+    ON gaming.event_outbox(next_attempt_at, id)
+    WHERE published_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_published
+    ON gaming.event_outbox(published_at)
+    WHERE published_at IS NOT NULL;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS gaming.idx_event_outbox_published;
+DROP INDEX IF EXISTS gaming.idx_event_outbox_pending;
+DROP TABLE IF EXISTS gaming.event_outbox;
+
+-- +goose StatementEnd
@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Partner webhook subscriptions. The secret signs every delivery, so it is
+-- stored as is; it is only ever returned when the webhook is created.
+CREATE TABLE IF NOT EXISTS gaming.webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    game_id VARCHAR(64) NOT NULL,
+    url TEXT NOT NULL,
+    event_types JSONB NOT NULL,
+    board_id VARCHAR(64),
+    top_n INT NOT NULL DEFAULT 10,
+    secret VARCHAR(128) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT chk_webhooks_top_n CHECK (top_n > 0),
+    CONSTRAINT fk_webhooks_board
+        FOREIGN KEY (game_id, board_id)
+            REFERENCES gaming.leaderboards(game_id, id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_game
+    ON gaming.webhooks(game_id, id);
+
+-- One row per event and webhook, doubling as the delivery log. event_key
+-- identifies the event, so an event is never queued twice for a webhook.
+-- Pending rows are leased by pushing next_attempt_at forward while a
+-- dispatcher delivers them.
+CREATE TABLE IF NOT EXISTS gaming.webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    game_id VARCHAR(64) NOT NULL,
+    webhook_id BIGINT NOT NULL REFERENCES gaming.webhooks(id) ON DELETE CASCADE,
+    event_type VARCHAR(64) NOT NULL,
+    event_key VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_status_code INT,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL,
+    delivered_at TIMESTAMP,
+    CONSTRAINT chk_webhook_deliveries_status CHECK (status IN ('pending', 'delivered', 'dead')),
+    CONSTRAINT uq_webhook_deliveries_event UNIQUE (webhook_id, event_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending
+    ON gaming.webhook_deliveries(next_attempt_at, id)
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook
+    ON gaming.webhook_deliveries(webhook_id, id DESC);
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS gaming.idx_webhook_deliveries_webhook;
+DROP INDEX IF EXISTS gaming.idx_webhook_deliveries_pending;
+DROP TABLE IF EXISTS gaming.webhook_deliveries;
+DROP INDEX IF EXISTS gaming.idx_webhooks_game;
+DROP TABLE IF EXISTS gaming.webhooks;
+
+-- +goose StatementEnd
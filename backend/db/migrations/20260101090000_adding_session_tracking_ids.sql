@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The tracking id of a submit queued for asynchronous ingestion. A queued
+-- submit can be handed to the ingest workers more than once; the unique
+-- index makes sure only the first attempt to reach the database applies it
+ALTER TABLE gaming.game_sessions
+    ADD COLUMN IF NOT EXISTS tracking_id VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_game_sessions_tracking_id
+    ON gaming.game_sessions(game_id, tracking_id)
+    WHERE tracking_id IS NOT NULL;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS gaming.uq_game_sessions_tracking_id;
+
+ALTER TABLE gaming.game_sessions
+    DROP COLUMN IF EXISTS tracking_id;
+
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Board scores of hot players' submits queued for the write-behind buffer.
+-- Rows are written with the submit's session and taken off the queue in the
+-- transaction that applies them, so a flush that never happened loses
+-- nothing and any instance can apply them
+CREATE TABLE IF NOT EXISTS gaming.buffered_scores (
+    id BIGSERIAL PRIMARY KEY,
+    game_id VARCHAR(64) NOT NULL,
+    board_id VARCHAR(64) NOT NULL,
+    period_start TIMESTAMP NOT NULL,
+    user_id INT NOT NULL,
+    value BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    CONSTRAINT fk_buffered_scores_game_board
+        FOREIGN KEY (game_id, board_id) REFERENCES gaming.leaderboards(game_id, id) ON DELETE CASCADE
+);
+
+-- Submit estimates add up what is still queued for a player's row
+CREATE INDEX IF NOT EXISTS idx_buffered_scores_player
+    ON gaming.buffered_scores(game_id, board_id, period_start, user_id);
+
+-- Buffered sessions whose stats are not folded into player_stats yet. The
+-- flush clears the flag in the transaction that applies them
+ALTER TABLE gaming.game_sessions
+    ADD COLUMN IF NOT EXISTS stats_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_game_sessions_stats_pending
+    ON gaming.game_sessions(id) WHERE stats_pending;
+
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS gaming.idx_game_sessions_stats_pending;
+
+ALTER TABLE gaming.game_sessions
+    DROP COLUMN IF EXISTS stats_pending;
+
+DROP INDEX IF EXISTS gaming.idx_buffered_scores_player;
+DROP TABLE IF EXISTS gaming.buffered_scores;
+
+-- +goose StatementEnd
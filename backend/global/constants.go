@@ -1 +1,10 @@
 package global
+
+const (
+	// DefaultGameID is the tenant existing single-game data was migrated into.
+	DefaultGameID = "default"
+
+	// GameIDHeader and APIKeyHeader identify the tenant (game) of a request.
+	GameIDHeader = "X-Game-Id"
+	APIKeyHeader = "X-Api-Key"
+)
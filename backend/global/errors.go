@@ -1 +1,9 @@
 package global
+
+const (
+	ErrGameRequired  = "GAME_REQUIRED"
+	ErrUnknownGame   = "UNKNOWN_GAME"
+	ErrInvalidAPIKey = "INVALID_API_KEY"
+
+	ErrInternalServer = "INTERNAL_SERVER_ERROR"
+)
@@ -0,0 +1,23 @@
+package global
+
+import (
+	"context"
+	"errors"
+)
+
+type gameIDKey struct{}
+
+// WithGameID returns a context scoped to the given tenant (game).
+func WithGameID(ctx context.Context, gameID string) context.Context {
+	return context.WithValue(ctx, gameIDKey{}, gameID)
+}
+
+// GameID returns the tenant the context is scoped to. Repositories call it
+// for every query, so a context without a tenant can never read or write data.
+func GameID(ctx context.Context) (string, error) {
+	gameID, _ := ctx.Value(gameIDKey{}).(string)
+	if gameID == "" {
+		return "", errors.New(ErrGameRequired)
+	}
+	return gameID, nil
+}
@@ -1 +1,106 @@
 package constants
+
+const (
+	// DefaultBoardID is the board used when a request does not name one.
+	DefaultBoardID = "global"
+
+	AggregationSum    = "sum"
+	AggregationMax    = "max"
+	AggregationMin    = "min"
+	AggregationLatest = "latest"
+	AggregationCount  = "count"
+	// AggregationBest keeps the best score in the board's sort order, e.g. the
+	// fastest lap on an ascending board.
+	AggregationBest = "best"
+
+	SortDescending = "desc"
+	SortAscending  = "asc"
+
+	ScoreUnitPoints       = "points"
+	ScoreUnitMilliseconds = "ms"
+
+	DecayNone     = "none"
+	DecayHalfLife = "half_life"
+	DecayWeekly   = "weekly"
+	// DecayBatchSize is how many board rows a decay run reads at a time.
+	DecayBatchSize = 1000
+
+	ResetNever   = "none"
+	ResetDaily   = "daily"
+	ResetWeekly  = "weekly"
+	ResetMonthly = "monthly"
+
+	TierDirectionPromotion = "promotion"
+	TierDirectionDemotion  = "demotion"
+
+	OutcomeWin  = "win"
+	OutcomeLoss = "loss"
+	OutcomeDraw = "draw"
+
+	RankHistoryHourly = "hourly"
+	RankHistoryDaily  = "daily"
+
+	SnapshotScheduled = "scheduled"
+	SnapshotManual    = "manual"
+
+	MaxSnapshotsPerPage = 100
+
+	NotificationOvertaken  = "overtaken"
+	NotificationEnteredTop = "entered_top"
+	NotificationDroppedTop = "dropped_out_of_top"
+	NotificationBufferSize = 32
+	MaxOvertakenNotified   = 100
+
+	StreamModeTop    = "top"
+	StreamModePage   = "page"
+	StreamModeAround = "around"
+
+	DefaultStreamLimit     = 10
+	MaxStreamLimit         = 100
+	DefaultStreamWindow    = 5
+	MaxStreamWindow        = 50
+	MaxStreamSubscriptions = 20
+
+	// MaxDiffVersionGap is how many versions behind a client may be and
+	// still get a diff instead of the full view.
+	MaxDiffVersionGap = 50
+	// DiffHistorySize is how many past updates each live view keeps to
+	// diff reconnecting subscribers against.
+	DiffHistorySize = 16
+
+	EventScoreSubmitted = "ScoreSubmitted"
+	EventRankChanged    = "RankChanged"
+
+	EventSinkRedis  = "redis"
+	EventSinkHTTP   = "http"
+	EventSinkStdout = "stdout"
+
+	// EventStream is the Redis stream the redis event sink appends to.
+	EventStream     = "leaderboard:events:outbox"
+	OutboxBatchSize = 100
+
+	// BufferFlushBatchSize is how many queued board scores a score buffer
+	// flush applies per transaction.
+	BufferFlushBatchSize = 1000
+
+	IngestModeSync  = "sync"
+	IngestModeAsync = "async"
+
+	SubmissionQueued   = "queued"
+	SubmissionApplied  = "applied"
+	SubmissionRejected = "rejected"
+	SubmissionFailed   = "failed"
+
+	// IngestStream is the Redis stream asynchronous submits are queued on;
+	// the ingest workers share it through the IngestGroup consumer group.
+	IngestStream = "leaderboard:ingest"
+	IngestGroup  = "leaderboard-ingest"
+	// MaxIngestAttempts is how often a queued submit that fails with an
+	// internal error is tried before it is marked failed.
+	MaxIngestAttempts = 5
+
+	// MaxSubmitBatchSize is how many submits one gRPC batch stream may
+	// carry; the stream is answered at the next one, which is refused with
+	// ErrBatchTooLarge.
+	MaxSubmitBatchSize = 1000
+)
@@ -5,4 +5,23 @@ const (
 	ErrUserNotFound   = "USER_NOT_FOUND"
 	ErrInternalServer = "INTERNAL_SERVER_ERROR"
 	ErrInvalidRequest = "INVALID_REQUEST"
+	ErrInvalidOutcome = "INVALID_OUTCOME"
+	ErrInvalidRange   = "INVALID_TIME_RANGE"
+
+	ErrBoardNotFound      = "BOARD_NOT_FOUND"
+	ErrBoardExists        = "BOARD_ALREADY_EXISTS"
+	ErrInvalidBoard       = "INVALID_BOARD"
+	ErrGameModeNotAllowed = "GAME_MODE_NOT_ALLOWED"
+	ErrBoardEnded         = "BOARD_ENDED"
+
+	ErrSnapshotNotFound   = "SNAPSHOT_NOT_FOUND"
+	ErrSubmissionNotFound = "SUBMISSION_NOT_FOUND"
+	ErrSubmissionApplied  = "SUBMISSION_ALREADY_APPLIED"
+	ErrBatchTooLarge      = "BATCH_TOO_LARGE"
+
+	ErrInvalidView          = "INVALID_VIEW"
+	ErrSubscriptionExists   = "SUBSCRIPTION_EXISTS"
+	ErrSubscriptionNotFound = "SUBSCRIPTION_NOT_FOUND"
+	ErrTooManySubscriptions = "TOO_MANY_SUBSCRIPTIONS"
+	ErrSubscriptionEvicted  = "SUBSCRIPTION_EVICTED"
 )
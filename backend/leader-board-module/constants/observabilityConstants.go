@@ -1 +1,12 @@
 package constants
+
+const (
+	// TierEventsChannel is the Redis Pub/Sub channel tier changes are published on.
+	TierEventsChannel = "leaderboard:events:tier"
+	// LeaderboardEventsChannel carries a message for every leaderboard change,
+	// so every instance can refresh its streams.
+	LeaderboardEventsChannel = "leaderboard:events:changed"
+	// RankNotificationsChannel carries per-player rank notifications to the
+	// instance holding the player's stream.
+	RankNotificationsChannel = "leaderboard:events:rank"
+)
@@ -0,0 +1,339 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/repository"
+)
+
+// Board definitions are read on every request but change rarely, so they are
+// cached in-process for a short while.
+const boardCacheTTL = time.Minute
+
+var boardIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+type boardCache struct {
+	mu      sync.RWMutex
+	entries map[string]cachedBoard
+}
+
+type cachedBoard struct {
+	board     *model.Board
+	expiresAt time.Time
+}
+
+func newBoardCache() *boardCache {
+	return &boardCache{entries: make(map[string]cachedBoard)}
+}
+
+// Entries are keyed by game and board, since board ids are only unique per game.
+func (bc *boardCache) get(gameID, boardID string) (*model.Board, bool) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	entry, ok := bc.entries[gameID+"/"+boardID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.board, true
+}
+
+func (bc *boardCache) put(gameID string, board *model.Board) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	bc.entries[gameID+"/"+board.ID] = cachedBoard{board: board, expiresAt: time.Now().Add(boardCacheTTL)}
+}
+
+// resolveBoard loads a board definition, falling back to the default board,
+// which every game is provisioned with, when no id is given. Unknown ids fail
+// with ErrBoardNotFound.
+func (c *LeaderboardCore) resolveBoard(ctx context.Context, boardID string) (*model.Board, error) {
+	if boardID == "" {
+		boardID = constants.DefaultBoardID
+	}
+
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if board, ok := c.boards.get(gameID, boardID); ok {
+		return board, nil
+	}
+
+	board, err := c.repo.GetBoard(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	c.boards.put(gameID, board)
+	return board, nil
+}
+
+// scopeFor returns the repository scope for the board's period containing now.
+func scopeFor(board *model.Board, now time.Time) repository.BoardScope {
+	return repository.BoardScope{
+		BoardID:     board.ID,
+		PeriodStart: PeriodStart(board.ResetSchedule, now),
+		Aggregation: board.Aggregation,
+		SortOrder:   board.SortOrder,
+	}
+}
+
+// PeriodStart returns the start of the reset period containing now, in UTC.
+// Boards that never reset use a single period starting at the Unix epoch.
+func PeriodStart(schedule string, now time.Time) time.Time {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch schedule {
+	case constants.ResetDaily:
+		return day
+	case constants.ResetWeekly:
+		// Weeks start on Monday
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case constants.ResetMonthly:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Unix(0, 0).UTC()
+	}
+}
+
+// ended reports whether the board is an event board that ended by now.
+func ended(board *model.Board, now time.Time) bool {
+	return board.EndsAt != nil && !now.Before(*board.EndsAt)
+}
+
+// validateScore returns a human readable problem with a score submitted to
+// the board, or "". A zero duration would beat every real time on a timed board.
+func validateScore(board *model.Board, score int64) string {
+	if board.ScoreUnit == constants.ScoreUnitMilliseconds && score <= 0 {
+		return fmt.Sprintf("Leaderboard %q expects a duration in milliseconds greater than zero", board.ID)
+	}
+	return ""
+}
+
+// tiered reports whether tiers apply to the board. Tier score thresholds
+// assume higher is better, so lower-is-better boards are not tiered.
+func tiered(board *model.Board) bool {
+	return board.SortOrder != constants.SortAscending
+}
+
+func allowsGameMode(board *model.Board, gameMode string) bool {
+	if len(board.GameModes) == 0 {
+		return true
+	}
+	for _, mode := range board.GameModes {
+		if mode == gameMode {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *LeaderboardCore) CreateBoard(ctx context.Context, req *model.CreateBoardRequest) (*model.BoardResponse, error) {
+	return c.createBoard(ctx, req, "")
+}
+
+// CreateManagedBoard creates a board on behalf of another subsystem, which is
+// recorded as the board's owner.
+func (c *LeaderboardCore) CreateManagedBoard(ctx context.Context, req *model.CreateBoardRequest, owner string) (*model.BoardResponse, error) {
+	return c.createBoard(ctx, req, owner)
+}
+
+func (c *LeaderboardCore) createBoard(ctx context.Context, req *model.CreateBoardRequest, owner string) (*model.BoardResponse, error) {
+	board := &model.Board{
+		ID:            strings.TrimSpace(req.ID),
+		Name:          strings.TrimSpace(req.Name),
+		Aggregation:   req.Aggregation,
+		SortOrder:     req.SortOrder,
+		ScoreUnit:     req.ScoreUnit,
+		ResetSchedule: req.ResetSchedule,
+		GameModes:     []string{},
+		Decay:         req.Decay,
+		Owner:         owner,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	if board.SortOrder == "" {
+		board.SortOrder = constants.SortDescending
+	}
+	// Summing is rarely meaningful when lower is better, e.g. lap times
+	if board.Aggregation == "" {
+		board.Aggregation = constants.AggregationSum
+		if board.SortOrder == constants.SortAscending {
+			board.Aggregation = constants.AggregationBest
+		}
+	}
+	if board.ScoreUnit == "" {
+		board.ScoreUnit = constants.ScoreUnitPoints
+	}
+	if board.ResetSchedule == "" {
+		board.ResetSchedule = constants.ResetNever
+	}
+	if req.EndsAt != nil {
+		endsAt := req.EndsAt.UTC()
+		board.EndsAt = &endsAt
+	}
+
+	if message := validateBoard(board, req.GameModes); message != "" {
+		return &model.BoardResponse{
+			Success: false,
+			Error:   message,
+			Code:    constants.ErrInvalidBoard,
+		}, nil
+	}
+
+	seen := make(map[string]bool, len(req.GameModes))
+	for _, mode := range req.GameModes {
+		mode = strings.TrimSpace(mode)
+		if !seen[mode] {
+			seen[mode] = true
+			board.GameModes = append(board.GameModes, mode)
+		}
+	}
+
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.repo.CreateBoard(ctx, board); err != nil {
+		if err.Error() == constants.ErrBoardExists {
+			return &model.BoardResponse{
+				Success: false,
+				Error:   "A leaderboard with this id already exists",
+				Code:    constants.ErrBoardExists,
+			}, nil
+		}
+		return nil, err
+	}
+	c.boards.put(gameID, board)
+
+	return &model.BoardResponse{
+		Success: true,
+		Data:    board,
+	}, nil
+}
+
+func (c *LeaderboardCore) GetBoard(ctx context.Context, boardID string) (*model.BoardResponse, error) {
+	board, err := c.resolveBoard(ctx, boardID)
+	if err != nil {
+		if err.Error() == constants.ErrBoardNotFound {
+			return &model.BoardResponse{
+				Success: false,
+				Error:   "Leaderboard not found",
+				Code:    constants.ErrBoardNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &model.BoardResponse{
+		Success: true,
+		Data:    board,
+	}, nil
+}
+
+func (c *LeaderboardCore) ListBoards(ctx context.Context) (*model.BoardsResponse, error) {
+	boards, err := c.repo.ListBoards(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.BoardsResponse{
+		Success: true,
+		Boards:  boards,
+	}, nil
+}
+
+// validateBoard returns a human readable problem with the definition, or "".
+func validateBoard(board *model.Board, gameModes []string) string {
+	if !boardIDPattern.MatchString(board.ID) {
+		return "id must be 1-64 lowercase letters, digits, '-' or '_'"
+	}
+	if board.Name == "" || len(board.Name) > 255 {
+		return "name must be between 1 and 255 characters"
+	}
+
+	switch board.Aggregation {
+	case constants.AggregationSum, constants.AggregationMax, constants.AggregationMin,
+		constants.AggregationLatest, constants.AggregationCount, constants.AggregationBest:
+	default:
+		return "aggregation must be one of sum, max, min, latest, count or best"
+	}
+
+	switch board.SortOrder {
+	case constants.SortDescending, constants.SortAscending:
+	default:
+		return "sort_order must be desc or asc"
+	}
+
+	switch board.ScoreUnit {
+	case constants.ScoreUnitPoints, constants.ScoreUnitMilliseconds:
+	default:
+		return "score_unit must be points or ms"
+	}
+
+	switch board.ResetSchedule {
+	case constants.ResetNever, constants.ResetDaily, constants.ResetWeekly, constants.ResetMonthly:
+	default:
+		return "reset_schedule must be one of none, daily, weekly or monthly"
+	}
+
+	for _, mode := range gameModes {
+		mode = strings.TrimSpace(mode)
+		if mode == "" || len(mode) > 50 || strings.Contains(mode, ",") {
+			return "game_modes must be non-empty names without commas"
+		}
+	}
+
+	if board.EndsAt != nil && !board.EndsAt.After(board.CreatedAt) {
+		return "ends_at must be in the future"
+	}
+
+	if board.Decay != nil {
+		return validateDecay(board)
+	}
+
+	return ""
+}
+
+// validateDecay checks a board's decay policy. Decay only makes sense on
+// evergreen boards where higher is better; periodic boards reset instead.
+func validateDecay(board *model.Board) string {
+	decay := board.Decay
+
+	if board.SortOrder != constants.SortDescending || board.ResetSchedule != constants.ResetNever {
+		return "decay is only supported on descending boards that never reset"
+	}
+	if decay.InactiveDays < 0 || decay.InactiveDays > 3650 {
+		return "decay.inactive_days must be between 0 and 3650"
+	}
+
+	switch decay.Type {
+	case constants.DecayHalfLife:
+		if decay.HalfLifeDays <= 0 {
+			return "decay.half_life_days must be positive"
+		}
+		decay.WeeklyDeduction = 0
+	case constants.DecayWeekly:
+		if decay.WeeklyDeduction <= 0 {
+			return "decay.weekly_deduction must be positive"
+		}
+		decay.HalfLifeDays = 0
+	default:
+		return "decay.type must be half_life or weekly"
+	}
+
+	return ""
+}
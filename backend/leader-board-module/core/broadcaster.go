@@ -0,0 +1,371 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+)
+
+const (
+	// streamBufferSize bounds the updates queued for one subscriber; a
+	// subscriber that falls this far behind is evicted.
+	streamBufferSize = 16
+	// streamMinInterval throttles recomputation during bursts of submits.
+	streamMinInterval = 500 * time.Millisecond
+	// streamRecheckInterval is a safety net for changes no event announces,
+	// e.g. period resets or Pub/Sub messages lost while reconnecting.
+	streamRecheckInterval = 5 * time.Second
+)
+
+// LeaderboardBroadcaster fans leaderboard updates out to stream subscribers.
+// Every watched view of a board has a single feed that recomputes the view
+// only when the board changed and sends the same update to everyone.
+type LeaderboardBroadcaster struct {
+	core   *LeaderboardCore
+	logger *providers.ConsoleLogger
+
+	mu sync.Mutex
+	// Feeds by game and board, then by view
+	feeds map[string]map[string]*viewFeed
+}
+
+type viewFeed struct {
+	boardKey    string
+	viewKey     string
+	gameID      string
+	board       *model.Board
+	view        model.LeaderboardView
+	dirty       chan struct{}
+	stop        chan struct{}
+	subscribers map[*StreamSubscription]struct{}
+
+	// Guarded by the broadcaster's mutex. history holds the last full
+	// updates, oldest first, to resume subscribers from.
+	history []*StreamUpdate
+	tracked bool
+
+	// Only touched by the feed's goroutine
+	version     int64
+	sent        int64
+	seq         int64
+	periodStart time.Time
+	encoded     []byte
+	players     []model.PlayerScore
+}
+
+// StreamUpdate is one change of a watched view. Players is the whole view and
+// Diff how it differs from the view at BaseVersion; the first update a
+// subscriber receives has no Diff unless it resumed from a version it had.
+// Payload and DiffPayload are the view and the diff encoded for SSE, shared
+// by all subscribers.
+type StreamUpdate struct {
+	BoardID     string
+	Version     int64
+	BaseVersion int64
+	Players     []model.PlayerScore
+	Diff        *model.LeaderboardDiff
+	Payload     []byte
+	DiffPayload []byte
+}
+
+// StreamSubscription receives the updates of one view. C is closed when the
+// subscriber is evicted for falling behind.
+type StreamSubscription struct {
+	C <-chan *StreamUpdate
+
+	ch   chan *StreamUpdate
+	feed *viewFeed
+}
+
+func newLeaderboardBroadcaster(core *LeaderboardCore, logger *providers.ConsoleLogger) *LeaderboardBroadcaster {
+	return &LeaderboardBroadcaster{
+		core:   core,
+		logger: logger,
+		feeds:  make(map[string]map[string]*viewFeed),
+	}
+}
+
+// SubscribeLeaderboard opens a stream of updates to a view of a board, which
+// must have passed ValidateView. The latest update, if any, is delivered
+// right away: in full, or as a diff when the subscriber already has the view
+// at sinceVersion, or not at all when that is the latest. Unknown boards fail
+// with ErrBoardNotFound; callers must Unsubscribe when done.
+func (c *LeaderboardCore) SubscribeLeaderboard(ctx context.Context, boardID string, view model.LeaderboardView, sinceVersion int64) (*StreamSubscription, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := c.resolveBoard(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.broadcaster.subscribe(gameID, board, view, sinceVersion), nil
+}
+
+// UnsubscribeLeaderboard closes a subscription opened by SubscribeLeaderboard.
+func (c *LeaderboardCore) UnsubscribeLeaderboard(sub *StreamSubscription) {
+	c.broadcaster.unsubscribe(sub)
+}
+
+func (b *LeaderboardBroadcaster) subscribe(gameID string, board *model.Board, view model.LeaderboardView, sinceVersion int64) *StreamSubscription {
+	ch := make(chan *StreamUpdate, streamBufferSize)
+	boardKey := gameID + "/" + board.ID
+	key := viewKey(view)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	views, ok := b.feeds[boardKey]
+	if !ok {
+		views = make(map[string]*viewFeed)
+		b.feeds[boardKey] = views
+	}
+
+	feed, ok := views[key]
+	if !ok {
+		feed = &viewFeed{
+			boardKey:    boardKey,
+			viewKey:     key,
+			gameID:      gameID,
+			board:       board,
+			view:        view,
+			dirty:       make(chan struct{}, 1),
+			stop:        make(chan struct{}),
+			subscribers: make(map[*StreamSubscription]struct{}),
+		}
+		views[key] = feed
+		go b.run(feed)
+	}
+
+	sub := &StreamSubscription{C: ch, ch: ch, feed: feed}
+	feed.subscribers[sub] = struct{}{}
+	if first := b.resumeLocked(feed, sinceVersion); first != nil {
+		ch <- first
+	}
+	return sub
+}
+
+// resumeLocked picks the first update for a subscriber that has the view at
+// sinceVersion. Versions only identify a view across feeds when Redis tracks
+// them; a feed's own counter restarts with the feed.
+func (b *LeaderboardBroadcaster) resumeLocked(feed *viewFeed, sinceVersion int64) *StreamUpdate {
+	if len(feed.history) == 0 {
+		return nil
+	}
+	latest := feed.history[len(feed.history)-1]
+	if !feed.tracked || sinceVersion <= 0 || sinceVersion > latest.Version {
+		return latest
+	}
+	if sinceVersion == latest.Version {
+		return nil
+	}
+	if latest.Version-sinceVersion > constants.MaxDiffVersionGap {
+		return latest
+	}
+
+	for _, past := range feed.history {
+		if past.Version != sinceVersion {
+			continue
+		}
+		resumed := *latest
+		resumed.BaseVersion = sinceVersion
+		resumed.Diff = diffPlayers(past.Players, latest.Players)
+		payload, err := encodeDiff(&resumed)
+		if err != nil {
+			b.logger.Errorf("Failed to marshal leaderboard stream diff | board_id=%s error=%v", latest.BoardID, err)
+			return latest
+		}
+		resumed.DiffPayload = payload
+		return &resumed
+	}
+	return latest
+}
+
+// encodeDiff encodes an update's diff for SSE in the WebSocket message shape.
+func encodeDiff(update *StreamUpdate) ([]byte, error) {
+	return json.Marshal(&model.StreamDiffMessage{
+		Type:            "diff",
+		BoardID:         update.BoardID,
+		Version:         update.Version,
+		BaseVersion:     update.BaseVersion,
+		LeaderboardDiff: *update.Diff,
+	})
+}
+
+func (b *LeaderboardBroadcaster) unsubscribe(sub *StreamSubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.removeLocked(sub)
+}
+
+// removeLocked drops a subscriber and stops its feed once nobody is left.
+func (b *LeaderboardBroadcaster) removeLocked(sub *StreamSubscription) {
+	feed := sub.feed
+	if _, ok := feed.subscribers[sub]; !ok {
+		return
+	}
+	delete(feed.subscribers, sub)
+	close(sub.ch)
+
+	if len(feed.subscribers) > 0 {
+		return
+	}
+	close(feed.stop)
+
+	views := b.feeds[feed.boardKey]
+	delete(views, feed.viewKey)
+	if len(views) == 0 {
+		delete(b.feeds, feed.boardKey)
+	}
+}
+
+// OnScoreSubmitted marks the feeds of every board the submit touched as
+// dirty, without waiting for the change to come back over Pub/Sub.
+func (b *LeaderboardBroadcaster) OnScoreSubmitted(ctx context.Context, event *model.ScoreSubmittedEvent) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, result := range event.Boards {
+		b.markDirty(gameID, result.BoardID)
+	}
+}
+
+// markDirty wakes every feed of the board. Recomputation happens on the
+// feeds' own goroutines, so bursts coalesce.
+func (b *LeaderboardBroadcaster) markDirty(gameID, boardID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, feed := range b.feeds[gameID+"/"+boardID] {
+		select {
+		case feed.dirty <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (b *LeaderboardBroadcaster) run(feed *viewFeed) {
+	ctx := global.WithGameID(context.Background(), feed.gameID)
+
+	ticker := time.NewTicker(streamRecheckInterval)
+	defer ticker.Stop()
+
+	for {
+		b.refresh(ctx, feed)
+
+		select {
+		case <-feed.stop:
+			return
+		case <-time.After(streamMinInterval):
+		}
+
+		select {
+		case <-feed.stop:
+			return
+		case <-feed.dirty:
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh recomputes the feed's view if the board changed since the last
+// update and fans the new update out.
+func (b *LeaderboardBroadcaster) refresh(ctx context.Context, feed *viewFeed) {
+	now := time.Now().UTC()
+	periodStart := PeriodStart(feed.board.ResetSchedule, now)
+
+	version, tracked := b.core.repo.LeaderboardVersion(ctx, feed.board.ID)
+	if tracked && feed.encoded != nil && version == feed.version && periodStart.Equal(feed.periodStart) {
+		return
+	}
+
+	players, err := b.core.readView(ctx, feed.board, feed.view)
+	if err != nil {
+		b.logger.Errorf("Leaderboard stream refresh failed | game_id=%s board_id=%s error=%v", feed.gameID, feed.board.ID, err)
+		return
+	}
+
+	// The SSE payload keeps the shape of the top players response
+	response := &model.GetTopPlayersResponse{
+		Success:   true,
+		BoardID:   feed.board.ID,
+		ScoreUnit: feed.board.ScoreUnit,
+		Players:   players,
+	}
+	if feed.board.ResetSchedule != constants.ResetNever {
+		response.PeriodStart = &periodStart
+	}
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		b.logger.Errorf("Failed to marshal leaderboard stream update | board_id=%s error=%v", feed.board.ID, err)
+		return
+	}
+	feed.version = version
+	feed.periodStart = periodStart
+	// Without version tracking every recheck recomputes; only real changes go out
+	if bytes.Equal(encoded, feed.encoded) {
+		return
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"players":   json.RawMessage(encoded),
+		"updatedAt": now.Format(time.RFC3339),
+	})
+	if err != nil {
+		b.logger.Errorf("Failed to marshal leaderboard stream update | board_id=%s error=%v", feed.board.ID, err)
+		return
+	}
+
+	if !tracked {
+		feed.seq++
+		version = feed.seq
+	}
+	update := &StreamUpdate{
+		BoardID: feed.board.ID,
+		Version: version,
+		Players: players,
+		Payload: payload,
+	}
+	snapshot := *update
+	if feed.encoded != nil {
+		update.BaseVersion = feed.sent
+		update.Diff = diffPlayers(feed.players, players)
+		if update.DiffPayload, err = encodeDiff(update); err != nil {
+			// Subscribers get the full view instead
+			b.logger.Errorf("Failed to marshal leaderboard stream diff | board_id=%s error=%v", feed.board.ID, err)
+			update.BaseVersion, update.Diff = 0, nil
+		}
+	}
+	feed.encoded = encoded
+	feed.players = players
+	feed.sent = version
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	feed.tracked = tracked
+	feed.history = append(feed.history, &snapshot)
+	if len(feed.history) > constants.DiffHistorySize {
+		feed.history = feed.history[len(feed.history)-constants.DiffHistorySize:]
+	}
+	for sub := range feed.subscribers {
+		select {
+		case sub.ch <- update:
+		default:
+			b.logger.Warnf("Evicting slow leaderboard stream subscriber | game_id=%s board_id=%s", feed.gameID, feed.board.ID)
+			b.removeLocked(sub)
+		}
+	}
+}
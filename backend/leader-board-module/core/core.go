@@ -4,6 +4,9 @@ package core
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"time"
 
 	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
 	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
@@ -12,83 +15,403 @@ import (
 )
 
 type LeaderboardCore struct {
-	repo   *repository.LeaderboardRepository
-	logger *providers.ConsoleLogger
+	repo        *repository.LeaderboardRepository
+	tiers       *TierEvaluator
+	boards      *boardCache
+	listeners   []ScoreListener
+	guards      []SubmitGuard
+	notifier    *RankNotifier
+	broadcaster *LeaderboardBroadcaster
+	buffer      *ScoreBuffer
+	asyncIngest bool
+	logger      *providers.ConsoleLogger
+}
+
+// ScoreListener is notified synchronously after every successful submit.
+// Listeners must not fail the submit; they log and swallow their own errors.
+type ScoreListener interface {
+	OnScoreSubmitted(ctx context.Context, event *model.ScoreSubmittedEvent)
+}
+
+// SubmitGuard can refuse a submit before the score is stored, e.g. to enforce
+// event rules on the boards it owns. A guard that reserved something for an
+// accepted submit gets ReleaseSubmit if storing the score fails afterwards.
+type SubmitGuard interface {
+	ReserveSubmit(ctx context.Context, userID int64, boards []*model.Board, at time.Time) (*model.SubmitRejection, error)
+	ReleaseSubmit(ctx context.Context, userID int64, boards []*model.Board)
 }
 
 type ILeaderboardCore interface {
 	SubmitScore(ctx context.Context, req *model.SubmitScoreRequest) (*model.SubmitScoreResponse, error)
-	GetTopPlayers(ctx context.Context, limit int) (*model.GetTopPlayersResponse, error)
-	GetPlayerRank(ctx context.Context, userID int64) (*model.PlayerRankResponse, error)
+	GetTopPlayers(ctx context.Context, boardID string, limit int) (*model.GetTopPlayersResponse, error)
+	GetPlayerRank(ctx context.Context, boardID string, userID int64) (*model.PlayerRankResponse, error)
+	GetFriendsLeaderboard(ctx context.Context, boardID string, userID int64) (*model.FriendsLeaderboardResponse, error)
 }
 
-func NewLeaderboardCore(repo *repository.LeaderboardRepository, logger *providers.ConsoleLogger) *LeaderboardCore {
-	return &LeaderboardCore{
+func NewLeaderboardCore(repo *repository.LeaderboardRepository, tiers *TierEvaluator, logger *providers.ConsoleLogger) *LeaderboardCore {
+	c := &LeaderboardCore{
 		repo:   repo,
+		tiers:  tiers,
+		boards: newBoardCache(),
 		logger: logger,
 	}
+	c.notifier = newRankNotifier(c, logger)
+	c.broadcaster = newLeaderboardBroadcaster(c, logger)
+	c.RegisterListener(c.notifier)
+	c.RegisterListener(c.broadcaster)
+	return c
+}
+
+// RegisterListener subscribes a listener to score submissions.
+func (c *LeaderboardCore) RegisterListener(listener ScoreListener) {
+	c.listeners = append(c.listeners, listener)
+}
+
+// RegisterSubmitGuard adds a guard that is consulted before every submit.
+func (c *LeaderboardCore) RegisterSubmitGuard(guard SubmitGuard) {
+	c.guards = append(c.guards, guard)
 }
 
 func (c *LeaderboardCore) SubmitScore(ctx context.Context, req *model.SubmitScoreRequest) (*model.SubmitScoreResponse, error) {
-	if req.Score < 0 {
+	now := time.Now().UTC()
+	boards, scopes, rejection, err := c.prepareSubmit(ctx, req, now)
+	if err != nil || rejection != nil {
+		return rejection, err
+	}
+	if rejection, err := c.reserveSubmit(ctx, req.UserID, boards, now); err != nil || rejection != nil {
+		return rejection, err
+	}
+
+	var submission *repository.ScoreSubmission
+	if c.buffer != nil && c.buffer.hot(ctx, req.UserID, now) {
+		submission, err = c.buffer.submit(ctx, req, scopes)
+	} else {
+		submission, err = c.repo.SubmitScore(
+			ctx,
+			req.UserID,
+			req.Score,
+			req.GameMode,
+			req.Outcome,
+			req.TrackingID,
+			scopes,
+		)
+	}
+	if err != nil {
+		c.releaseSubmit(ctx, req.UserID, boards)
+		return refusedSubmit(err)
+	}
+
+	return c.completeSubmit(ctx, req, boards, scopes, submission), nil
+}
+
+// reserveSubmit consults the submit guards. A submit one of them refuses gets
+// a response, and the reservations of the guards before it are released.
+func (c *LeaderboardCore) reserveSubmit(ctx context.Context, userID int64, boards []*model.Board, now time.Time) (*model.SubmitScoreResponse, error) {
+	for i, guard := range c.guards {
+		rejection, err := guard.ReserveSubmit(ctx, userID, boards, now)
+		if err == nil && rejection == nil {
+			continue
+		}
+		for _, reserved := range c.guards[:i] {
+			reserved.ReleaseSubmit(ctx, userID, boards)
+		}
+		if err != nil {
+			return nil, err
+		}
 		return &model.SubmitScoreResponse{
 			Success: false,
-			Error:   "Invalid score",
-			Code:    constants.ErrInvalidScore,
+			Error:   rejection.Message,
+			Code:    rejection.Code,
+		}, nil
+	}
+	return nil, nil
+}
+
+// releaseSubmit hands back what the guards reserved for a submit that was
+// not stored.
+func (c *LeaderboardCore) releaseSubmit(ctx context.Context, userID int64, boards []*model.Board) {
+	for _, guard := range c.guards {
+		guard.ReleaseSubmit(ctx, userID, boards)
+	}
+}
+
+// refusedSubmit turns the repository refusing a submit into its response.
+// Other errors are returned as they are.
+func refusedSubmit(err error) (*model.SubmitScoreResponse, error) {
+	switch err.Error() {
+	case constants.ErrUserNotFound:
+		return &model.SubmitScoreResponse{
+			Success: false,
+			Error:   "User not found",
+			Code:    constants.ErrUserNotFound,
+		}, nil
+	case constants.ErrSubmissionApplied:
+		return &model.SubmitScoreResponse{
+			Success: false,
+			Error:   "Submission was already applied",
+			Code:    constants.ErrSubmissionApplied,
 		}, nil
 	}
+	return nil, err
+}
+
+// completeSubmit ranks a stored submit, records its ranks, notifies the
+// listeners and builds its response. The score is already stored, so
+// nothing here may fail the submit.
+func (c *LeaderboardCore) completeSubmit(
+	ctx context.Context,
+	req *model.SubmitScoreRequest,
+	boards []*model.Board,
+	scopes []repository.BoardScope,
+	submission *repository.ScoreSubmission,
+) *model.SubmitScoreResponse {
+	data := &model.ScoreData{
+		UserID:    req.UserID,
+		Score:     req.Score,
+		Timestamp: submission.Timestamp,
+		Boards:    make([]model.BoardScore, 0, len(submission.Boards)),
+	}
+	event := &model.ScoreSubmittedEvent{
+		UserID:    req.UserID,
+		Score:     req.Score,
+		GameMode:  req.GameMode,
+		Outcome:   req.Outcome,
+		Boards:    make([]model.BoardSubmitEvent, 0, len(submission.Boards)),
+		Timestamp: submission.Timestamp,
+	}
 
-	timestamp, err := c.repo.SubmitScore(
-		ctx,
-		req.UserID,
-		req.Score,
-		req.GameMode,
-	)
-	if err != nil {
-		return nil, err
+	ranks := make([]repository.SubmitRank, 0, len(submission.Boards))
+	ranked := true
+	for i, result := range submission.Boards {
+		scope := scopes[i]
+		tiers := c.tiersFor(boards[i])
+
+		// Counted after the commit, so the submit holds no locks while scanning the board
+		rank, previousRank, err := c.submitRanks(ctx, scope, req.UserID, result)
+		if err != nil {
+			c.logger.Warnf("Failed to resolve rank after submit | board_id=%s user_id=%d error=%v", scope.BoardID, req.UserID, err)
+			rank, previousRank, ranked = 0, 0, false
+		}
+		ranks = append(ranks, repository.SubmitRank{BoardID: result.BoardID, Rank: rank, PreviousRank: previousRank})
+
+		boardScore := model.BoardScore{
+			BoardID:    result.BoardID,
+			TotalScore: result.TotalScore,
+			Rank:       rank,
+		}
+		if tiers != nil {
+			tier, change, err := c.evaluateTierChange(ctx, tiers, scope, req.UserID, rank, submission.Timestamp, result)
+			if err != nil {
+				c.logger.Warnf("Failed to evaluate tier | board_id=%s user_id=%d error=%v", scope.BoardID, req.UserID, err)
+			}
+			boardScore.Tier = tier
+			boardScore.TierChange = change
+		}
+		data.Boards = append(data.Boards, boardScore)
+
+		event.Boards = append(event.Boards, model.BoardSubmitEvent{
+			BoardID:       result.BoardID,
+			PeriodStart:   scope.PeriodStart,
+			TotalScore:    result.TotalScore,
+			PreviousScore: result.PreviousScore,
+			Rank:          rank,
+			PreviousRank:  previousRank,
+			IsNewPlayer:   result.IsNewPlayer,
+		})
+	}
+
+	// Without every rank the relay counts them once the grace period is over
+	if ranked {
+		if err := c.repo.RecordSubmitRanks(ctx, submission.EventID, ranks); err != nil {
+			c.logger.Warnf("Failed to record submit ranks | event_id=%d user_id=%d error=%v", submission.EventID, req.UserID, err)
+		}
+	}
+
+	for _, listener := range c.listeners {
+		listener.OnScoreSubmitted(ctx, event)
 	}
 
 	return &model.SubmitScoreResponse{
 		Success: true,
 		Message: "Score submitted successfully",
-		Data: &model.ScoreData{
-			UserID:    req.UserID,
-			Score:     req.Score,
-			Timestamp: timestamp,
-		},
-	}, nil
+		Data:    data,
+	}
+}
+
+// submitRanks counts the player's rank on a board right after a submit, and
+// the rank their previous score held against the same standings.
+func (c *LeaderboardCore) submitRanks(ctx context.Context, scope repository.BoardScope, userID int64, result repository.BoardSubmission) (int, int, error) {
+	rank, err := c.repo.RankOf(ctx, scope, userID, result.TotalScore)
+	if err != nil || result.IsNewPlayer {
+		return rank, 0, err
+	}
+	previousRank, err := c.repo.RankOf(ctx, scope, userID, result.PreviousScore)
+	if err != nil {
+		return 0, 0, err
+	}
+	return rank, previousRank, nil
+}
+
+// prepareSubmit validates a submit and resolves the boards it targets at now.
+// A submit that must be refused gets a response instead of boards.
+func (c *LeaderboardCore) prepareSubmit(ctx context.Context, req *model.SubmitScoreRequest, now time.Time) ([]*model.Board, []repository.BoardScope, *model.SubmitScoreResponse, error) {
+	if req.Score < 0 {
+		return nil, nil, &model.SubmitScoreResponse{
+			Success: false,
+			Error:   "Invalid score",
+			Code:    constants.ErrInvalidScore,
+		}, nil
+	}
+
+	switch req.Outcome {
+	case "", constants.OutcomeWin, constants.OutcomeLoss, constants.OutcomeDraw:
+	default:
+		return nil, nil, &model.SubmitScoreResponse{
+			Success: false,
+			Error:   "Outcome must be one of win, loss or draw",
+			Code:    constants.ErrInvalidOutcome,
+		}, nil
+	}
+
+	boardIDs := req.BoardIDs
+	if len(boardIDs) == 0 {
+		boardIDs = []string{constants.DefaultBoardID}
+	}
+
+	seen := make(map[string]bool, len(boardIDs))
+	boards := make([]*model.Board, 0, len(boardIDs))
+	scopes := make([]repository.BoardScope, 0, len(boardIDs))
+	for _, boardID := range boardIDs {
+		if seen[boardID] {
+			continue
+		}
+		seen[boardID] = true
+
+		board, err := c.resolveBoard(ctx, boardID)
+		if err != nil {
+			if err.Error() == constants.ErrBoardNotFound {
+				return nil, nil, &model.SubmitScoreResponse{
+					Success: false,
+					Error:   fmt.Sprintf("Leaderboard %q not found", boardID),
+					Code:    constants.ErrBoardNotFound,
+				}, nil
+			}
+			return nil, nil, nil, err
+		}
+		if ended(board, now) {
+			return nil, nil, &model.SubmitScoreResponse{
+				Success: false,
+				Error:   fmt.Sprintf("Leaderboard %q has ended", board.ID),
+				Code:    constants.ErrBoardEnded,
+			}, nil
+		}
+		if !allowsGameMode(board, req.GameMode) {
+			return nil, nil, &model.SubmitScoreResponse{
+				Success: false,
+				Error:   fmt.Sprintf("Leaderboard %q does not accept game mode %q", board.ID, req.GameMode),
+				Code:    constants.ErrGameModeNotAllowed,
+			}, nil
+		}
+		if message := validateScore(board, req.Score); message != "" {
+			return nil, nil, &model.SubmitScoreResponse{
+				Success: false,
+				Error:   message,
+				Code:    constants.ErrInvalidScore,
+			}, nil
+		}
+		boards = append(boards, board)
+		scopes = append(scopes, scopeFor(board, now))
+	}
+
+	return boards, scopes, nil, nil
+}
+
+func (c *LeaderboardCore) GetTopPlayers(ctx context.Context, boardID string, limit int) (*model.GetTopPlayersResponse, error) {
+	return c.GetTopPlayersSince(ctx, boardID, limit, 0)
 }
 
-func (c *LeaderboardCore) GetTopPlayers(ctx context.Context, limit int) (*model.GetTopPlayersResponse, error) {
+// GetTopPlayersSince is GetTopPlayers for a client that already has the top
+// players at sinceVersion: only the players whose rank or score changed are
+// returned, as a diff. Clients too far behind, or whose version is no longer
+// cached, get the full list instead.
+func (c *LeaderboardCore) GetTopPlayersSince(ctx context.Context, boardID string, limit int, sinceVersion int64) (*model.GetTopPlayersResponse, error) {
 	if limit <= 0 {
 		limit = 10 // Default to 10 if invalid limit provided
 	}
 
-	entries, err := c.repo.GetTopPlayers(ctx, limit)
+	board, err := c.resolveBoard(ctx, boardID)
+	if err != nil {
+		if err.Error() == constants.ErrBoardNotFound {
+			return &model.GetTopPlayersResponse{
+				Success: false,
+				Error:   "Leaderboard not found",
+				Code:    constants.ErrBoardNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+	scope := scopeFor(board, time.Now())
+
+	entries, version, err := c.repo.GetVersionedTopPlayers(ctx, scope, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	players := make([]model.PlayerScore, 0, len(entries))
-	for _, entry := range entries {
-		players = append(players, model.PlayerScore{
-			UserID: entry.UserID,
-			Score:  entry.TotalScore,
-		})
+	players, err := c.rankEntries(ctx, board, scope, entries, 0)
+	if err != nil {
+		return nil, err
 	}
 
-	return &model.GetTopPlayersResponse{
-		Success: true,
-		Players: players,
-	}, nil
+	response := &model.GetTopPlayersResponse{
+		Success:   true,
+		BoardID:   board.ID,
+		ScoreUnit: board.ScoreUnit,
+		Version:   version,
+		Players:   players,
+	}
+	if board.ResetSchedule != constants.ResetNever {
+		response.PeriodStart = &scope.PeriodStart
+	}
+
+	// Versions are per board, not per period, so an older version may belong
+	// to the previous period; its cached entries are keyed by period and miss.
+	if version == 0 || sinceVersion <= 0 || sinceVersion > version || version-sinceVersion > constants.MaxDiffVersionGap {
+		return response, nil
+	}
+	previousEntries, ok := c.repo.GetCachedTopPlayers(ctx, scope, sinceVersion, limit)
+	if !ok {
+		return response, nil
+	}
+	previous, err := c.rankEntries(ctx, board, scope, previousEntries, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	response.BaseVersion = sinceVersion
+	response.Diff = diffPlayers(previous, players)
+	response.Players = nil
+	return response, nil
 }
 
-func (c *LeaderboardCore) GetPlayerRank(ctx context.Context, userID int64) (*model.PlayerRankResponse, error) {
+func (c *LeaderboardCore) GetPlayerRank(ctx context.Context, boardID string, userID int64) (*model.PlayerRankResponse, error) {
 	if userID <= 0 {
 		return nil, errors.New("invalid user ID")
 	}
 
-	rank, err := c.repo.GetPlayerRank(ctx, userID)
+	board, err := c.resolveBoard(ctx, boardID)
+	if err != nil {
+		if err.Error() == constants.ErrBoardNotFound {
+			return &model.PlayerRankResponse{
+				Success: false,
+				Error:   "Leaderboard not found",
+				Code:    constants.ErrBoardNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+	scope := scopeFor(board, time.Now())
+
+	rank, err := c.repo.GetPlayerRank(ctx, scope, userID)
 	if err != nil {
 		if err.Error() == constants.ErrUserNotFound {
 			return &model.PlayerRankResponse{
@@ -100,12 +423,199 @@ func (c *LeaderboardCore) GetPlayerRank(ctx context.Context, userID int64) (*mod
 		return nil, err
 	}
 
+	totalPlayers, err := c.repo.TotalPlayers(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	// The maintained count can briefly lag behind a brand new player's rank
+	if totalPlayers < rank.Rank {
+		totalPlayers = rank.Rank
+	}
+
 	return &model.PlayerRankResponse{
 		Success: true,
 		Data: &model.PlayerRankData{
+			BoardID:      board.ID,
+			ScoreUnit:    board.ScoreUnit,
+			UserID:       rank.UserID,
+			Rank:         rank.Rank,
+			Score:        rank.Score,
+			TotalPlayers: totalPlayers,
+			Percentile:   percentile(rank.Rank, totalPlayers),
+			Tier:         tierFor(c.tiersFor(board), rank.Score, rank.Rank, totalPlayers),
+		},
+	}, nil
+}
+
+// GetFriendsLeaderboard ranks the user and their accepted friends against each other.
+func (c *LeaderboardCore) GetFriendsLeaderboard(ctx context.Context, boardID string, userID int64) (*model.FriendsLeaderboardResponse, error) {
+	if userID <= 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	board, err := c.resolveBoard(ctx, boardID)
+	if err != nil {
+		if err.Error() == constants.ErrBoardNotFound {
+			return &model.FriendsLeaderboardResponse{
+				Success: false,
+				Error:   "Leaderboard not found",
+				Code:    constants.ErrBoardNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+
+	ranks, err := c.repo.GetFriendsLeaderboard(ctx, scopeFor(board, time.Now()), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	players := make([]model.PlayerScore, 0, len(ranks))
+	for _, rank := range ranks {
+		players = append(players, model.PlayerScore{
 			UserID: rank.UserID,
 			Rank:   rank.Rank,
 			Score:  rank.Score,
-		},
+		})
+	}
+
+	return &model.FriendsLeaderboardResponse{
+		Success:   true,
+		BoardID:   board.ID,
+		ScoreUnit: board.ScoreUnit,
+		UserID:    userID,
+		Players:   players,
 	}, nil
 }
+
+// percentile returns the share of ranked players placed at or below the
+// given rank, rounded to two decimals. The leader, including the only player
+// on a board, is the 100th percentile and last place of 1,000 is the 0.1th.
+func percentile(rank, totalPlayers int) float64 {
+	if totalPlayers <= 0 || rank <= 0 || rank > totalPlayers {
+		return 0
+	}
+	value := float64(totalPlayers-rank+1) / float64(totalPlayers) * 100
+	return math.Round(value*100) / 100
+}
+
+/* ============================
+   Tiers
+============================ */
+
+// tiersFor returns the tier evaluator for the board, or nil if it is not tiered.
+func (c *LeaderboardCore) tiersFor(board *model.Board) *TierEvaluator {
+	if c.tiers == nil || !tiered(board) {
+		return nil
+	}
+	return c.tiers
+}
+
+func tierFor(tiers *TierEvaluator, score int64, rank, totalPlayers int) *model.TierInfo {
+	if tiers == nil {
+		return nil
+	}
+	return tiers.Evaluate(score, rank, totalPlayers)
+}
+
+// totalPlayersForTiers only looks up the player count when a percentile tier needs it.
+func totalPlayersForTiers(ctx context.Context, repo *repository.LeaderboardRepository, tiers *TierEvaluator, scope repository.BoardScope) (int, error) {
+	if tiers == nil || !tiers.NeedsRank() {
+		return 0, nil
+	}
+	return repo.TotalPlayers(ctx, scope)
+}
+
+// evaluateTierChange works out the player's tier on a board before and after
+// the submit and publishes an event when the submit moved them across a tier
+// boundary.
+func (c *LeaderboardCore) evaluateTierChange(
+	ctx context.Context,
+	tiers *TierEvaluator,
+	scope repository.BoardScope,
+	userID int64,
+	rank int,
+	occurredAt time.Time,
+	result repository.BoardSubmission,
+) (*model.TierInfo, *model.TierChangeEvent, error) {
+	var previousRank, totalPlayers int
+	if tiers.NeedsRank() {
+		if rank == 0 {
+			return nil, nil, errors.New("rank is required for percentile tiers")
+		}
+
+		var err error
+		if totalPlayers, err = c.repo.TotalPlayers(ctx, scope); err != nil {
+			return nil, nil, err
+		}
+
+		if !result.IsNewPlayer {
+			ahead, err := c.repo.CountPlayersAhead(ctx, scope, result.PreviousScore)
+			if err != nil {
+				return nil, nil, err
+			}
+			// The player's own updated row is now ahead of their previous score
+			if isBetter(scope.SortOrder, result.TotalScore, result.PreviousScore) {
+				ahead--
+			}
+			previousRank = ahead + 1
+		}
+	}
+
+	current := tiers.Evaluate(result.TotalScore, rank, totalPlayers)
+	if result.IsNewPlayer {
+		return current, nil, nil
+	}
+
+	previous := tiers.Evaluate(result.PreviousScore, previousRank, totalPlayers)
+	diff := tiers.Compare(current, previous)
+	if diff == 0 {
+		return current, nil, nil
+	}
+
+	direction := constants.TierDirectionPromotion
+	if diff < 0 {
+		direction = constants.TierDirectionDemotion
+	}
+
+	event := &model.TierChangeEvent{
+		BoardID:    scope.BoardID,
+		UserID:     userID,
+		Direction:  direction,
+		From:       previous,
+		To:         current,
+		Score:      result.TotalScore,
+		Rank:       rank,
+		OccurredAt: occurredAt,
+	}
+
+	c.logger.Infof(
+		"Tier %s | board_id=%s user_id=%d from=%s to=%s",
+		direction,
+		scope.BoardID,
+		userID,
+		tierLabel(previous),
+		tierLabel(current),
+	)
+
+	if err := c.repo.PublishTierChange(ctx, event); err != nil {
+		c.logger.Warnf("Failed to publish tier change | board_id=%s user_id=%d error=%v", scope.BoardID, userID, err)
+	}
+
+	return current, event, nil
+}
+
+// isBetter reports whether score a ranks ahead of score b on a board.
+func isBetter(sortOrder string, a, b int64) bool {
+	if sortOrder == constants.SortAscending {
+		return a < b
+	}
+	return a > b
+}
+
+func tierLabel(info *model.TierInfo) string {
+	if info == nil {
+		return "unranked"
+	}
+	return info.Label
+}
@@ -1 +1,434 @@
 package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/repository"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+)
+
+func TestTierEvaluatorEvaluate(t *testing.T) {
+	evaluator, err := NewTierEvaluator(DefaultTierDefinitions())
+	if err != nil {
+		t.Fatalf("NewTierEvaluator() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		score        int64
+		rank         int
+		totalPlayers int
+		want         string
+	}{
+		{name: "lowest score", score: 0, want: "Bronze III"},
+		{name: "top of bronze", score: 2499, want: "Bronze I"},
+		{name: "bottom of silver", score: 2500, want: "Silver III"},
+		{name: "middle of gold", score: 17500, want: "Gold II"},
+		{name: "open-ended top score tier has no divisions", score: 1000000, want: "Diamond"},
+		{name: "unranked player falls back to score tiers", score: 60000, rank: 0, totalPlayers: 1000, want: "Diamond"},
+		{name: "top percent", score: 10, rank: 1, totalPlayers: 1000, want: "Grandmaster"},
+		{name: "upper edge of percent band", score: 10, rank: 10, totalPlayers: 1000, want: "Grandmaster"},
+		{name: "next percent band", score: 10, rank: 11, totalPlayers: 1000, want: "Master"},
+		{name: "below percent bands", score: 10, rank: 51, totalPlayers: 1000, want: "Bronze III"},
+		{name: "sole player", score: 0, rank: 1, totalPlayers: 1, want: "Bronze III"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluator.Evaluate(tt.score, tt.rank, tt.totalPlayers)
+			if got == nil {
+				t.Fatalf("Evaluate() = nil, want %q", tt.want)
+			}
+			if got.Label != tt.want {
+				t.Errorf("Evaluate() = %q, want %q", got.Label, tt.want)
+			}
+		})
+	}
+}
+
+func TestTierEvaluatorEvaluateNoMatch(t *testing.T) {
+	minScore := int64(100)
+	evaluator, err := NewTierEvaluator([]model.TierDefinition{{Name: "Ranked", MinScore: &minScore}})
+	if err != nil {
+		t.Fatalf("NewTierEvaluator() error = %v", err)
+	}
+	if got := evaluator.Evaluate(99, 0, 0); got != nil {
+		t.Errorf("Evaluate() = %+v, want nil", got)
+	}
+}
+
+func TestTierEvaluatorCompare(t *testing.T) {
+	evaluator, err := NewTierEvaluator(DefaultTierDefinitions())
+	if err != nil {
+		t.Fatalf("NewTierEvaluator() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		a, b *model.TierInfo
+		want int // sign only
+	}{
+		{name: "better tier", a: &model.TierInfo{Name: "Gold", Division: "III"}, b: &model.TierInfo{Name: "Silver", Division: "I"}, want: 1},
+		{name: "better division", a: &model.TierInfo{Name: "Gold", Division: "I"}, b: &model.TierInfo{Name: "Gold", Division: "II"}, want: 1},
+		{name: "same", a: &model.TierInfo{Name: "Gold", Division: "II"}, b: &model.TierInfo{Name: "Gold", Division: "II"}, want: 0},
+		{name: "nil is worst", a: nil, b: &model.TierInfo{Name: "Bronze", Division: "III"}, want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluator.Compare(tt.a, tt.b)
+			if sign(got) != tt.want {
+				t.Errorf("Compare() = %d, want sign %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTierEvaluatorValidation(t *testing.T) {
+	percent := func(p float64) *float64 { return &p }
+	points := func(s int64) *int64 { return &s }
+
+	tests := []struct {
+		name    string
+		defs    []model.TierDefinition
+		wantErr bool
+	}{
+		{name: "defaults", defs: DefaultTierDefinitions()},
+		{name: "empty", defs: nil, wantErr: true},
+		{name: "unnamed", defs: []model.TierDefinition{{MinScore: points(0)}}, wantErr: true},
+		{name: "duplicate", defs: []model.TierDefinition{{Name: "A", MinScore: points(10)}, {Name: "A", MinScore: points(0)}}, wantErr: true},
+		{name: "both thresholds", defs: []model.TierDefinition{{Name: "A", MinScore: points(0), TopPercent: percent(1)}}, wantErr: true},
+		{name: "no threshold", defs: []model.TierDefinition{{Name: "A"}}, wantErr: true},
+		{name: "too many divisions", defs: []model.TierDefinition{{Name: "A", MinScore: points(10)}, {Name: "B", MinScore: points(0), Divisions: 6}}, wantErr: true},
+		{name: "divisions on top score tier", defs: []model.TierDefinition{{Name: "A", MinScore: points(10), Divisions: 3}, {Name: "B", MinScore: points(0)}}, wantErr: true},
+		{name: "divisions on top score tier below percent tiers", defs: []model.TierDefinition{{Name: "A", TopPercent: percent(1)}, {Name: "B", MinScore: points(0), Divisions: 2}}, wantErr: true},
+		{name: "single division on top score tier", defs: []model.TierDefinition{{Name: "A", MinScore: points(10), Divisions: 1}}},
+		{name: "min scores not decreasing", defs: []model.TierDefinition{{Name: "A", MinScore: points(10)}, {Name: "B", MinScore: points(10)}}, wantErr: true},
+		{name: "percents not increasing", defs: []model.TierDefinition{{Name: "A", TopPercent: percent(5)}, {Name: "B", TopPercent: percent(1)}}, wantErr: true},
+		{name: "percent above 100", defs: []model.TierDefinition{{Name: "A", TopPercent: percent(101)}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTierEvaluator(tt.defs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTierEvaluator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name         string
+		rank         int
+		totalPlayers int
+		want         float64
+	}{
+		{name: "sole player", rank: 1, totalPlayers: 1, want: 100},
+		{name: "leader", rank: 1, totalPlayers: 1000, want: 100},
+		{name: "last place", rank: 1000, totalPlayers: 1000, want: 0.1},
+		{name: "last of two", rank: 2, totalPlayers: 2, want: 50},
+		{name: "rounded to two decimals", rank: 2, totalPlayers: 3, want: 66.67},
+		{name: "no players", rank: 1, totalPlayers: 0, want: 0},
+		{name: "unranked", rank: 0, totalPlayers: 10, want: 0},
+		{name: "rank beyond count", rank: 11, totalPlayers: 10, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.rank, tt.totalPlayers); got != tt.want {
+				t.Errorf("percentile(%d, %d) = %v, want %v", tt.rank, tt.totalPlayers, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPeriodStart(t *testing.T) {
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	plusThree := time.FixedZone("UTC+3", 3*60*60)
+
+	tests := []struct {
+		name     string
+		schedule string
+		now      time.Time
+		want     time.Time
+	}{
+		{name: "daily", schedule: constants.ResetDaily, now: date(2024, time.May, 15, 17), want: date(2024, time.May, 15, 0)},
+		{name: "daily at midnight", schedule: constants.ResetDaily, now: date(2024, time.May, 15, 0), want: date(2024, time.May, 15, 0)},
+		{name: "weekly midweek", schedule: constants.ResetWeekly, now: date(2024, time.May, 15, 17), want: date(2024, time.May, 13, 0)},
+		{name: "weekly on monday", schedule: constants.ResetWeekly, now: date(2024, time.May, 13, 9), want: date(2024, time.May, 13, 0)},
+		{name: "weekly on sunday", schedule: constants.ResetWeekly, now: date(2024, time.May, 19, 23), want: date(2024, time.May, 13, 0)},
+		{name: "weekly across months", schedule: constants.ResetWeekly, now: date(2024, time.June, 1, 12), want: date(2024, time.May, 27, 0)},
+		{name: "monthly", schedule: constants.ResetMonthly, now: date(2024, time.February, 29, 12), want: date(2024, time.February, 1, 0)},
+		{name: "never", schedule: constants.ResetNever, now: date(2024, time.May, 15, 17), want: time.Unix(0, 0).UTC()},
+		{name: "unknown schedule never resets", schedule: "hourly", now: date(2024, time.May, 15, 17), want: time.Unix(0, 0).UTC()},
+		{name: "periods are in UTC", schedule: constants.ResetWeekly, now: time.Date(2024, time.May, 13, 1, 0, 0, 0, plusThree), want: date(2024, time.May, 6, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PeriodStart(tt.schedule, tt.now)
+			if !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("PeriodStart(%q, %v) = %v, want %v", tt.schedule, tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffPlayers(t *testing.T) {
+	gold := &model.TierInfo{Name: "Gold", Division: "I", Label: "Gold I"}
+	silver := &model.TierInfo{Name: "Silver", Division: "I", Label: "Silver I"}
+
+	tests := []struct {
+		name     string
+		previous []model.PlayerScore
+		current  []model.PlayerScore
+		want     *model.LeaderboardDiff
+	}{
+		{
+			name:     "no change",
+			previous: []model.PlayerScore{{UserID: 1, Rank: 1, Score: 100}},
+			current:  []model.PlayerScore{{UserID: 1, Rank: 1, Score: 100}},
+			want:     &model.LeaderboardDiff{},
+		},
+		{
+			name:    "first view",
+			current: []model.PlayerScore{{UserID: 1, Rank: 1, Score: 100}, {UserID: 2, Rank: 2, Score: 50}},
+			want: &model.LeaderboardDiff{
+				Entered: []model.PlayerScore{{UserID: 1, Rank: 1, Score: 100}, {UserID: 2, Rank: 2, Score: 50}},
+			},
+		},
+		{
+			name:     "everyone left",
+			previous: []model.PlayerScore{{UserID: 2, Rank: 1, Score: 100}, {UserID: 1, Rank: 2, Score: 50}},
+			want:     &model.LeaderboardDiff{Left: []int64{2, 1}},
+		},
+		{
+			name:     "overtaken",
+			previous: []model.PlayerScore{{UserID: 1, Rank: 1, Score: 100}, {UserID: 2, Rank: 2, Score: 50}},
+			current:  []model.PlayerScore{{UserID: 2, Rank: 1, Score: 150}, {UserID: 1, Rank: 2, Score: 100}},
+			want: &model.LeaderboardDiff{
+				Moved: []model.PlayerMove{
+					{UserID: 2, Rank: 1, PreviousRank: 2, Score: 150, PreviousScore: 50},
+					{UserID: 1, Rank: 2, PreviousRank: 1, Score: 100, PreviousScore: 100},
+				},
+			},
+		},
+		{
+			name:     "pushed out by a newcomer",
+			previous: []model.PlayerScore{{UserID: 1, Rank: 1, Score: 100}, {UserID: 2, Rank: 2, Score: 50}},
+			current:  []model.PlayerScore{{UserID: 1, Rank: 1, Score: 100}, {UserID: 3, Rank: 2, Score: 75}},
+			want: &model.LeaderboardDiff{
+				Entered: []model.PlayerScore{{UserID: 3, Rank: 2, Score: 75}},
+				Left:    []int64{2},
+			},
+		},
+		{
+			name:     "tier change alone is a move",
+			previous: []model.PlayerScore{{UserID: 1, Rank: 1, Score: 100, Tier: silver}},
+			current:  []model.PlayerScore{{UserID: 1, Rank: 1, Score: 100, Tier: gold}},
+			want: &model.LeaderboardDiff{
+				Moved: []model.PlayerMove{{UserID: 1, Rank: 1, PreviousRank: 1, Score: 100, PreviousScore: 100, Tier: gold}},
+			},
+		},
+		{
+			name:     "equal tiers are not a move",
+			previous: []model.PlayerScore{{UserID: 1, Rank: 1, Score: 100, Tier: &model.TierInfo{Name: "Gold", Division: "I", Label: "Gold I"}}},
+			current:  []model.PlayerScore{{UserID: 1, Rank: 1, Score: 100, Tier: gold}},
+			want:     &model.LeaderboardDiff{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffPlayers(tt.previous, tt.current)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffPlayers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeOutbox is an in-memory outbox. Events are claimed once, oldest first;
+// rankFails lists events whose ranks cannot be counted.
+type fakeOutbox struct {
+	events    []repository.OutboxEvent
+	rankFails map[int64]bool
+
+	claimed   int
+	ranked    []int64
+	published []int64
+	retried   map[int64]time.Time
+}
+
+func (f *fakeOutbox) ClaimOutboxEvents(ctx context.Context, now, leaseUntil, unrankedBefore time.Time, limit int) ([]repository.OutboxEvent, error) {
+	end := min(f.claimed+limit, len(f.events))
+	batch := f.events[f.claimed:end]
+	f.claimed = end
+	return batch, nil
+}
+
+func (f *fakeOutbox) RankSubmitEvent(ctx context.Context, event *repository.OutboxEvent) error {
+	if f.rankFails[event.ID] {
+		return errors.New("rank failed")
+	}
+	f.ranked = append(f.ranked, event.ID)
+	event.RanksPending = false
+	return nil
+}
+
+func (f *fakeOutbox) MarkOutboxPublished(ctx context.Context, ids []int64, at time.Time) error {
+	f.published = append(f.published, ids...)
+	return nil
+}
+
+func (f *fakeOutbox) RetryOutboxEvent(ctx context.Context, id int64, nextAttempt time.Time, cause string) error {
+	if f.retried == nil {
+		f.retried = make(map[int64]time.Time)
+	}
+	f.retried[id] = nextAttempt
+	return nil
+}
+
+func (f *fakeOutbox) AcquireJobLock(ctx context.Context, job string, slot time.Time, ttl time.Duration) bool {
+	return false
+}
+
+func (f *fakeOutbox) PruneOutbox(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// fakeSink records the events it is given and refuses those in fails.
+type fakeSink struct {
+	fails    map[int64]bool
+	attempts []int64
+}
+
+func (s *fakeSink) Publish(ctx context.Context, event *model.DomainEvent) error {
+	s.attempts = append(s.attempts, event.ID)
+	if s.fails[event.ID] {
+		return errors.New("sink down")
+	}
+	return nil
+}
+
+func outboxEvent(id int64, attempts int, ranksPending bool) repository.OutboxEvent {
+	return repository.OutboxEvent{
+		DomainEvent:  model.DomainEvent{ID: id, Type: constants.EventScoreSubmitted},
+		Attempts:     attempts,
+		RanksPending: ranksPending,
+	}
+}
+
+func TestOutboxRelayBatch(t *testing.T) {
+	store := &fakeOutbox{
+		events: []repository.OutboxEvent{
+			outboxEvent(1, 1, false),
+			outboxEvent(2, 1, true),
+			outboxEvent(3, 3, false),
+			outboxEvent(4, 1, true),
+			outboxEvent(5, 2, false),
+		},
+		rankFails: map[int64]bool{4: true},
+	}
+	sink := &fakeSink{fails: map[int64]bool{3: true}}
+	relay := &OutboxRelay{repo: store, sink: sink, logger: providers.NewConsoleLogger(), interval: time.Second}
+
+	before := time.Now().UTC()
+	claimed, err := relay.relayBatch(context.Background(), before)
+	after := time.Now().UTC()
+	if err != nil {
+		t.Fatalf("relayBatch() error = %v", err)
+	}
+	if claimed != 5 {
+		t.Errorf("relayBatch() claimed %d, want 5", claimed)
+	}
+
+	// Events go out in the order they were written; an event whose ranks
+	// could not be counted is not published without them
+	if want := []int64{1, 2, 3, 5}; !reflect.DeepEqual(sink.attempts, want) {
+		t.Errorf("sink saw %v, want %v", sink.attempts, want)
+	}
+	if want := []int64{2}; !reflect.DeepEqual(store.ranked, want) {
+		t.Errorf("ranked %v, want %v", store.ranked, want)
+	}
+	if want := []int64{1, 2, 5}; !reflect.DeepEqual(store.published, want) {
+		t.Errorf("marked published %v, want %v", store.published, want)
+	}
+
+	// Failed events are retried after a backoff growing with their attempts
+	wantDelays := map[int64]time.Duration{3: 4 * time.Second, 4: time.Second}
+	if len(store.retried) != len(wantDelays) {
+		t.Fatalf("retried %v, want events 3 and 4", store.retried)
+	}
+	for id, delay := range wantDelays {
+		retryAt, ok := store.retried[id]
+		if !ok {
+			t.Errorf("event %d was not retried", id)
+			continue
+		}
+		if retryAt.Before(before.Add(delay)) || retryAt.After(after.Add(delay)) {
+			t.Errorf("event %d retried at %v, want %v after the batch", id, retryAt, delay)
+		}
+	}
+}
+
+func TestOutboxRelayDrainsBacklog(t *testing.T) {
+	store := &fakeOutbox{}
+	for id := int64(1); id <= constants.OutboxBatchSize+5; id++ {
+		store.events = append(store.events, outboxEvent(id, 1, false))
+	}
+	sink := &fakeSink{}
+	relay := &OutboxRelay{repo: store, sink: sink, logger: providers.NewConsoleLogger(), interval: time.Second}
+
+	relay.runOnce(context.Background(), time.Now().UTC())
+
+	if len(sink.attempts) != len(store.events) {
+		t.Fatalf("sink saw %d events, want %d", len(sink.attempts), len(store.events))
+	}
+	for i, id := range sink.attempts {
+		if id != int64(i+1) {
+			t.Fatalf("sink saw event %d at position %d, want events in order", id, i)
+		}
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{name: "first attempt", attempts: 1, want: 5 * time.Second},
+		{name: "second attempt", attempts: 2, want: 10 * time.Second},
+		{name: "fourth attempt", attempts: 4, want: 40 * time.Second},
+		{name: "capped", attempts: 20, want: outboxMaxBackoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := outboxBackoff(5*time.Second, tt.attempts); got != tt.want {
+				t.Errorf("outboxBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/repository"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+)
+
+// ScoreDecayJob periodically applies each board's decay policy to the scores
+// of inactive players.
+type ScoreDecayJob struct {
+	repo     *repository.LeaderboardRepository
+	games    GameLister
+	logger   *providers.ConsoleLogger
+	interval time.Duration
+}
+
+func NewScoreDecayJob(
+	repo *repository.LeaderboardRepository,
+	games GameLister,
+	logger *providers.ConsoleLogger,
+	interval time.Duration,
+) *ScoreDecayJob {
+	return &ScoreDecayJob{
+		repo:     repo,
+		games:    games,
+		logger:   logger,
+		interval: interval,
+	}
+}
+
+// Run blocks until ctx is cancelled, applying decay on every interval.
+func (j *ScoreDecayJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.runOnce(ctx, time.Now().UTC())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			j.runOnce(ctx, now.UTC())
+		}
+	}
+}
+
+func (j *ScoreDecayJob) runOnce(ctx context.Context, now time.Time) {
+	slot := now.Truncate(j.interval)
+	if !j.repo.AcquireJobLock(ctx, "score-decay", slot, j.interval) {
+		return
+	}
+
+	gameIDs, err := j.games.ListGameIDs(ctx)
+	if err != nil {
+		j.logger.Errorf("Score decay failed to list games | error=%v", err)
+		return
+	}
+
+	for _, gameID := range gameIDs {
+		gameCtx := global.WithGameID(ctx, gameID)
+
+		boards, err := j.repo.ListBoards(gameCtx)
+		if err != nil {
+			j.logger.Errorf("Score decay failed to list boards | game_id=%s error=%v", gameID, err)
+			continue
+		}
+
+		for i := range boards {
+			if boards[i].Decay == nil {
+				continue
+			}
+
+			scope := scopeFor(&boards[i], slot)
+			rows, err := j.repo.ApplyDecay(gameCtx, scope, boards[i].Decay, slot)
+			if err != nil {
+				j.logger.Errorf("Score decay failed | game_id=%s board_id=%s error=%v", gameID, scope.BoardID, err)
+				continue
+			}
+			if rows > 0 {
+				j.logger.Infof("Score decay applied | game_id=%s board_id=%s players=%d", gameID, scope.BoardID, rows)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+)
+
+// FanOutSink hands every event to each of its sinks in turn. An event one
+// sink refuses is retried for all of them, so each must tolerate duplicates.
+type FanOutSink []EventSink
+
+func (s FanOutSink) Publish(ctx context.Context, event *model.DomainEvent) error {
+	for _, sink := range s {
+		if err := sink.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RedisStreamSink appends events to a Redis stream, trimmed to roughly
+// maxLen entries. Consumers read it with their own consumer groups.
+type RedisStreamSink struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+func NewRedisStreamSink(client *redis.Client, stream string, maxLen int64) *RedisStreamSink {
+	return &RedisStreamSink{client: client, stream: stream, maxLen: maxLen}
+}
+
+func (s *RedisStreamSink) Publish(ctx context.Context, event *model.DomainEvent) error {
+	return s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.stream,
+		MaxLen: s.maxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"id":          event.ID,
+			"type":        event.Type,
+			"game_id":     event.GameID,
+			"payload":     string(event.Payload),
+			"occurred_at": event.OccurredAt.Format(time.RFC3339Nano),
+		},
+	}).Err()
+}
+
+// HTTPSink POSTs every event as JSON to a URL. Any non-2xx answer counts as
+// a failure and the event is retried.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSink(url string, timeout time.Duration) *HTTPSink {
+	return &HTTPSink{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (s *HTTPSink) Publish(ctx context.Context, event *model.DomainEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", strconv.FormatInt(event.ID, 10))
+	req.Header.Set("X-Event-Type", event.Type)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("event sink answered %s", resp.Status)
+	}
+	return nil
+}
+
+// WriterSink writes every event as a line of JSON, e.g. to stdout for local
+// development or a log shipper.
+type WriterSink struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func NewWriterSink(out io.Writer) *WriterSink {
+	return &WriterSink{out: out}
+}
+
+func (s *WriterSink) Publish(ctx context.Context, event *model.DomainEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.out.Write(append(line, '\n'))
+	return err
+}
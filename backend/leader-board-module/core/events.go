@@ -0,0 +1,47 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+)
+
+// RunEventRelay blocks until ctx is cancelled, feeding leaderboard events
+// published by any instance into this instance's streams. Without Redis it
+// returns immediately; streams then only see local submits.
+func (c *LeaderboardCore) RunEventRelay(ctx context.Context) {
+	messages := c.repo.SubscribeEvents(ctx, constants.LeaderboardEventsChannel, constants.RankNotificationsChannel)
+	if messages == nil {
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			switch msg.Channel {
+			case constants.LeaderboardEventsChannel:
+				var event model.LeaderboardChangedEvent
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					c.logger.Warnf("Dropped malformed leaderboard event | error=%v", err)
+					continue
+				}
+				c.broadcaster.markDirty(event.GameID, event.BoardID)
+			case constants.RankNotificationsChannel:
+				var notification model.RankNotification
+				if err := json.Unmarshal([]byte(msg.Payload), &notification); err != nil {
+					c.logger.Warnf("Dropped malformed rank notification | error=%v", err)
+					continue
+				}
+				c.notifier.deliver(&notification)
+			}
+		}
+	}
+}
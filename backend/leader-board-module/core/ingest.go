@@ -0,0 +1,343 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/repository"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+)
+
+const (
+	// ingestBlock is how long an idle worker waits for new submits per read.
+	ingestBlock = 2 * time.Second
+	// ingestClaimIdle is how long a submit may stay unacknowledged before
+	// another worker takes it over. It also spaces the attempts of a submit
+	// that failed with an internal error.
+	ingestClaimIdle = 30 * time.Second
+	// ingestErrorBackoff is how long a worker pauses after Redis failed.
+	ingestErrorBackoff = time.Second
+)
+
+// EnableAsyncIngest makes the submit API queue scores for the ingest
+// workers instead of applying them while the client waits.
+func (c *LeaderboardCore) EnableAsyncIngest() {
+	c.asyncIngest = true
+}
+
+// AsyncIngest reports whether submits are queued for the ingest workers.
+func (c *LeaderboardCore) AsyncIngest() bool {
+	return c.asyncIngest
+}
+
+// EnqueueScore validates a submit and queues it for the ingest workers. The
+// response carries the tracking id its status can be looked up with. Checks
+// that need the database, such as whether the user exists, are made when the
+// submit is applied, and the score counts towards the board periods current
+// at that time.
+func (c *LeaderboardCore) EnqueueScore(ctx context.Context, req *model.SubmitScoreRequest) (*model.SubmitScoreResponse, error) {
+	now := time.Now().UTC()
+	if _, _, rejection, err := c.prepareSubmit(ctx, req, now); err != nil || rejection != nil {
+		return rejection, err
+	}
+
+	trackingID, err := newTrackingID()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.repo.EnqueueSubmission(ctx, &model.QueuedSubmission{
+		TrackingID: trackingID,
+		Request:    *req,
+		QueuedAt:   now,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &model.SubmitScoreResponse{
+		Success:    true,
+		Message:    "Score queued for submission",
+		TrackingID: trackingID,
+		Status:     constants.SubmissionQueued,
+	}, nil
+}
+
+// GetSubmissionStatus looks up a queued submit. Statuses are kept for a day.
+func (c *LeaderboardCore) GetSubmissionStatus(ctx context.Context, trackingID string) (*model.SubmissionStatusResponse, error) {
+	status, err := c.repo.GetSubmissionStatus(ctx, trackingID)
+	if err != nil {
+		if err.Error() == constants.ErrSubmissionNotFound {
+			return &model.SubmissionStatusResponse{
+				Success: false,
+				Error:   "Submission not found",
+				Code:    constants.ErrSubmissionNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &model.SubmissionStatusResponse{
+		Success: true,
+		Data:    status,
+	}, nil
+}
+
+func newTrackingID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// IngestWorkerPool applies the submits queued by EnqueueScore. Every worker
+// is a consumer of the ingest group reading up to batchSize submits at a time
+// and applying them together in one transaction. A submit is acknowledged
+// once it was applied or rejected; when the batch failed with an internal
+// error its submits are left pending and retried by whichever worker claims
+// them next, up to MaxIngestAttempts times. A worker that dies after applying
+// a batch but before acknowledging it leaves the submits to be handed out
+// again; their tracking ids are stored with the sessions, so the second
+// attempt finds them applied and changes nothing.
+type IngestWorkerPool struct {
+	core      *LeaderboardCore
+	logger    *providers.ConsoleLogger
+	workers   int
+	batchSize int64
+}
+
+func NewIngestWorkerPool(
+	core *LeaderboardCore,
+	logger *providers.ConsoleLogger,
+	workers int,
+	batchSize int64,
+) *IngestWorkerPool {
+	return &IngestWorkerPool{
+		core:      core,
+		logger:    logger,
+		workers:   workers,
+		batchSize: batchSize,
+	}
+}
+
+// Run blocks until ctx is cancelled and every worker finished its batch.
+func (p *IngestWorkerPool) Run(ctx context.Context) {
+	for {
+		err := p.core.repo.EnsureIngestGroup(ctx)
+		if err == nil {
+			break
+		}
+		p.logger.Errorf("Ingest workers failed to create consumer group | error=%v", err)
+		if !sleepContext(ctx, ingestErrorBackoff) {
+			return
+		}
+	}
+
+	// Consumer names only need to be unique across instances
+	host, _ := os.Hostname()
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func(consumer string) {
+			defer wg.Done()
+			p.work(ctx, consumer)
+		}(fmt.Sprintf("%s-%d-%d", host, os.Getpid(), i))
+	}
+	wg.Wait()
+}
+
+func (p *IngestWorkerPool) work(ctx context.Context, consumer string) {
+	lastClaim := time.Time{}
+	for ctx.Err() == nil {
+		var messages []repository.IngestMessage
+		var err error
+
+		if time.Since(lastClaim) >= ingestClaimIdle {
+			messages, err = p.core.repo.ClaimStaleIngest(ctx, consumer, ingestClaimIdle, p.batchSize)
+			lastClaim = time.Now()
+		}
+		if err == nil && len(messages) == 0 {
+			messages, err = p.core.repo.ReadIngest(ctx, consumer, p.batchSize, ingestBlock)
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				p.logger.Errorf("Ingest worker failed to read submits | consumer=%s error=%v", consumer, err)
+				sleepContext(ctx, ingestErrorBackoff)
+			}
+			continue
+		}
+
+		p.applyBatch(ctx, messages)
+	}
+}
+
+// applyBatch applies the submits read together in one transaction and
+// settles every submit that reached a final status in one go.
+func (p *IngestWorkerPool) applyBatch(ctx context.Context, messages []repository.IngestMessage) {
+	// Submits not applied yet are left to other workers after ingestClaimIdle
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Settling must not be skipped on shutdown once submits were applied
+	settleCtx := context.WithoutCancel(ctx)
+	outcomes := make([]repository.IngestOutcome, 0, len(messages))
+
+	decoded := make([]repository.IngestMessage, 0, len(messages))
+	submissions := make([]*model.QueuedSubmission, 0, len(messages))
+	for _, message := range messages {
+		if message.Submission == nil {
+			p.logger.Warnf("Dropping undecodable queued submit | message_id=%s", message.ID)
+			outcomes = append(outcomes, repository.IngestOutcome{MessageID: message.ID})
+			continue
+		}
+		decoded = append(decoded, message)
+		submissions = append(submissions, message.Submission)
+	}
+
+	for i, result := range p.core.submitBatch(settleCtx, submissions) {
+		message, submission := decoded[i], decoded[i].Submission
+		gameCtx := global.WithGameID(settleCtx, submission.GameID)
+		status := &model.SubmissionStatus{
+			TrackingID: submission.TrackingID,
+			Attempts:   message.Deliveries,
+			QueuedAt:   submission.QueuedAt,
+		}
+
+		resp, err := result.resp, result.err
+		if err != nil {
+			status.Error = "Internal server error"
+			status.Code = constants.ErrInternalServer
+			if message.Deliveries < constants.MaxIngestAttempts {
+				p.logger.Warnf("Queued submit failed, will retry | tracking_id=%s attempts=%d error=%v", submission.TrackingID, message.Deliveries, err)
+				status.Status = constants.SubmissionQueued
+				if err := p.core.repo.SaveSubmissionStatus(gameCtx, status); err != nil {
+					p.logger.Warnf("Failed to record submit attempt | tracking_id=%s error=%v", submission.TrackingID, err)
+				}
+				continue
+			}
+			p.logger.Errorf("Queued submit failed | tracking_id=%s attempts=%d error=%v", submission.TrackingID, message.Deliveries, err)
+			status.Status = constants.SubmissionFailed
+		} else if resp.Code == constants.ErrSubmissionApplied {
+			// An earlier attempt applied it but did not get to settle it
+			p.logger.Infof("Queued submit was already applied | tracking_id=%s attempts=%d", submission.TrackingID, message.Deliveries)
+			status.Status = constants.SubmissionApplied
+		} else if !resp.Success {
+			status.Status = constants.SubmissionRejected
+			status.Error = resp.Error
+			status.Code = resp.Code
+		} else {
+			appliedAt := time.Now().UTC()
+			status.Status = constants.SubmissionApplied
+			status.AppliedAt = &appliedAt
+			status.Result = resp.Data
+		}
+
+		outcomes = append(outcomes, repository.IngestOutcome{
+			MessageID: message.ID,
+			GameID:    submission.GameID,
+			Status:    status,
+		})
+	}
+
+	if err := p.core.repo.CompleteIngest(settleCtx, outcomes); err != nil {
+		// The submits are claimed again and reapplied after ingestClaimIdle
+		p.logger.Errorf("Ingest worker failed to acknowledge submits | submits=%d error=%v", len(outcomes), err)
+	}
+}
+
+// submitResult is the response to a queued submit, or the internal error
+// that kept it from being applied.
+type submitResult struct {
+	resp *model.SubmitScoreResponse
+	err  error
+}
+
+// stagedSubmit is a queued submit that passed validation and the guards and
+// waits for its batch to be written.
+type stagedSubmit struct {
+	index  int
+	ctx    context.Context
+	req    *model.SubmitScoreRequest
+	boards []*model.Board
+	scopes []repository.BoardScope
+}
+
+// submitBatch applies queued submits with one SubmitScores call and returns
+// their results in order. Each submit is validated against the boards
+// current now, and its tracking id keeps a submit applied before from being
+// applied again. Queued submits are batched already, so they do not go
+// through the hot player buffer.
+func (c *LeaderboardCore) submitBatch(ctx context.Context, submissions []*model.QueuedSubmission) []submitResult {
+	now := time.Now().UTC()
+	results := make([]submitResult, len(submissions))
+
+	staged := make([]stagedSubmit, 0, len(submissions))
+	scores := make([]repository.QueuedScore, 0, len(submissions))
+	for i, submission := range submissions {
+		gameCtx := global.WithGameID(ctx, submission.GameID)
+		req := submission.Request
+		req.TrackingID = submission.TrackingID
+
+		boards, scopes, rejection, err := c.prepareSubmit(gameCtx, &req, now)
+		if err == nil && rejection == nil {
+			rejection, err = c.reserveSubmit(gameCtx, req.UserID, boards, now)
+		}
+		if err != nil || rejection != nil {
+			results[i] = submitResult{resp: rejection, err: err}
+			continue
+		}
+
+		staged = append(staged, stagedSubmit{index: i, ctx: gameCtx, req: &req, boards: boards, scopes: scopes})
+		scores = append(scores, repository.QueuedScore{
+			GameID:     submission.GameID,
+			UserID:     req.UserID,
+			Score:      req.Score,
+			GameMode:   req.GameMode,
+			Outcome:    req.Outcome,
+			TrackingID: req.TrackingID,
+			Scopes:     scopes,
+		})
+	}
+	if len(staged) == 0 {
+		return results
+	}
+
+	written, err := c.repo.SubmitScores(ctx, scores)
+	for j, submit := range staged {
+		if err != nil {
+			c.releaseSubmit(submit.ctx, submit.req.UserID, submit.boards)
+			results[submit.index].err = err
+			continue
+		}
+		if written[j].Err != nil {
+			c.releaseSubmit(submit.ctx, submit.req.UserID, submit.boards)
+			resp, err := refusedSubmit(written[j].Err)
+			results[submit.index] = submitResult{resp: resp, err: err}
+			continue
+		}
+		results[submit.index].resp = c.completeSubmit(submit.ctx, submit.req, submit.boards, submit.scopes, written[j].Submission)
+	}
+	return results
+}
+
+// sleepContext waits for d and reports false if ctx was cancelled first.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
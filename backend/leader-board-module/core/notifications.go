@@ -0,0 +1,272 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/repository"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+)
+
+// rankMilestones are the top-N positions players are told about entering or
+// dropping out of, tightest first.
+var rankMilestones = []int{1, 10, 100}
+
+// RankNotifier turns submit results into per-player notifications and hands
+// them to the players' stream subscriptions on whichever instance holds them.
+// Only submits that improve a player's standing notify anyone.
+type RankNotifier struct {
+	core   *LeaderboardCore
+	logger *providers.ConsoleLogger
+
+	mu          sync.RWMutex
+	subscribers map[string]map[*NotificationSubscription]struct{}
+}
+
+// NotificationSubscription receives the notifications of one player. A full
+// buffer drops new notifications rather than blocking submits.
+type NotificationSubscription struct {
+	C <-chan *model.RankNotification
+
+	ch  chan *model.RankNotification
+	key string
+}
+
+func newRankNotifier(core *LeaderboardCore, logger *providers.ConsoleLogger) *RankNotifier {
+	return &RankNotifier{
+		core:        core,
+		logger:      logger,
+		subscribers: make(map[string]map[*NotificationSubscription]struct{}),
+	}
+}
+
+func notificationKey(gameID string, userID int64) string {
+	return fmt.Sprintf("%s/%d", gameID, userID)
+}
+
+// SubscribeNotifications opens a stream of the user's rank notifications.
+// Callers must Unsubscribe when done.
+func (c *LeaderboardCore) SubscribeNotifications(ctx context.Context, userID int64) (*NotificationSubscription, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.notifier.Subscribe(gameID, userID), nil
+}
+
+// UnsubscribeNotifications closes a subscription opened by SubscribeNotifications.
+func (c *LeaderboardCore) UnsubscribeNotifications(sub *NotificationSubscription) {
+	c.notifier.Unsubscribe(sub)
+}
+
+func (n *RankNotifier) Subscribe(gameID string, userID int64) *NotificationSubscription {
+	ch := make(chan *model.RankNotification, constants.NotificationBufferSize)
+	sub := &NotificationSubscription{C: ch, ch: ch, key: notificationKey(gameID, userID)}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.subscribers[sub.key] == nil {
+		n.subscribers[sub.key] = make(map[*NotificationSubscription]struct{})
+	}
+	n.subscribers[sub.key][sub] = struct{}{}
+	return sub
+}
+
+func (n *RankNotifier) Unsubscribe(sub *NotificationSubscription) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	subs := n.subscribers[sub.key]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(n.subscribers, sub.key)
+	}
+	close(sub.ch)
+}
+
+// publish hands a notification to whichever instance holds the player's
+// stream. Without Pub/Sub only local streams can be reached.
+func (n *RankNotifier) publish(ctx context.Context, notification *model.RankNotification) {
+	if n.core.repo.PublishesEvents() {
+		err := n.core.repo.PublishRankNotification(ctx, notification)
+		if err == nil {
+			return
+		}
+		n.logger.Warnf("Failed to publish rank notification, delivering locally | user_id=%d error=%v", notification.UserID, err)
+	}
+	n.deliver(notification)
+}
+
+func (n *RankNotifier) deliver(notification *model.RankNotification) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for sub := range n.subscribers[notificationKey(notification.GameID, notification.UserID)] {
+		select {
+		case sub.ch <- notification:
+		default:
+			n.logger.Warnf("Dropped rank notification for slow subscriber | game_id=%s user_id=%d type=%s", notification.GameID, notification.UserID, notification.Type)
+		}
+	}
+}
+
+// OnScoreSubmitted notifies the submitter about milestones they reached and
+// the players they moved past about being overtaken.
+func (n *RankNotifier) OnScoreSubmitted(ctx context.Context, event *model.ScoreSubmittedEvent) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return
+	}
+
+	for i := range event.Boards {
+		result := &event.Boards[i]
+		// Without the new rank there is nothing reliable to report
+		if result.Rank == 0 {
+			continue
+		}
+
+		board, err := n.core.resolveBoard(ctx, result.BoardID)
+		if err != nil {
+			n.logger.Warnf("Failed to resolve board for rank notifications | board_id=%s error=%v", result.BoardID, err)
+			continue
+		}
+		if !improved(board, result) {
+			continue
+		}
+
+		scope := repository.BoardScope{
+			BoardID:     board.ID,
+			PeriodStart: result.PeriodStart,
+			Aggregation: board.Aggregation,
+			SortOrder:   board.SortOrder,
+		}
+		if err := n.notifySubmitter(ctx, gameID, board, scope, event, result); err != nil {
+			n.logger.Warnf("Failed to notify submitter | board_id=%s user_id=%d error=%v", board.ID, event.UserID, err)
+		}
+		if err := n.notifyOvertaken(ctx, gameID, board, scope, event, result); err != nil {
+			n.logger.Warnf("Failed to notify overtaken players | board_id=%s user_id=%d error=%v", board.ID, event.UserID, err)
+		}
+	}
+}
+
+func (n *RankNotifier) notifySubmitter(
+	ctx context.Context,
+	gameID string,
+	board *model.Board,
+	scope repository.BoardScope,
+	event *model.ScoreSubmittedEvent,
+	result *model.BoardSubmitEvent,
+) error {
+	// Everyone ahead of the previous score, including the submitter's new
+	// score, was ahead before: that count is exactly the previous rank.
+	previousRank := 0
+	if !result.IsNewPlayer {
+		ahead, err := n.core.repo.CountPlayersAhead(ctx, scope, result.PreviousScore)
+		if err != nil {
+			return err
+		}
+		previousRank = ahead
+	}
+
+	for _, milestone := range rankMilestones {
+		if result.Rank > milestone || (previousRank != 0 && previousRank <= milestone) {
+			continue
+		}
+		n.publish(ctx, &model.RankNotification{
+			Type:       constants.NotificationEnteredTop,
+			GameID:     gameID,
+			BoardID:    board.ID,
+			UserID:     event.UserID,
+			Rank:       result.Rank,
+			Score:      result.TotalScore,
+			Milestone:  milestone,
+			Message:    enteredTopMessage(board, milestone),
+			OccurredAt: event.Timestamp,
+		})
+		break
+	}
+	return nil
+}
+
+func (n *RankNotifier) notifyOvertaken(
+	ctx context.Context,
+	gameID string,
+	board *model.Board,
+	scope repository.BoardScope,
+	event *model.ScoreSubmittedEvent,
+	result *model.BoardSubmitEvent,
+) error {
+	var previousScore *int64
+	if !result.IsNewPlayer {
+		previousScore = &result.PreviousScore
+	}
+
+	overtaken, err := n.core.repo.ListOvertaken(ctx, scope, event.UserID, previousScore, result.TotalScore, constants.MaxOvertakenNotified)
+	if err != nil {
+		return err
+	}
+
+	for _, player := range overtaken {
+		n.publish(ctx, &model.RankNotification{
+			Type:       constants.NotificationOvertaken,
+			GameID:     gameID,
+			BoardID:    board.ID,
+			UserID:     player.UserID,
+			Rank:       player.Rank,
+			Score:      player.Score,
+			ByUserID:   event.UserID,
+			ByScore:    result.TotalScore,
+			Message:    fmt.Sprintf("You were overtaken by player %d on %s", event.UserID, board.Name),
+			OccurredAt: event.Timestamp,
+		})
+
+		// Being overtaken costs exactly one place
+		for _, milestone := range rankMilestones {
+			if player.Rank != milestone+1 {
+				continue
+			}
+			n.publish(ctx, &model.RankNotification{
+				Type:       constants.NotificationDroppedTop,
+				GameID:     gameID,
+				BoardID:    board.ID,
+				UserID:     player.UserID,
+				Rank:       player.Rank,
+				Score:      player.Score,
+				ByUserID:   event.UserID,
+				ByScore:    result.TotalScore,
+				Milestone:  milestone,
+				Message:    fmt.Sprintf("You dropped out of the top %d on %s", milestone, board.Name),
+				OccurredAt: event.Timestamp,
+			})
+		}
+	}
+	return nil
+}
+
+// improved reports whether a submit moved the player's score forward in the
+// board's sort order. Scores that got worse, e.g. on latest boards, do not
+// overtake anyone.
+func improved(board *model.Board, result *model.BoardSubmitEvent) bool {
+	if result.IsNewPlayer {
+		return true
+	}
+	if board.SortOrder == constants.SortAscending {
+		return result.TotalScore < result.PreviousScore
+	}
+	return result.TotalScore > result.PreviousScore
+}
+
+func enteredTopMessage(board *model.Board, milestone int) string {
+	if milestone == 1 {
+		return fmt.Sprintf("You took first place on %s", board.Name)
+	}
+	return fmt.Sprintf("You entered the top %d on %s", milestone, board.Name)
+}
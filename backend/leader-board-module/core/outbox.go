@@ -0,0 +1,163 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/repository"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+)
+
+const (
+	// outboxLease is how long a claimed event is reserved for one relay. A
+	// relay that dies mid-batch leaves its events to be claimed again after it.
+	outboxLease = time.Minute
+	// outboxMaxBackoff caps the delay between attempts of a failing event.
+	outboxMaxBackoff = 10 * time.Minute
+	// outboxPruneInterval is how often published events are cleaned up.
+	outboxPruneInterval = time.Hour
+	// outboxRankGrace is how long the relay leaves a submit to record the
+	// ranks of its event before counting them itself.
+	outboxRankGrace = 30 * time.Second
+)
+
+// EventSink receives the domain events of the outbox. Publish may see an
+// event more than once and must return an error unless it took the event.
+type EventSink interface {
+	Publish(ctx context.Context, event *model.DomainEvent) error
+}
+
+// outboxStore is the part of the repository the outbox relay works on.
+type outboxStore interface {
+	ClaimOutboxEvents(ctx context.Context, now, leaseUntil, unrankedBefore time.Time, limit int) ([]repository.OutboxEvent, error)
+	RankSubmitEvent(ctx context.Context, event *repository.OutboxEvent) error
+	MarkOutboxPublished(ctx context.Context, ids []int64, at time.Time) error
+	RetryOutboxEvent(ctx context.Context, id int64, nextAttempt time.Time, cause string) error
+	AcquireJobLock(ctx context.Context, job string, slot time.Time, ttl time.Duration) bool
+	PruneOutbox(ctx context.Context, before time.Time) (int64, error)
+}
+
+// OutboxRelay publishes the events written to the outbox with each submit.
+// Events are only marked published once the sink took them, so delivery is
+// at least once; consumers deduplicate on the event id.
+type OutboxRelay struct {
+	repo      outboxStore
+	sink      EventSink
+	logger    *providers.ConsoleLogger
+	interval  time.Duration
+	retention time.Duration
+}
+
+func NewOutboxRelay(
+	repo *repository.LeaderboardRepository,
+	sink EventSink,
+	logger *providers.ConsoleLogger,
+	interval time.Duration,
+	retention time.Duration,
+) *OutboxRelay {
+	return &OutboxRelay{
+		repo:      repo,
+		sink:      sink,
+		logger:    logger,
+		interval:  interval,
+		retention: retention,
+	}
+}
+
+// Run blocks until ctx is cancelled, draining the outbox on every interval.
+func (j *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.runOnce(ctx, time.Now().UTC())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			j.runOnce(ctx, now.UTC())
+		}
+	}
+}
+
+func (j *OutboxRelay) runOnce(ctx context.Context, now time.Time) {
+	// Keep going while full batches come back, so a backlog drains quickly
+	for ctx.Err() == nil {
+		claimed, err := j.relayBatch(ctx, time.Now().UTC())
+		if err != nil {
+			j.logger.Errorf("Outbox relay failed | error=%v", err)
+			break
+		}
+		if claimed < constants.OutboxBatchSize {
+			break
+		}
+	}
+
+	slot := now.Truncate(outboxPruneInterval)
+	if !j.repo.AcquireJobLock(ctx, "outbox-prune", slot, outboxPruneInterval) {
+		return
+	}
+	pruned, err := j.repo.PruneOutbox(ctx, now.Add(-j.retention))
+	if err != nil {
+		j.logger.Errorf("Outbox prune failed | error=%v", err)
+		return
+	}
+	if pruned > 0 {
+		j.logger.Infof("Outbox pruned | events=%d", pruned)
+	}
+}
+
+// relayBatch publishes one batch of due events and returns how many it claimed.
+func (j *OutboxRelay) relayBatch(ctx context.Context, now time.Time) (int, error) {
+	events, err := j.repo.ClaimOutboxEvents(ctx, now, now.Add(outboxLease), now.Add(-outboxRankGrace), constants.OutboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := make([]int64, 0, len(events))
+	for i := range events {
+		event := &events[i]
+		// The submit did not get to record its ranks, e.g. because its
+		// process died; the RankChanged events this records go out with a
+		// later batch
+		if event.RanksPending {
+			if err := j.repo.RankSubmitEvent(ctx, event); err != nil {
+				j.retry(ctx, event, "Failed to rank outbox event", err)
+				continue
+			}
+		}
+		if err := j.sink.Publish(ctx, &event.DomainEvent); err != nil {
+			j.retry(ctx, event, "Failed to publish outbox event", err)
+			continue
+		}
+		published = append(published, event.ID)
+	}
+
+	// Events published but not marked are sent again once their lease ends
+	if err := j.repo.MarkOutboxPublished(ctx, published, time.Now().UTC()); err != nil {
+		return len(events), err
+	}
+	return len(events), nil
+}
+
+// retry schedules another attempt of an event that could not be published.
+func (j *OutboxRelay) retry(ctx context.Context, event *repository.OutboxEvent, message string, cause error) {
+	retryAt := time.Now().UTC().Add(outboxBackoff(j.interval, event.Attempts))
+	j.logger.Warnf("%s | id=%d type=%s attempts=%d error=%v", message, event.ID, event.Type, event.Attempts, cause)
+	if err := j.repo.RetryOutboxEvent(ctx, event.ID, retryAt, cause.Error()); err != nil {
+		// The lease still runs out, so the event is retried regardless
+		j.logger.Warnf("Failed to reschedule outbox event | id=%d error=%v", event.ID, err)
+	}
+}
+
+// outboxBackoff doubles the delay with every failed attempt, up to outboxMaxBackoff.
+func outboxBackoff(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < outboxMaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxMaxBackoff)
+}
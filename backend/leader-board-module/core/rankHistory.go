@@ -0,0 +1,167 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/repository"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+)
+
+const maxRankHistoryRange = 366 * 24 * time.Hour
+
+func (c *LeaderboardCore) GetRankHistory(ctx context.Context, boardID string, userID int64, from, to time.Time) (*model.RankHistoryResponse, error) {
+	if userID <= 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if !from.Before(to) || to.Sub(from) > maxRankHistoryRange {
+		return &model.RankHistoryResponse{
+			Success: false,
+			Error:   "from must be before to and the range cannot exceed one year",
+			Code:    constants.ErrInvalidRange,
+		}, nil
+	}
+
+	board, err := c.resolveBoard(ctx, boardID)
+	if err != nil {
+		if err.Error() == constants.ErrBoardNotFound {
+			return &model.RankHistoryResponse{
+				Success: false,
+				Error:   "Leaderboard not found",
+				Code:    constants.ErrBoardNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+
+	entries, err := c.repo.GetRankHistory(ctx, board.ID, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &model.RankHistoryData{
+		BoardID: board.ID,
+		UserID:  userID,
+		From:    from,
+		To:      to,
+		Points:  make([]model.RankHistoryPoint, 0, len(entries)),
+	}
+
+	for _, entry := range entries {
+		data.Points = append(data.Points, model.RankHistoryPoint{
+			Timestamp:   entry.CapturedAt,
+			Granularity: entry.Granularity,
+			Rank:        entry.Rank,
+			Score:       entry.TotalScore,
+		})
+		if data.BestRank == 0 || entry.Rank < data.BestRank {
+			data.BestRank = entry.Rank
+		}
+	}
+
+	if len(entries) > 1 {
+		data.RankChange = entries[0].Rank - entries[len(entries)-1].Rank
+	}
+
+	return &model.RankHistoryResponse{
+		Success: true,
+		Data:    data,
+	}, nil
+}
+
+// GameLister lists the games (tenants) background jobs have to visit.
+type GameLister interface {
+	ListGameIDs(ctx context.Context) ([]string, error)
+}
+
+// RankHistoryJob periodically snapshots every player's rank and compacts
+// older snapshots so history stays small.
+type RankHistoryJob struct {
+	repo         *repository.LeaderboardRepository
+	games        GameLister
+	logger       *providers.ConsoleLogger
+	interval     time.Duration
+	compactAfter time.Duration
+	retention    time.Duration
+}
+
+func NewRankHistoryJob(
+	repo *repository.LeaderboardRepository,
+	games GameLister,
+	logger *providers.ConsoleLogger,
+	interval time.Duration,
+	compactAfter time.Duration,
+	retention time.Duration,
+) *RankHistoryJob {
+	return &RankHistoryJob{
+		repo:         repo,
+		games:        games,
+		logger:       logger,
+		interval:     interval,
+		compactAfter: compactAfter,
+		retention:    retention,
+	}
+}
+
+// Run blocks until ctx is cancelled, taking a snapshot on every interval.
+func (j *RankHistoryJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.runOnce(ctx, time.Now().UTC())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			j.runOnce(ctx, now.UTC())
+		}
+	}
+}
+
+func (j *RankHistoryJob) runOnce(ctx context.Context, now time.Time) {
+	// Aligning to the interval makes the snapshot idempotent across instances
+	slot := now.Truncate(j.interval)
+	if !j.repo.AcquireJobLock(ctx, "rank-history", slot, j.interval) {
+		return
+	}
+
+	gameIDs, err := j.games.ListGameIDs(ctx)
+	if err != nil {
+		j.logger.Errorf("Rank snapshot failed to list games | slot=%s error=%v", slot.Format(time.RFC3339), err)
+		return
+	}
+
+	for _, gameID := range gameIDs {
+		j.runForGame(global.WithGameID(ctx, gameID), gameID, slot)
+	}
+}
+
+func (j *RankHistoryJob) runForGame(ctx context.Context, gameID string, slot time.Time) {
+	boards, err := j.repo.ListBoards(ctx)
+	if err != nil {
+		j.logger.Errorf("Rank snapshot failed to list boards | game_id=%s slot=%s error=%v", gameID, slot.Format(time.RFC3339), err)
+		return
+	}
+
+	// Each board is snapshotted for its current period only
+	for i := range boards {
+		scope := scopeFor(&boards[i], slot)
+		rows, err := j.repo.SnapshotRanks(ctx, scope, slot)
+		if err != nil {
+			j.logger.Errorf("Rank snapshot failed | game_id=%s board_id=%s slot=%s error=%v", gameID, scope.BoardID, slot.Format(time.RFC3339), err)
+			continue
+		}
+		j.logger.Infof("Rank snapshot stored | game_id=%s board_id=%s slot=%s players=%d", gameID, scope.BoardID, slot.Format(time.RFC3339), rows)
+	}
+
+	if err := j.repo.CompactRankHistory(ctx, slot.Add(-j.compactAfter), slot.Add(-j.retention)); err != nil {
+		j.logger.Errorf("Rank history compaction failed | game_id=%s error=%v", gameID, err)
+	}
+}
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/repository"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+)
+
+// hotPlayerWindow is the window submits are counted in to tell hot players.
+const hotPlayerWindow = time.Second
+
+// ScoreBuffer is a write-behind buffer for hot players. Once a player
+// submits more than threshold times within a second, each further session
+// is still stored as it comes in, but what it adds to the board scores and
+// stats is queued and written in bulk every window, so the player's board
+// and stats rows are locked once per window instead of once per submit.
+//
+// Until a window is flushed the player's stored board scores and stats lag
+// behind, so achievements read from the stats may only be awarded on a later
+// submit; the submit response and events carry board estimates that include
+// what is queued. The queue is stored with the sessions, so a process that
+// dies before its flush loses nothing: the next flush of any instance
+// applies it.
+type ScoreBuffer struct {
+	repo      *repository.LeaderboardRepository
+	logger    *providers.ConsoleLogger
+	window    time.Duration
+	threshold int
+
+	mu      sync.Mutex
+	rates   map[bufferedPlayer]*submitRate
+	pending map[bufferedPlayer]bool
+}
+
+type bufferedPlayer struct {
+	gameID string
+	userID int64
+}
+
+type submitRate struct {
+	since time.Time
+	count int
+}
+
+func NewScoreBuffer(
+	repo *repository.LeaderboardRepository,
+	logger *providers.ConsoleLogger,
+	window time.Duration,
+	threshold int,
+) *ScoreBuffer {
+	return &ScoreBuffer{
+		repo:      repo,
+		logger:    logger,
+		window:    window,
+		threshold: threshold,
+		rates:     make(map[bufferedPlayer]*submitRate),
+		pending:   make(map[bufferedPlayer]bool),
+	}
+}
+
+// EnableScoreBuffer routes the submits of hot players through buffer.
+func (c *LeaderboardCore) EnableScoreBuffer(buffer *ScoreBuffer) {
+	c.buffer = buffer
+}
+
+// Run blocks until ctx is cancelled, flushing the queue every window. What
+// is left is flushed before it returns.
+func (b *ScoreBuffer) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			b.flush(context.WithoutCancel(ctx), time.Now())
+			return
+		case now := <-ticker.C:
+			b.flush(ctx, now)
+		}
+	}
+}
+
+// hot counts a submit of the player and reports whether it should go
+// through the buffer. Players stay hot while this instance queued scores of
+// theirs that were not flushed yet.
+func (b *ScoreBuffer) hot(ctx context.Context, userID int64, now time.Time) bool {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return false
+	}
+	player := bufferedPlayer{gameID: gameID, userID: userID}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	rate, ok := b.rates[player]
+	if !ok || now.Sub(rate.since) >= hotPlayerWindow {
+		rate = &submitRate{since: now}
+		b.rates[player] = rate
+	}
+	rate.count++
+
+	return b.pending[player] || rate.count > b.threshold
+}
+
+// submit stores the session of a hot player's submit and queues its board
+// scores and stats.
+func (b *ScoreBuffer) submit(ctx context.Context, req *model.SubmitScoreRequest, scopes []repository.BoardScope) (*repository.ScoreSubmission, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := b.repo.RecordSession(ctx, req.UserID, req.Score, req.GameMode, req.Outcome, req.TrackingID, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	b.pending[bufferedPlayer{gameID: gameID, userID: req.UserID}] = true
+	b.mu.Unlock()
+
+	return submission, nil
+}
+
+// flush applies everything queued so far. What a failed flush leaves queued
+// is applied by the next one.
+func (b *ScoreBuffer) flush(ctx context.Context, now time.Time) {
+	b.mu.Lock()
+	b.pending = make(map[bufferedPlayer]bool)
+	for player, rate := range b.rates {
+		if now.Sub(rate.since) >= hotPlayerWindow {
+			delete(b.rates, player)
+		}
+	}
+	b.mu.Unlock()
+
+	// Keep going while full batches come back, so a backlog drains quickly
+	for {
+		flushed, err := b.repo.FlushBufferedScores(ctx, constants.BufferFlushBatchSize)
+		if err != nil {
+			b.logger.Errorf("Score buffer flush failed | error=%v", err)
+			return
+		}
+		if flushed < constants.BufferFlushBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/repository"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+)
+
+// CreateSnapshot captures the current standings of a board on demand.
+func (c *LeaderboardCore) CreateSnapshot(ctx context.Context, boardID string) (*model.SnapshotResponse, error) {
+	board, err := c.resolveBoard(ctx, boardID)
+	if err != nil {
+		if err.Error() == constants.ErrBoardNotFound {
+			return &model.SnapshotResponse{
+				Success: false,
+				Error:   "Leaderboard not found",
+				Code:    constants.ErrBoardNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	snapshot, err := c.repo.CreateSnapshot(ctx, scopeFor(board, now), now, constants.SnapshotManual)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SnapshotResponse{
+		Success: true,
+		Data:    snapshot,
+	}, nil
+}
+
+func (c *LeaderboardCore) ListSnapshots(ctx context.Context, boardID string, from, to time.Time, limit int) (*model.SnapshotsResponse, error) {
+	if limit <= 0 || limit > constants.MaxSnapshotsPerPage {
+		limit = constants.MaxSnapshotsPerPage
+	}
+
+	if !from.Before(to) {
+		return &model.SnapshotsResponse{
+			Success: false,
+			Error:   "from must be before to",
+			Code:    constants.ErrInvalidRange,
+		}, nil
+	}
+
+	board, err := c.resolveBoard(ctx, boardID)
+	if err != nil {
+		if err.Error() == constants.ErrBoardNotFound {
+			return &model.SnapshotsResponse{
+				Success: false,
+				Error:   "Leaderboard not found",
+				Code:    constants.ErrBoardNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+
+	snapshots, err := c.repo.ListSnapshots(ctx, board.ID, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SnapshotsResponse{
+		Success:   true,
+		BoardID:   board.ID,
+		Snapshots: snapshots,
+	}, nil
+}
+
+// GetTopPlayersAsOf answers GetTopPlayers from the latest snapshot taken at
+// or before asOf.
+func (c *LeaderboardCore) GetTopPlayersAsOf(ctx context.Context, boardID string, limit int, asOf time.Time) (*model.GetTopPlayersResponse, error) {
+	if limit <= 0 {
+		limit = 10 // Default to 10 if invalid limit provided
+	}
+
+	board, snapshot, code, err := c.snapshotAsOf(ctx, boardID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if code != "" {
+		return &model.GetTopPlayersResponse{
+			Success: false,
+			Error:   snapshotErrorMessage(code),
+			Code:    code,
+		}, nil
+	}
+
+	entries := snapshot.Entries
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	tiers := c.tiersFor(board)
+	ranks := snapshotRanks(entries)
+	players := make([]model.PlayerScore, 0, len(entries))
+	for i, entry := range entries {
+		players = append(players, model.PlayerScore{
+			UserID: entry.UserID,
+			Rank:   ranks[i],
+			Score:  entry.TotalScore,
+			Tier:   tierFor(tiers, entry.TotalScore, ranks[i], snapshot.Players),
+		})
+	}
+
+	response := &model.GetTopPlayersResponse{
+		Success:    true,
+		BoardID:    board.ID,
+		ScoreUnit:  board.ScoreUnit,
+		SnapshotAt: &snapshot.CapturedAt,
+		Players:    players,
+	}
+	if board.ResetSchedule != constants.ResetNever {
+		response.PeriodStart = &snapshot.PeriodStart
+	}
+	return response, nil
+}
+
+// GetPlayerRankAsOf answers GetPlayerRank from the latest snapshot taken at
+// or before asOf.
+func (c *LeaderboardCore) GetPlayerRankAsOf(ctx context.Context, boardID string, userID int64, asOf time.Time) (*model.PlayerRankResponse, error) {
+	if userID <= 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	board, snapshot, code, err := c.snapshotAsOf(ctx, boardID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if code != "" {
+		return &model.PlayerRankResponse{
+			Success: false,
+			Error:   snapshotErrorMessage(code),
+			Code:    code,
+		}, nil
+	}
+
+	ranks := snapshotRanks(snapshot.Entries)
+	for i, entry := range snapshot.Entries {
+		if entry.UserID != userID {
+			continue
+		}
+		return &model.PlayerRankResponse{
+			Success: true,
+			Data: &model.PlayerRankData{
+				BoardID:      board.ID,
+				ScoreUnit:    board.ScoreUnit,
+				UserID:       userID,
+				Rank:         ranks[i],
+				Score:        entry.TotalScore,
+				TotalPlayers: snapshot.Players,
+				Percentile:   percentile(ranks[i], snapshot.Players),
+				Tier:         tierFor(c.tiersFor(board), entry.TotalScore, ranks[i], snapshot.Players),
+				SnapshotAt:   &snapshot.CapturedAt,
+			},
+		}, nil
+	}
+
+	return &model.PlayerRankResponse{
+		Success: false,
+		Error:   "User not found",
+		Code:    constants.ErrUserNotFound,
+	}, nil
+}
+
+// snapshotAsOf resolves the board and loads its snapshot, returning an error
+// code instead of an error for unknown boards and missing snapshots.
+func (c *LeaderboardCore) snapshotAsOf(ctx context.Context, boardID string, asOf time.Time) (*model.Board, *repository.StoredSnapshot, string, error) {
+	board, err := c.resolveBoard(ctx, boardID)
+	if err != nil {
+		if err.Error() == constants.ErrBoardNotFound {
+			return nil, nil, constants.ErrBoardNotFound, nil
+		}
+		return nil, nil, "", err
+	}
+
+	snapshot, err := c.repo.GetSnapshotAsOf(ctx, board.ID, asOf)
+	if err != nil {
+		if err.Error() == constants.ErrSnapshotNotFound {
+			return nil, nil, constants.ErrSnapshotNotFound, nil
+		}
+		return nil, nil, "", err
+	}
+
+	return board, snapshot, "", nil
+}
+
+func snapshotErrorMessage(code string) string {
+	if code == constants.ErrBoardNotFound {
+		return "Leaderboard not found"
+	}
+	return "No snapshot of this leaderboard exists at or before as_of"
+}
+
+// snapshotRanks ranks standings stored in rank order. Tied scores share a
+// rank, matching GetTopPlayers.
+func snapshotRanks(entries []repository.LeaderboardEntry) []int {
+	ranks := make([]int, len(entries))
+	for i, entry := range entries {
+		ranks[i] = i + 1
+		if i > 0 && entry.TotalScore == entries[i-1].TotalScore {
+			ranks[i] = ranks[i-1]
+		}
+	}
+	return ranks
+}
+
+// LeaderboardSnapshotJob periodically snapshots every board and expires old
+// scheduled snapshots.
+type LeaderboardSnapshotJob struct {
+	repo      *repository.LeaderboardRepository
+	games     GameLister
+	logger    *providers.ConsoleLogger
+	interval  time.Duration
+	retention time.Duration
+}
+
+func NewLeaderboardSnapshotJob(
+	repo *repository.LeaderboardRepository,
+	games GameLister,
+	logger *providers.ConsoleLogger,
+	interval time.Duration,
+	retention time.Duration,
+) *LeaderboardSnapshotJob {
+	return &LeaderboardSnapshotJob{
+		repo:      repo,
+		games:     games,
+		logger:    logger,
+		interval:  interval,
+		retention: retention,
+	}
+}
+
+// Run blocks until ctx is cancelled, taking snapshots on every interval.
+func (j *LeaderboardSnapshotJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.runOnce(ctx, time.Now().UTC())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			j.runOnce(ctx, now.UTC())
+		}
+	}
+}
+
+func (j *LeaderboardSnapshotJob) runOnce(ctx context.Context, now time.Time) {
+	slot := now.Truncate(j.interval)
+	if !j.repo.AcquireJobLock(ctx, "leaderboard-snapshot", slot, j.interval) {
+		return
+	}
+
+	gameIDs, err := j.games.ListGameIDs(ctx)
+	if err != nil {
+		j.logger.Errorf("Leaderboard snapshot failed to list games | slot=%s error=%v", slot.Format(time.RFC3339), err)
+		return
+	}
+
+	for _, gameID := range gameIDs {
+		j.runForGame(global.WithGameID(ctx, gameID), gameID, slot)
+	}
+}
+
+func (j *LeaderboardSnapshotJob) runForGame(ctx context.Context, gameID string, slot time.Time) {
+	boards, err := j.repo.ListBoards(ctx)
+	if err != nil {
+		j.logger.Errorf("Leaderboard snapshot failed to list boards | game_id=%s slot=%s error=%v", gameID, slot.Format(time.RFC3339), err)
+		return
+	}
+
+	// The period is taken just before the slot, so a run at a reset boundary
+	// captures the final standings of the period that just ended.
+	for i := range boards {
+		scope := scopeFor(&boards[i], slot.Add(-time.Nanosecond))
+		snapshot, err := j.repo.CreateSnapshot(ctx, scope, slot, constants.SnapshotScheduled)
+		if err != nil {
+			j.logger.Errorf("Leaderboard snapshot failed | game_id=%s board_id=%s slot=%s error=%v", gameID, scope.BoardID, slot.Format(time.RFC3339), err)
+			continue
+		}
+		if snapshot != nil {
+			j.logger.Infof("Leaderboard snapshot stored | game_id=%s board_id=%s slot=%s players=%d bytes=%d", gameID, scope.BoardID, slot.Format(time.RFC3339), snapshot.Players, snapshot.SizeBytes)
+		}
+	}
+
+	if err := j.repo.PruneSnapshots(ctx, slot.Add(-j.retention)); err != nil {
+		j.logger.Errorf("Leaderboard snapshot pruning failed | game_id=%s error=%v", gameID, err)
+	}
+}
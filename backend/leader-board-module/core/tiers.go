@@ -0,0 +1,180 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+)
+
+var divisionNames = []string{"I", "II", "III", "IV", "V"}
+
+// TierEvaluator maps a player's score and rank onto the configured tiers.
+// Definitions are ordered best tier first; the first one a player satisfies wins.
+type TierEvaluator struct {
+	tiers     []model.TierDefinition
+	needsRank bool
+}
+
+func DefaultTierDefinitions() []model.TierDefinition {
+	percent := func(p float64) *float64 { return &p }
+	points := func(s int64) *int64 { return &s }
+
+	return []model.TierDefinition{
+		{Name: "Grandmaster", TopPercent: percent(1)},
+		{Name: "Master", TopPercent: percent(5)},
+		{Name: "Diamond", MinScore: points(50000)},
+		{Name: "Platinum", MinScore: points(25000), Divisions: 3},
+		{Name: "Gold", MinScore: points(10000), Divisions: 3},
+		{Name: "Silver", MinScore: points(2500), Divisions: 3},
+		{Name: "Bronze", MinScore: points(0), Divisions: 3},
+	}
+}
+
+// LoadTierDefinitions reads tier definitions from a JSON file.
+func LoadTierDefinitions(path string) ([]model.TierDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tier config: %w", err)
+	}
+
+	var defs []model.TierDefinition
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("failed to parse tier config: %w", err)
+	}
+	return defs, nil
+}
+
+func NewTierEvaluator(defs []model.TierDefinition) (*TierEvaluator, error) {
+	if len(defs) == 0 {
+		return nil, fmt.Errorf("at least one tier must be defined")
+	}
+
+	seen := make(map[string]bool, len(defs))
+	needsRank := false
+	lastScore := int64(math.MaxInt64)
+	lastPercent := 0.0
+
+	for _, def := range defs {
+		if def.Name == "" {
+			return nil, fmt.Errorf("tier name cannot be empty")
+		}
+		if seen[def.Name] {
+			return nil, fmt.Errorf("duplicate tier %q", def.Name)
+		}
+		seen[def.Name] = true
+
+		if (def.MinScore == nil) == (def.TopPercent == nil) {
+			return nil, fmt.Errorf("tier %q must set exactly one of min_score or top_percent", def.Name)
+		}
+		if def.Divisions < 0 || def.Divisions > len(divisionNames) {
+			return nil, fmt.Errorf("tier %q divisions must be between 0 and %d", def.Name, len(divisionNames))
+		}
+
+		if def.TopPercent != nil {
+			if *def.TopPercent <= lastPercent || *def.TopPercent > 100 {
+				return nil, fmt.Errorf("tier %q top_percent must be increasing and within (0, 100]", def.Name)
+			}
+			lastPercent = *def.TopPercent
+			needsRank = true
+			continue
+		}
+
+		if *def.MinScore >= lastScore {
+			return nil, fmt.Errorf("tier %q min_score must be lower than the tier above it", def.Name)
+		}
+		// Divisions split the range up to the next tier; the best score
+		// tier has no upper bound to split
+		if lastScore == math.MaxInt64 && def.Divisions > 1 {
+			return nil, fmt.Errorf("tier %q is the highest score tier and cannot have divisions", def.Name)
+		}
+		lastScore = *def.MinScore
+	}
+
+	return &TierEvaluator{tiers: defs, needsRank: needsRank}, nil
+}
+
+// NeedsRank reports whether any tier is percentile based, in which case
+// Evaluate must be given the player's rank and the total player count.
+func (e *TierEvaluator) NeedsRank() bool {
+	return e.needsRank
+}
+
+// Compare returns a positive number if a is a better tier than b, a negative
+// number if it is worse and zero if both are the same. nil ranks below every tier.
+func (e *TierEvaluator) Compare(a, b *model.TierInfo) int {
+	return e.position(b) - e.position(a)
+}
+
+// Evaluate returns the tier for the given standing, or nil if no tier matches.
+// rank and totalPlayers are ignored by score-based tiers.
+func (e *TierEvaluator) Evaluate(score int64, rank, totalPlayers int) *model.TierInfo {
+	var percentile float64
+	if rank > 0 && totalPlayers > 0 {
+		percentile = float64(rank) / float64(totalPlayers) * 100
+	}
+
+	upperScore := int64(-1)
+	lowerPercent := 0.0
+
+	for _, def := range e.tiers {
+		if def.TopPercent != nil {
+			if percentile > 0 && percentile <= *def.TopPercent {
+				// Closer to the top of the band means a better division.
+				fraction := (percentile - lowerPercent) / (*def.TopPercent - lowerPercent)
+				return tierInfo(def.Name, def.Divisions, int(math.Ceil(fraction*float64(def.Divisions))))
+			}
+			lowerPercent = *def.TopPercent
+			continue
+		}
+
+		if score >= *def.MinScore {
+			division := 0
+			if upperScore > *def.MinScore {
+				fraction := float64(score-*def.MinScore) / float64(upperScore-*def.MinScore)
+				division = def.Divisions - int(math.Floor(fraction*float64(def.Divisions)))
+			}
+			return tierInfo(def.Name, def.Divisions, division)
+		}
+		upperScore = *def.MinScore
+	}
+
+	return nil
+}
+
+// position orders tiers and divisions so that a lower value is a better standing.
+func (e *TierEvaluator) position(info *model.TierInfo) int {
+	if info == nil {
+		return math.MaxInt32
+	}
+	for i, def := range e.tiers {
+		if def.Name != info.Name {
+			continue
+		}
+		for d, name := range divisionNames {
+			if name == info.Division {
+				return i*(len(divisionNames)+1) + d + 1
+			}
+		}
+		return i * (len(divisionNames) + 1)
+	}
+	return math.MaxInt32
+}
+
+func tierInfo(name string, divisions, division int) *model.TierInfo {
+	if divisions <= 1 || division <= 0 {
+		return &model.TierInfo{Name: name, Label: name}
+	}
+	if division > divisions {
+		division = divisions
+	}
+
+	numeral := divisionNames[division-1]
+	return &model.TierInfo{
+		Name:     name,
+		Division: numeral,
+		Label:    name + " " + numeral,
+	}
+}
@@ -0,0 +1,170 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/repository"
+)
+
+// ValidateView fills in the defaults of a live view and returns a human
+// readable problem with it, or "".
+func ValidateView(view *model.LeaderboardView) string {
+	if view.Mode == "" {
+		view.Mode = constants.StreamModeTop
+	}
+
+	switch view.Mode {
+	case constants.StreamModeTop, constants.StreamModePage:
+		if view.Limit == 0 {
+			view.Limit = constants.DefaultStreamLimit
+		}
+		if view.Limit < 1 || view.Limit > constants.MaxStreamLimit {
+			return fmt.Sprintf("limit must be between 1 and %d", constants.MaxStreamLimit)
+		}
+		if view.Mode == constants.StreamModeTop {
+			view.Page, view.UserID, view.Window = 0, 0, 0
+			return ""
+		}
+		if view.Page == 0 {
+			view.Page = 1
+		}
+		if view.Page < 1 {
+			return "page must be positive"
+		}
+		view.UserID, view.Window = 0, 0
+	case constants.StreamModeAround:
+		if view.UserID <= 0 {
+			return "user_id is required for the around mode"
+		}
+		if view.Window == 0 {
+			view.Window = constants.DefaultStreamWindow
+		}
+		if view.Window < 1 || view.Window > constants.MaxStreamWindow {
+			return fmt.Sprintf("window must be between 1 and %d", constants.MaxStreamWindow)
+		}
+		view.Limit, view.Page = 0, 0
+	default:
+		return "mode must be one of top, page or around"
+	}
+
+	return ""
+}
+
+// viewKey identifies a validated view, so identical views share one feed.
+func viewKey(view model.LeaderboardView) string {
+	return fmt.Sprintf("%s:%d:%d:%d:%d", view.Mode, view.Limit, view.Page, view.UserID, view.Window)
+}
+
+// readView loads the players a validated view currently shows. A player
+// watching their own neighbourhood before their first submit sees nothing.
+func (c *LeaderboardCore) readView(ctx context.Context, board *model.Board, view model.LeaderboardView) ([]model.PlayerScore, error) {
+	scope := scopeFor(board, time.Now())
+
+	var entries []repository.LeaderboardEntry
+	var err error
+	offset := 0
+
+	switch view.Mode {
+	case constants.StreamModePage:
+		offset = (view.Page - 1) * view.Limit
+		entries, err = c.repo.GetPlayersPage(ctx, scope, offset, view.Limit)
+	case constants.StreamModeAround:
+		rank, rankErr := c.repo.GetPlayerRank(ctx, scope, view.UserID)
+		if rankErr != nil {
+			if rankErr.Error() == constants.ErrUserNotFound {
+				return []model.PlayerScore{}, nil
+			}
+			return nil, rankErr
+		}
+		offset = max(rank.Rank-1-view.Window, 0)
+		entries, err = c.repo.GetPlayersPage(ctx, scope, offset, 2*view.Window+1)
+	default:
+		entries, err = c.repo.GetTopPlayers(ctx, scope, view.Limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.rankEntries(ctx, board, scope, entries, offset)
+}
+
+// rankEntries ranks consecutive entries that start at offset in the board's
+// order. Tied scores share a rank, matching GetPlayerRank.
+func (c *LeaderboardCore) rankEntries(
+	ctx context.Context,
+	board *model.Board,
+	scope repository.BoardScope,
+	entries []repository.LeaderboardEntry,
+	offset int,
+) ([]model.PlayerScore, error) {
+	tiers := c.tiersFor(board)
+	totalPlayers, err := totalPlayersForTiers(ctx, c.repo, tiers, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	players := make([]model.PlayerScore, 0, len(entries))
+	for i, entry := range entries {
+		rank := offset + i + 1
+		switch {
+		case i > 0 && entry.TotalScore == entries[i-1].TotalScore:
+			rank = players[i-1].Rank
+		case i == 0 && offset > 0:
+			// The first entry of a page may share its score with the previous page
+			ahead, err := c.repo.CountPlayersAhead(ctx, scope, entry.TotalScore)
+			if err != nil {
+				return nil, err
+			}
+			rank = ahead + 1
+		}
+
+		players = append(players, model.PlayerScore{
+			UserID: entry.UserID,
+			Rank:   rank,
+			Score:  entry.TotalScore,
+			Tier:   tierFor(tiers, entry.TotalScore, rank, totalPlayers),
+		})
+	}
+	return players, nil
+}
+
+// diffPlayers describes how a view changed from previous to current.
+func diffPlayers(previous, current []model.PlayerScore) *model.LeaderboardDiff {
+	before := make(map[int64]model.PlayerScore, len(previous))
+	for _, player := range previous {
+		before[player.UserID] = player
+	}
+
+	diff := &model.LeaderboardDiff{}
+	for _, player := range current {
+		old, ok := before[player.UserID]
+		delete(before, player.UserID)
+
+		switch {
+		case !ok:
+			diff.Entered = append(diff.Entered, player)
+		case !reflect.DeepEqual(old, player):
+			diff.Moved = append(diff.Moved, model.PlayerMove{
+				UserID:        player.UserID,
+				Rank:          player.Rank,
+				PreviousRank:  old.Rank,
+				Score:         player.Score,
+				PreviousScore: old.Score,
+				Tier:          player.Tier,
+			})
+		}
+	}
+
+	// Keep the order of the previous view for players that left
+	for _, player := range previous {
+		if _, ok := before[player.UserID]; ok {
+			diff.Left = append(diff.Left, player.UserID)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// Board is a leaderboard definition. Scores are aggregated per player and per
+// period; boards with a reset schedule start a fresh period on every reset.
+type Board struct {
+	ID            string       `json:"id"`
+	Name          string       `json:"name"`
+	Aggregation   string       `json:"aggregation"`
+	SortOrder     string       `json:"sort_order"`
+	ScoreUnit     string       `json:"score_unit"`
+	ResetSchedule string       `json:"reset_schedule"`
+	GameModes     []string     `json:"game_modes"`
+	Decay         *DecayPolicy `json:"decay,omitempty"`
+	// EndsAt is set on event boards, e.g. tournament boards. They take no
+	// submits from then on, and their rewards are distributed after it.
+	EndsAt *time.Time `json:"ends_at,omitempty"`
+	// Owner names the subsystem managing the board, e.g. "tournament", and is
+	// empty for boards created through the API. Submit guards use it to find
+	// the boards they enforce rules on.
+	Owner     string    `json:"owner,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DecayPolicy lowers the scores of inactive players on an evergreen board.
+// Decay starts InactiveDays after a player's last submit and stops as soon as
+// they submit again; that submit adds to their undecayed score. Half-life
+// decay halves the score every HalfLifeDays; weekly decay deducts
+// WeeklyDeduction for every full week.
+type DecayPolicy struct {
+	Type            string `json:"type" validate:"required,oneof=half_life weekly"`
+	InactiveDays    int    `json:"inactive_days"`
+	HalfLifeDays    int    `json:"half_life_days,omitempty"`
+	WeeklyDeduction int64  `json:"weekly_deduction,omitempty"`
+}
+
+type CreateBoardRequest struct {
+	ID            string       `json:"id" validate:"required"`
+	Name          string       `json:"name" validate:"required"`
+	Aggregation   string       `json:"aggregation,omitempty" validate:"omitempty,oneof=sum max min latest count best"`
+	SortOrder     string       `json:"sort_order,omitempty" validate:"omitempty,oneof=desc asc"`
+	ScoreUnit     string       `json:"score_unit,omitempty" validate:"omitempty,oneof=points ms"`
+	ResetSchedule string       `json:"reset_schedule,omitempty" validate:"omitempty,oneof=none daily weekly monthly"`
+	GameModes     []string     `json:"game_modes,omitempty"`
+	Decay         *DecayPolicy `json:"decay,omitempty"`
+	EndsAt        *time.Time   `json:"ends_at,omitempty"`
+}
+
+type BoardResponse struct {
+	Success bool   `json:"success"`
+	Data    *Board `json:"data,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+type BoardsResponse struct {
+	Success bool    `json:"success"`
+	Boards  []Board `json:"boards"`
+	Error   string  `json:"error,omitempty"`
+	Code    string  `json:"code,omitempty"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// QueuedSubmission is a submit waiting on the ingest stream to be applied.
+type QueuedSubmission struct {
+	TrackingID string             `json:"tracking_id"`
+	GameID     string             `json:"game_id"`
+	Request    SubmitScoreRequest `json:"request"`
+	QueuedAt   time.Time          `json:"queued_at"`
+}
+
+// SubmissionStatus tracks a queued submit. Result is set once it was applied,
+// unless the attempt that applied it died before recording it; Error and
+// Code explain why it was rejected or failed, or why the latest
+// attempt of a submit that is still queued did not go through.
+type SubmissionStatus struct {
+	TrackingID string     `json:"tracking_id"`
+	Status     string     `json:"status"`
+	Attempts   int64      `json:"attempts,omitempty"`
+	QueuedAt   time.Time  `json:"queued_at"`
+	AppliedAt  *time.Time `json:"applied_at,omitempty"`
+	Result     *ScoreData `json:"result,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	Code       string     `json:"code,omitempty"`
+}
+
+type SubmissionStatusResponse struct {
+	Success bool              `json:"success"`
+	Data    *SubmissionStatus `json:"data,omitempty"`
+	Error   string            `json:"error,omitempty"`
+	Code    string            `json:"code,omitempty"`
+}
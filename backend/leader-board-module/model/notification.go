@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// RankNotification tells a single player that their standing on a board
+// changed because of someone's submit, e.g. they were overtaken by ByUserID
+// or they entered the top Milestone.
+type RankNotification struct {
+	Type       string    `json:"type"`
+	GameID     string    `json:"game_id"`
+	BoardID    string    `json:"board_id"`
+	UserID     int64     `json:"user_id"`
+	Rank       int       `json:"rank"`
+	Score      int64     `json:"score"`
+	ByUserID   int64     `json:"by_user_id,omitempty"`
+	ByScore    int64     `json:"by_score,omitempty"`
+	Milestone  int       `json:"milestone,omitempty"`
+	Message    string    `json:"message"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DomainEvent is an outbox entry as handed to event sinks. ID is stable
+// across redeliveries, so consumers can use it to drop duplicates.
+type DomainEvent struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	GameID     string          `json:"game_id"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// RankChangedEvent is the payload of a RankChanged event: a submit moved the
+// player to a different rank on a board. PreviousRank is 0 for a player new
+// to the period.
+type RankChangedEvent struct {
+	UserID        int64     `json:"user_id"`
+	BoardID       string    `json:"board_id"`
+	PeriodStart   time.Time `json:"period_start"`
+	Rank          int       `json:"rank"`
+	PreviousRank  int       `json:"previous_rank,omitempty"`
+	Score         int64     `json:"score"`
+	PreviousScore int64     `json:"previous_score"`
+	Timestamp     time.Time `json:"timestamp"`
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+type RankHistoryPoint struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Granularity string    `json:"granularity"`
+	Rank        int       `json:"rank"`
+	Score       int64     `json:"score"`
+}
+
+type RankHistoryData struct {
+	BoardID string             `json:"board_id"`
+	UserID  int64              `json:"user_id"`
+	From    time.Time          `json:"from"`
+	To      time.Time          `json:"to"`
+	Points  []RankHistoryPoint `json:"points"`
+	// RankChange is positive when the player climbed over the period.
+	RankChange int `json:"rank_change"`
+	BestRank   int `json:"best_rank,omitempty"`
+}
+
+type RankHistoryResponse struct {
+	Success bool             `json:"success"`
+	Data    *RankHistoryData `json:"data,omitempty"`
+	Error   string           `json:"error,omitempty"`
+	Code    string           `json:"code,omitempty"`
+}
@@ -3,41 +3,92 @@ package model
 import "time"
 
 type SubmitScoreRequest struct {
-	UserID   int64  `json:"user_id" validate:"required"`
+	UserID int64 `json:"user_id" validate:"required"`
+	// Score is in the unit of the targeted boards: points, or milliseconds for timed boards.
 	Score    int64  `json:"score" validate:"required,min=0"`
 	GameMode string `json:"game_mode" validate:"required,oneof=solo team"` // Add more modes as needed
+	Outcome  string `json:"outcome,omitempty" validate:"omitempty,oneof=win loss draw"`
+	// BoardIDs lists the leaderboards the score counts towards; defaults to the global board.
+	BoardIDs []string `json:"board_ids,omitempty"`
+	// TrackingID is set on submits queued by EnqueueScore; a submit with a
+	// tracking id is applied at most once.
+	TrackingID string `json:"-"`
 }
 
 type SubmitScoreResponse struct {
 	Success bool       `json:"success"`
 	Message string     `json:"message,omitempty"`
 	Data    *ScoreData `json:"data,omitempty"`
-	Error   string     `json:"error,omitempty"`
-	Code    string     `json:"code,omitempty"`
+	// TrackingID and Status are set instead of Data when the submit was
+	// queued for asynchronous ingestion.
+	TrackingID string `json:"tracking_id,omitempty"`
+	Status     string `json:"status,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Code       string `json:"code,omitempty"`
 }
 
 type ScoreData struct {
-	UserID    int64     `json:"user_id"`
-	Score     int64     `json:"score"`
-	Timestamp time.Time `json:"timestamp"`
+	UserID    int64        `json:"user_id"`
+	Score     int64        `json:"score"`
+	Timestamp time.Time    `json:"timestamp"`
+	Boards    []BoardScore `json:"boards"`
+}
+
+// BoardScore is the player's standing on one board after a submit.
+type BoardScore struct {
+	BoardID    string           `json:"board_id"`
+	TotalScore int64            `json:"total_score"`
+	Rank       int              `json:"rank,omitempty"`
+	Tier       *TierInfo        `json:"tier,omitempty"`
+	TierChange *TierChangeEvent `json:"tier_change,omitempty"`
 }
 
 type PlayerScore struct {
-	UserID int64 `json:"user_id"`
-	Score  int64 `json:"score"`
+	UserID int64     `json:"user_id"`
+	Rank   int       `json:"rank"`
+	Score  int64     `json:"score"`
+	Tier   *TierInfo `json:"tier,omitempty"`
 }
 
 type GetTopPlayersResponse struct {
-	Success bool          `json:"success"`
-	Players []PlayerScore `json:"players"`
-	Error   string        `json:"error,omitempty"`
-	Code    string        `json:"code,omitempty"`
+	Success     bool       `json:"success"`
+	BoardID     string     `json:"board_id,omitempty"`
+	ScoreUnit   string     `json:"score_unit,omitempty"`
+	PeriodStart *time.Time `json:"period_start,omitempty"`
+	// SnapshotAt is set when the standings were read from a snapshot.
+	SnapshotAt *time.Time `json:"snapshot_at,omitempty"`
+	// Version is the board version the players are at, when tracked.
+	Version int64 `json:"version,omitempty"`
+	// BaseVersion and Diff replace Players when the client asked for the
+	// changes since a version it already has.
+	BaseVersion int64            `json:"base_version,omitempty"`
+	Diff        *LeaderboardDiff `json:"diff,omitempty"`
+	Players     []PlayerScore    `json:"players"`
+	Error       string           `json:"error,omitempty"`
+	Code        string           `json:"code,omitempty"`
+}
+
+type FriendsLeaderboardResponse struct {
+	Success   bool          `json:"success"`
+	BoardID   string        `json:"board_id,omitempty"`
+	ScoreUnit string        `json:"score_unit,omitempty"`
+	UserID    int64         `json:"user_id"`
+	Players   []PlayerScore `json:"players"`
+	Error     string        `json:"error,omitempty"`
+	Code      string        `json:"code,omitempty"`
 }
 
 type PlayerRankData struct {
-	UserID int64 `json:"user_id"`
-	Rank   int   `json:"rank"`
-	Score  int64 `json:"score"`
+	BoardID      string    `json:"board_id"`
+	ScoreUnit    string    `json:"score_unit"`
+	UserID       int64     `json:"user_id"`
+	Rank         int       `json:"rank"`
+	Score        int64     `json:"score"`
+	TotalPlayers int       `json:"total_players"`
+	Percentile   float64   `json:"percentile"`
+	Tier         *TierInfo `json:"tier,omitempty"`
+	// SnapshotAt is set when the rank was read from a snapshot.
+	SnapshotAt *time.Time `json:"snapshot_at,omitempty"`
 }
 
 type PlayerRankResponse struct {
@@ -46,3 +97,49 @@ type PlayerRankResponse struct {
 	Error   string          `json:"error,omitempty"`
 	Code    string          `json:"code,omitempty"`
 }
+
+// SubmitRejection is returned by a submit guard that refuses a submission.
+type SubmitRejection struct {
+	Code    string
+	Message string
+}
+
+// ScoreSubmittedEvent is handed to score listeners once a submit has been stored.
+type ScoreSubmittedEvent struct {
+	UserID    int64              `json:"user_id"`
+	Score     int64              `json:"score"`
+	GameMode  string             `json:"game_mode"`
+	Outcome   string             `json:"outcome,omitempty"`
+	Boards    []BoardSubmitEvent `json:"boards"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+// BoardSubmitEvent is the effect of a submit on one board. Ranks are counted
+// right after the submit commits; PreviousRank is 0 for a player new to the
+// period.
+type BoardSubmitEvent struct {
+	BoardID       string    `json:"board_id"`
+	PeriodStart   time.Time `json:"period_start"`
+	TotalScore    int64     `json:"total_score"`
+	PreviousScore int64     `json:"previous_score"`
+	Rank          int       `json:"rank"`
+	PreviousRank  int       `json:"previous_rank,omitempty"`
+	IsNewPlayer   bool      `json:"is_new_player"`
+}
+
+// Board returns the event for the given board, or nil if the submit did not target it.
+func (e *ScoreSubmittedEvent) Board(boardID string) *BoardSubmitEvent {
+	for i := range e.Boards {
+		if e.Boards[i].BoardID == boardID {
+			return &e.Boards[i]
+		}
+	}
+	return nil
+}
+
+// LeaderboardChangedEvent is published whenever a board's version is bumped.
+type LeaderboardChangedEvent struct {
+	GameID  string `json:"game_id"`
+	BoardID string `json:"board_id"`
+	Version int64  `json:"version"`
+}
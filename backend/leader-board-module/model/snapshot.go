@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// Snapshot describes a stored point-in-time copy of a board period. The
+// standings themselves are only decoded when a snapshot is queried.
+type Snapshot struct {
+	ID          int64     `json:"id"`
+	BoardID     string    `json:"board_id"`
+	PeriodStart time.Time `json:"period_start"`
+	CapturedAt  time.Time `json:"captured_at"`
+	Trigger     string    `json:"trigger"`
+	Players     int       `json:"players"`
+	SizeBytes   int       `json:"size_bytes"`
+}
+
+type SnapshotResponse struct {
+	Success bool      `json:"success"`
+	Data    *Snapshot `json:"data,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	Code    string    `json:"code,omitempty"`
+}
+
+type SnapshotsResponse struct {
+	Success   bool       `json:"success"`
+	BoardID   string     `json:"board_id,omitempty"`
+	Snapshots []Snapshot `json:"snapshots"`
+	Error     string     `json:"error,omitempty"`
+	Code      string     `json:"code,omitempty"`
+}
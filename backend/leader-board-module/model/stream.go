@@ -0,0 +1,69 @@
+package model
+
+// LeaderboardView selects the part of a board a live subscriber watches: the
+// top Limit players, page Page of Limit players, or the Window players on
+// either side of UserID.
+type LeaderboardView struct {
+	Mode   string `json:"mode"`
+	Limit  int    `json:"limit,omitempty"`
+	Page   int    `json:"page,omitempty"`
+	UserID int64  `json:"user_id,omitempty"`
+	Window int    `json:"window,omitempty"`
+}
+
+// PlayerMove is a player that stayed in a view but changed rank or score.
+type PlayerMove struct {
+	UserID        int64     `json:"user_id"`
+	Rank          int       `json:"rank"`
+	PreviousRank  int       `json:"previous_rank"`
+	Score         int64     `json:"score"`
+	PreviousScore int64     `json:"previous_score"`
+	Tier          *TierInfo `json:"tier,omitempty"`
+}
+
+// LeaderboardDiff describes how a view changed between two updates.
+type LeaderboardDiff struct {
+	Entered []PlayerScore `json:"entered,omitempty"`
+	Left    []int64       `json:"left,omitempty"`
+	Moved   []PlayerMove  `json:"moved,omitempty"`
+}
+
+// StreamClientMessage is sent by WebSocket clients. Type is subscribe,
+// unsubscribe or ping; subscriptions are named by the client-chosen ID.
+// SinceVersion resumes a subscription from a version the client already has.
+type StreamClientMessage struct {
+	Type         string `json:"type"`
+	ID           string `json:"id,omitempty"`
+	BoardID      string `json:"board_id,omitempty"`
+	SinceVersion int64  `json:"since_version,omitempty"`
+	LeaderboardView
+}
+
+// StreamMessage acknowledges client messages and reports errors.
+type StreamMessage struct {
+	Type    string `json:"type"`
+	ID      string `json:"id,omitempty"`
+	BoardID string `json:"board_id,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+// StreamSnapshotMessage carries the full view, sent first on every
+// subscription that cannot resume from a diff.
+type StreamSnapshotMessage struct {
+	Type    string        `json:"type"`
+	ID      string        `json:"id,omitempty"`
+	BoardID string        `json:"board_id"`
+	Version int64         `json:"version"`
+	Players []PlayerScore `json:"players"`
+}
+
+// StreamDiffMessage carries the changes to a view from BaseVersion to Version.
+type StreamDiffMessage struct {
+	Type        string `json:"type"`
+	ID          string `json:"id,omitempty"`
+	BoardID     string `json:"board_id"`
+	Version     int64  `json:"version"`
+	BaseVersion int64  `json:"base_version"`
+	LeaderboardDiff
+}
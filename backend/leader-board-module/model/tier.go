@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// TierDefinition describes a single tier. A tier is matched either by an
+// absolute score threshold (MinScore) or by a percentile band (TopPercent),
+// e.g. TopPercent 1 means "top 1% of ranked players".
+type TierDefinition struct {
+	Name       string   `json:"name"`
+	MinScore   *int64   `json:"min_score,omitempty"`
+	TopPercent *float64 `json:"top_percent,omitempty"`
+	Divisions  int      `json:"divisions,omitempty"`
+}
+
+type TierInfo struct {
+	Name     string `json:"name"`
+	Division string `json:"division,omitempty"`
+	Label    string `json:"label"`
+}
+
+type TierChangeEvent struct {
+	GameID     string    `json:"game_id"`
+	BoardID    string    `json:"board_id"`
+	UserID     int64     `json:"user_id"`
+	Direction  string    `json:"direction"`
+	From       *TierInfo `json:"from"`
+	To         *TierInfo `json:"to"`
+	Score      int64     `json:"score"`
+	Rank       int       `json:"rank,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"gorm.io/gorm/clause"
+)
+
+type boardRow struct {
+	GameID        string     `gorm:"column:game_id"`
+	ID            string     `gorm:"column:id"`
+	Name          string     `gorm:"column:name"`
+	Aggregation   string     `gorm:"column:aggregation"`
+	SortOrder     string     `gorm:"column:sort_order"`
+	ScoreUnit     string     `gorm:"column:score_unit"`
+	ResetSchedule string     `gorm:"column:reset_schedule"`
+	GameModes     string     `gorm:"column:game_modes"`
+	EndsAt        *time.Time `gorm:"column:ends_at"`
+	Owner         string     `gorm:"column:owner"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+
+	DecayPolicy          string `gorm:"column:decay_policy"`
+	DecayInactiveDays    int    `gorm:"column:decay_inactive_days"`
+	DecayHalfLifeDays    int    `gorm:"column:decay_half_life_days"`
+	DecayWeeklyDeduction int64  `gorm:"column:decay_weekly_deduction"`
+}
+
+func (boardRow) TableName() string {
+	return "gaming.leaderboards"
+}
+
+// CreateBoard stores a new board definition. Creating an id that already
+// exists fails with ErrBoardExists.
+func (r *LeaderboardRepository) CreateBoard(ctx context.Context, board *model.Board) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	row := boardRow{
+		GameID:        gameID,
+		ID:            board.ID,
+		Name:          board.Name,
+		Aggregation:   board.Aggregation,
+		SortOrder:     board.SortOrder,
+		ScoreUnit:     board.ScoreUnit,
+		ResetSchedule: board.ResetSchedule,
+		GameModes:     strings.Join(board.GameModes, ","),
+		EndsAt:        board.EndsAt,
+		Owner:         board.Owner,
+		CreatedAt:     board.CreatedAt,
+		DecayPolicy:   constants.DecayNone,
+	}
+	if board.Decay != nil {
+		row.DecayPolicy = board.Decay.Type
+		row.DecayInactiveDays = board.Decay.InactiveDays
+		row.DecayHalfLifeDays = board.Decay.HalfLifeDays
+		row.DecayWeeklyDeduction = board.Decay.WeeklyDeduction
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&row)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create board: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(constants.ErrBoardExists)
+	}
+	return nil
+}
+
+func (r *LeaderboardRepository) GetBoard(ctx context.Context, boardID string) (*model.Board, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []boardRow
+	if err := r.db.WithContext(ctx).
+		Where("game_id = ? AND id = ?", gameID, boardID).
+		Limit(1).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New(constants.ErrBoardNotFound)
+	}
+	return rows[0].toModel(), nil
+}
+
+func (r *LeaderboardRepository) ListBoards(ctx context.Context) ([]model.Board, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []boardRow
+	if err := r.db.WithContext(ctx).
+		Where("game_id = ?", gameID).
+		Order("created_at, id").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list boards: %w", err)
+	}
+
+	boards := make([]model.Board, 0, len(rows))
+	for _, row := range rows {
+		boards = append(boards, *row.toModel())
+	}
+	return boards, nil
+}
+
+func (row boardRow) toModel() *model.Board {
+	modes := []string{}
+	if row.GameModes != "" {
+		modes = strings.Split(row.GameModes, ",")
+	}
+
+	board := &model.Board{
+		ID:            row.ID,
+		Name:          row.Name,
+		Aggregation:   row.Aggregation,
+		SortOrder:     row.SortOrder,
+		ScoreUnit:     row.ScoreUnit,
+		ResetSchedule: row.ResetSchedule,
+		GameModes:     modes,
+		EndsAt:        row.EndsAt,
+		Owner:         row.Owner,
+		CreatedAt:     row.CreatedAt,
+	}
+	if row.DecayPolicy != "" && row.DecayPolicy != constants.DecayNone {
+		board.Decay = &model.DecayPolicy{
+			Type:            row.DecayPolicy,
+			InactiveDays:    row.DecayInactiveDays,
+			HalfLifeDays:    row.DecayHalfLifeDays,
+			WeeklyDeduction: row.DecayWeeklyDeduction,
+		}
+	}
+	return board
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+)
+
+type decayRow struct {
+	ID         int64     `gorm:"column:id"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+	TotalScore int64     `gorm:"column:total_score"`
+	RawScore   int64     `gorm:"column:raw_score"`
+	Decayed    int64     `gorm:"-"`
+}
+
+// ApplyDecay lowers the stored score of every player on the board who has been
+// inactive for longer than the policy allows, so top lists, ranks and the
+// stream all read the decayed score. The undecayed score is kept in raw_score
+// and every run derives the decayed score from it for the whole inactive
+// time, so runs never compound and re-running for the same now is a no-op.
+// Rows changed by a concurrent submit are skipped and picked up on the next run.
+func (r *LeaderboardRepository) ApplyDecay(
+	ctx context.Context,
+	scope BoardScope,
+	policy *model.DecayPolicy,
+	now time.Time,
+) (int64, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	switch policy.Type {
+	case constants.DecayHalfLife, constants.DecayWeekly:
+	default:
+		return 0, fmt.Errorf("unknown decay policy %q", policy.Type)
+	}
+
+	inactiveSince := now.AddDate(0, 0, -policy.InactiveDays)
+	var applied int64
+	var afterID int64
+
+	for {
+		var rows []decayRow
+		if err := r.db.WithContext(ctx).Raw(`
+			SELECT id, updated_at, total_score, COALESCE(raw_score, total_score) AS raw_score
+			FROM gaming.leaderboard
+			WHERE game_id = ?
+				AND board_id = ?
+				AND period_start = ?
+				AND id > ?
+				AND COALESCE(raw_score, total_score) > 0
+				AND updated_at < ?
+			ORDER BY id
+			LIMIT ?
+		`, gameID, scope.BoardID, scope.PeriodStart, afterID, inactiveSince, constants.DecayBatchSize).Scan(&rows).Error; err != nil {
+			return applied, fmt.Errorf("failed to read inactive scores: %w", err)
+		}
+
+		if due := decayRows(rows, policy, inactiveSince); len(due) > 0 {
+			values := make([]string, 0, len(due))
+			args := make([]interface{}, 0, 5*len(due)+1)
+			args = append(args, now)
+			for _, row := range due {
+				values = append(values, "(?::bigint, ?::timestamp, ?::bigint, ?::bigint, ?::bigint)")
+				args = append(args, row.ID, row.UpdatedAt, row.TotalScore, row.RawScore, row.Decayed)
+			}
+
+			// A row whose score or activity changed since it was read is left
+			// for the next run
+			result := r.db.WithContext(ctx).Exec(`
+				UPDATE gaming.leaderboard lb
+				SET
+					total_score = due.score,
+					raw_score = due.raw,
+					decayed_through = ?
+				FROM (VALUES `+strings.Join(values, ", ")+`) AS due(id, updated_at, stored, raw, score)
+				WHERE lb.id = due.id
+					AND lb.updated_at = due.updated_at
+					AND lb.total_score = due.stored
+			`, args...)
+			if result.Error != nil {
+				return applied, fmt.Errorf("failed to apply score decay: %w", result.Error)
+			}
+			applied += result.RowsAffected
+		}
+
+		if len(rows) < constants.DecayBatchSize {
+			break
+		}
+		afterID = rows[len(rows)-1].ID
+	}
+
+	if applied > 0 {
+		r.bumpLeaderboardVersion(ctx, gameID, scope.BoardID)
+	}
+	return applied, nil
+}
+
+// decayRows returns the rows whose decayed score differs from their stored
+// score, with Decayed set. Decay starts once a row has been inactive since
+// inactiveSince.
+func decayRows(rows []decayRow, policy *model.DecayPolicy, inactiveSince time.Time) []decayRow {
+	var due []decayRow
+	for _, row := range rows {
+		row.Decayed = decayedScore(policy, row.RawScore, inactiveSince.Sub(row.UpdatedAt))
+		if row.Decayed != row.TotalScore {
+			due = append(due, row)
+		}
+	}
+	return due
+}
+
+// decayedScore is what is left of a raw score after decaying for elapsed
+// under the policy. Half-life decay halves it every HalfLifeDays, rounding
+// down; weekly decay deducts WeeklyDeduction for every full week, down to 0.
+func decayedScore(policy *model.DecayPolicy, raw int64, elapsed time.Duration) int64 {
+	if elapsed <= 0 {
+		return raw
+	}
+
+	switch policy.Type {
+	case constants.DecayHalfLife:
+		halfLife := time.Duration(policy.HalfLifeDays) * 24 * time.Hour
+		return int64(math.Floor(float64(raw) * math.Pow(0.5, elapsed.Seconds()/halfLife.Seconds())))
+	case constants.DecayWeekly:
+		weeks := int64(elapsed / (7 * 24 * time.Hour))
+		return max(raw-policy.WeeklyDeduction*weeks, 0)
+	}
+	return raw
+}
@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	submissionStatusKey = "leaderboard:%s:submission:%s" // gameID, trackingID
+	submissionStatusTTL = 24 * time.Hour
+)
+
+var errIngestDisabled = errors.New("asynchronous ingestion requires Redis")
+
+// IngestMessage is a queued submit read from the ingest stream. Deliveries
+// counts how often it was handed to a worker, this time included. Submission
+// is nil when the message could not be decoded.
+type IngestMessage struct {
+	ID         string
+	Deliveries int64
+	Submission *model.QueuedSubmission
+}
+
+// IngestOutcome settles a message: it is acknowledged and removed from the
+// stream, and Status, when set, replaces the submission's status.
+type IngestOutcome struct {
+	MessageID string
+	GameID    string
+	Status    *model.SubmissionStatus
+}
+
+// EnqueueSubmission queues a submit on the ingest stream. Its status and the
+// stream entry are written in one MULTI/EXEC, so the status can be looked up
+// as soon as a worker could apply the submit.
+func (r *LeaderboardRepository) EnqueueSubmission(ctx context.Context, submission *model.QueuedSubmission) error {
+	if r.redis == nil {
+		return errIngestDisabled
+	}
+
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+	submission.GameID = gameID
+
+	payload, err := json.Marshal(submission)
+	if err != nil {
+		return fmt.Errorf("failed to marshal queued submission: %w", err)
+	}
+	status, err := json.Marshal(&model.SubmissionStatus{
+		TrackingID: submission.TrackingID,
+		Status:     constants.SubmissionQueued,
+		QueuedAt:   submission.QueuedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal submission status: %w", err)
+	}
+
+	if _, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, fmt.Sprintf(submissionStatusKey, gameID, submission.TrackingID), status, submissionStatusTTL)
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: constants.IngestStream,
+			Values: map[string]interface{}{"payload": payload},
+		})
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to queue submission: %w", err)
+	}
+	return nil
+}
+
+func (r *LeaderboardRepository) GetSubmissionStatus(ctx context.Context, trackingID string) (*model.SubmissionStatus, error) {
+	if r.redis == nil {
+		return nil, errors.New(constants.ErrSubmissionNotFound)
+	}
+
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := r.redis.Get(ctx, fmt.Sprintf(submissionStatusKey, gameID, trackingID)).Bytes()
+	if err == redis.Nil {
+		return nil, errors.New(constants.ErrSubmissionNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var status model.SubmissionStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal submission status: %w", err)
+	}
+	return &status, nil
+}
+
+// SaveSubmissionStatus records the outcome of an attempt that leaves the
+// submission queued.
+func (r *LeaderboardRepository) SaveSubmissionStatus(ctx context.Context, status *model.SubmissionStatus) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal submission status: %w", err)
+	}
+	return r.redis.Set(ctx, fmt.Sprintf(submissionStatusKey, gameID, status.TrackingID), data, submissionStatusTTL).Err()
+}
+
+// EnsureIngestGroup creates the ingest stream and its consumer group. A new
+// group starts at the beginning of the stream, so submits queued before any
+// worker ran are applied too.
+func (r *LeaderboardRepository) EnsureIngestGroup(ctx context.Context) error {
+	if r.redis == nil {
+		return errIngestDisabled
+	}
+
+	err := r.redis.XGroupCreateMkStream(ctx, constants.IngestStream, constants.IngestGroup, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+// ReadIngest hands up to count new messages to consumer, waiting up to block
+// for the first one.
+func (r *LeaderboardRepository) ReadIngest(ctx context.Context, consumer string, count int64, block time.Duration) ([]IngestMessage, error) {
+	streams, err := r.redis.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    constants.IngestGroup,
+		Consumer: consumer,
+		Streams:  []string{constants.IngestStream, ">"},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []IngestMessage
+	for _, stream := range streams {
+		for _, message := range stream.Messages {
+			messages = append(messages, decodeIngestMessage(message, 1))
+		}
+	}
+	return messages, nil
+}
+
+// ClaimStaleIngest moves up to count messages that another consumer, or an
+// earlier failed attempt, left unacknowledged for at least minIdle over to
+// consumer.
+func (r *LeaderboardRepository) ClaimStaleIngest(ctx context.Context, consumer string, minIdle time.Duration, count int64) ([]IngestMessage, error) {
+	pending, err := r.redis.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: constants.IngestStream,
+		Group:  constants.IngestGroup,
+		Start:  "-",
+		End:    "+",
+		Count:  count,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make(map[string]int64, len(pending))
+	ids := make([]string, 0, len(pending))
+	for _, entry := range pending {
+		if entry.Idle >= minIdle {
+			deliveries[entry.ID] = entry.RetryCount
+			ids = append(ids, entry.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	// Claiming skips messages another consumer claimed in the meantime
+	claimed, err := r.redis.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   constants.IngestStream,
+		Group:    constants.IngestGroup,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]IngestMessage, 0, len(claimed))
+	for _, message := range claimed {
+		messages = append(messages, decodeIngestMessage(message, deliveries[message.ID]+1))
+	}
+	return messages, nil
+}
+
+// CompleteIngest settles the given messages in a single transaction.
+func (r *LeaderboardRepository) CompleteIngest(ctx context.Context, outcomes []IngestOutcome) error {
+	if len(outcomes) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(outcomes))
+	statuses := make(map[string][]byte, len(outcomes))
+	for _, outcome := range outcomes {
+		ids = append(ids, outcome.MessageID)
+		if outcome.Status == nil {
+			continue
+		}
+		data, err := json.Marshal(outcome.Status)
+		if err != nil {
+			return fmt.Errorf("failed to marshal submission status: %w", err)
+		}
+		statuses[fmt.Sprintf(submissionStatusKey, outcome.GameID, outcome.Status.TrackingID)] = data
+	}
+
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, data := range statuses {
+			pipe.Set(ctx, key, data, submissionStatusTTL)
+		}
+		pipe.XAck(ctx, constants.IngestStream, constants.IngestGroup, ids...)
+		pipe.XDel(ctx, constants.IngestStream, ids...)
+		return nil
+	})
+	return err
+}
+
+func decodeIngestMessage(message redis.XMessage, deliveries int64) IngestMessage {
+	decoded := IngestMessage{ID: message.ID, Deliveries: deliveries}
+
+	payload, _ := message.Values["payload"].(string)
+	var submission model.QueuedSubmission
+	if err := json.Unmarshal([]byte(payload), &submission); err == nil && submission.GameID != "" {
+		decoded.Submission = &submission
+	}
+	return decoded
+}
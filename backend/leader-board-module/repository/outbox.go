@@ -0,0 +1,352 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"gorm.io/gorm"
+)
+
+// OutboxEvent is a claimed outbox row. Attempts includes the current one.
+// RanksPending marks a ScoreSubmitted event whose submit has not recorded its
+// ranks yet.
+type OutboxEvent struct {
+	model.DomainEvent
+	Attempts     int
+	RanksPending bool
+}
+
+type outboxRow struct {
+	ID           int64     `gorm:"column:id"`
+	GameID       string    `gorm:"column:game_id"`
+	EventType    string    `gorm:"column:event_type"`
+	Payload      string    `gorm:"column:payload"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	Attempts     int       `gorm:"column:attempts"`
+	RanksPending bool      `gorm:"column:ranks_pending"`
+}
+
+// SubmitRank is the rank of a submit on one of its boards. PreviousRank is 0
+// for a player new to the period.
+type SubmitRank struct {
+	BoardID      string
+	Rank         int
+	PreviousRank int
+}
+
+// writeSubmitEvents records the ScoreSubmitted event of a submit in its
+// transaction and returns its id. Only the scores are recorded: counting
+// ranks here would scan the board twice while the submit holds its row locks,
+// so the submit records them with RecordSubmitRanks once it committed.
+func (r *LeaderboardRepository) writeSubmitEvents(
+	tx *gorm.DB,
+	gameID string,
+	userID int64,
+	score int64,
+	gameMode string,
+	outcome string,
+	scopes []BoardScope,
+	boards []BoardSubmission,
+	now time.Time,
+) (int64, error) {
+	submitted := model.ScoreSubmittedEvent{
+		UserID:    userID,
+		Score:     score,
+		GameMode:  gameMode,
+		Outcome:   outcome,
+		Boards:    make([]model.BoardSubmitEvent, 0, len(boards)),
+		Timestamp: now,
+	}
+	for i, scope := range scopes {
+		submitted.Boards = append(submitted.Boards, model.BoardSubmitEvent{
+			BoardID:       boards[i].BoardID,
+			PeriodStart:   scope.PeriodStart,
+			TotalScore:    boards[i].TotalScore,
+			PreviousScore: boards[i].PreviousScore,
+			IsNewPlayer:   boards[i].IsNewPlayer,
+		})
+	}
+
+	return insertOutboxEvent(tx, gameID, constants.EventScoreSubmitted, submitted, true, now)
+}
+
+// RecordSubmitRanks stores the ranks a submit counted right after it
+// committed in its ScoreSubmitted event, and records a RankChanged event for
+// every board where the player moved. Events the relay already ranked are
+// left alone.
+func (r *LeaderboardRepository) RecordSubmitRanks(ctx context.Context, eventID int64, ranks []SubmitRank) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stored []string
+		if err := tx.Raw(`
+			SELECT payload
+			FROM gaming.event_outbox
+			WHERE id = ? AND game_id = ? AND ranks_pending
+			FOR UPDATE
+		`, eventID, gameID).Scan(&stored).Error; err != nil {
+			return fmt.Errorf("failed to read submit event: %w", err)
+		}
+		if len(stored) == 0 {
+			return nil
+		}
+
+		var submitted model.ScoreSubmittedEvent
+		if err := json.Unmarshal([]byte(stored[0]), &submitted); err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", constants.EventScoreSubmitted, err)
+		}
+		for _, rank := range ranks {
+			if board := submitted.Board(rank.BoardID); board != nil {
+				board.Rank = rank.Rank
+				board.PreviousRank = rank.PreviousRank
+			}
+		}
+
+		_, _, err := storeSubmitRanks(tx, gameID, eventID, &submitted, now)
+		return err
+	})
+}
+
+// RankSubmitEvent counts the ranks of a ScoreSubmitted event the relay
+// claimed while they were still pending, which only happens when the submit
+// could not record them itself, e.g. because its process died right after
+// the commit. Ranks then reflect the standings when the relay got to the
+// event rather than at the submit. Like the rest of the outbox this is not
+// scoped to the context's game.
+func (r *LeaderboardRepository) RankSubmitEvent(ctx context.Context, event *OutboxEvent) error {
+	var submitted model.ScoreSubmittedEvent
+	if err := json.Unmarshal(event.Payload, &submitted); err != nil {
+		return fmt.Errorf("failed to decode %s event: %w", event.Type, err)
+	}
+
+	gameCtx := global.WithGameID(ctx, event.GameID)
+	for i := range submitted.Boards {
+		result := &submitted.Boards[i]
+
+		board, err := r.GetBoard(gameCtx, result.BoardID)
+		if err != nil {
+			// A board deleted since the submit has no ranks left to report
+			if err.Error() == constants.ErrBoardNotFound {
+				continue
+			}
+			return err
+		}
+		scope := BoardScope{
+			BoardID:     board.ID,
+			PeriodStart: result.PeriodStart,
+			Aggregation: board.Aggregation,
+			SortOrder:   board.SortOrder,
+		}
+
+		// The player's own row may since have moved on, so it is left out
+		ahead, err := countAhead(r.db.WithContext(ctx), event.GameID, scope, result.TotalScore, submitted.UserID)
+		if err != nil {
+			return err
+		}
+		result.Rank = ahead + 1
+
+		if !result.IsNewPlayer {
+			if ahead, err = countAhead(r.db.WithContext(ctx), event.GameID, scope, result.PreviousScore, submitted.UserID); err != nil {
+				return err
+			}
+			result.PreviousRank = ahead + 1
+		}
+	}
+
+	var payload []byte
+	now := time.Now().UTC()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stored bool
+		var err error
+		if stored, payload, err = storeSubmitRanks(tx, event.GameID, event.ID, &submitted, now); err != nil || stored {
+			return err
+		}
+
+		// Ranked by the submit or a relay whose lease ran out; those ranks stand
+		var current string
+		if err := tx.Raw(`SELECT payload FROM gaming.event_outbox WHERE id = ?`, event.ID).Scan(&current).Error; err != nil {
+			return fmt.Errorf("failed to read event ranks: %w", err)
+		}
+		payload = []byte(current)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	event.Payload = json.RawMessage(payload)
+	event.RanksPending = false
+	return nil
+}
+
+// storeSubmitRanks writes the ranks of a ScoreSubmitted event still pending
+// them, together with a RankChanged event for every board where the player
+// moved, so redeliveries carry the same ranks. It reports false and writes
+// nothing if the event was ranked already.
+func storeSubmitRanks(tx *gorm.DB, gameID string, eventID int64, submitted *model.ScoreSubmittedEvent, now time.Time) (bool, []byte, error) {
+	payload, err := json.Marshal(submitted)
+	if err != nil {
+		return false, nil, err
+	}
+
+	result := tx.Exec(`
+		UPDATE gaming.event_outbox
+		SET payload = CAST(? AS JSONB), ranks_pending = FALSE
+		WHERE id = ? AND ranks_pending
+	`, string(payload), eventID)
+	if result.Error != nil {
+		return false, nil, fmt.Errorf("failed to store event ranks: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, nil, nil
+	}
+
+	for _, board := range submitted.Boards {
+		// Boards deleted before the relay ranked them have no rank
+		if board.Rank == 0 || board.Rank == board.PreviousRank {
+			continue
+		}
+		change := model.RankChangedEvent{
+			UserID:        submitted.UserID,
+			BoardID:       board.BoardID,
+			PeriodStart:   board.PeriodStart,
+			Rank:          board.Rank,
+			PreviousRank:  board.PreviousRank,
+			Score:         board.TotalScore,
+			PreviousScore: board.PreviousScore,
+			Timestamp:     submitted.Timestamp,
+		}
+		if _, err := insertOutboxEvent(tx, gameID, constants.EventRankChanged, change, false, now); err != nil {
+			return false, nil, err
+		}
+	}
+	return true, payload, nil
+}
+
+// countAhead counts the players ahead of score, leaving out excludeUserID
+// when it is set.
+func countAhead(db *gorm.DB, gameID string, scope BoardScope, score int64, excludeUserID int64) (int, error) {
+	var count int64
+	if err := db.Raw(`
+		SELECT COUNT(*)
+		FROM gaming.leaderboard
+		WHERE game_id = ? AND board_id = ? AND period_start = ?
+		  AND total_score `+aheadOp(scope.SortOrder)+` ?
+		  AND user_id <> ?
+	`, gameID, scope.BoardID, scope.PeriodStart, score, excludeUserID).Scan(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count players ahead: %w", err)
+	}
+	return int(count), nil
+}
+
+func insertOutboxEvent(tx *gorm.DB, gameID, eventType string, payload interface{}, ranksPending bool, now time.Time) (int64, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	if err := tx.Raw(`
+		INSERT INTO gaming.event_outbox (game_id, event_type, payload, ranks_pending, created_at, next_attempt_at)
+		VALUES (?, ?, CAST(? AS JSONB), ?, ?, ?)
+		RETURNING id
+	`, gameID, eventType, string(data), ranksPending, now, now).Scan(&id).Error; err != nil {
+		return 0, fmt.Errorf("failed to write %s event: %w", eventType, err)
+	}
+	return id, nil
+}
+
+// ClaimOutboxEvents leases up to limit due events, oldest first, until
+// leaseUntil. Events still pending ranks are only claimed once created before
+// unrankedBefore, leaving the submit time to record them. The outbox is
+// shared by all games, so unlike most reads this is not scoped to the
+// context's game. Rows another relay holds are skipped.
+func (r *LeaderboardRepository) ClaimOutboxEvents(ctx context.Context, now, leaseUntil, unrankedBefore time.Time, limit int) ([]OutboxEvent, error) {
+	var rows []outboxRow
+	if err := r.db.WithContext(ctx).Raw(`
+		UPDATE gaming.event_outbox
+		SET next_attempt_at = ?, attempts = attempts + 1
+		WHERE id IN (
+			SELECT id
+			FROM gaming.event_outbox
+			WHERE published_at IS NULL
+				AND next_attempt_at <= ?
+				AND (NOT ranks_pending OR created_at < ?)
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, game_id, event_type, payload, created_at, attempts, ranks_pending
+	`, leaseUntil, now, unrankedBefore, limit).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	// RETURNING does not keep the subquery's order
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+
+	events := make([]OutboxEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, OutboxEvent{
+			DomainEvent: model.DomainEvent{
+				ID:         row.ID,
+				Type:       row.EventType,
+				GameID:     row.GameID,
+				Payload:    json.RawMessage(row.Payload),
+				OccurredAt: row.CreatedAt,
+			},
+			Attempts:     row.Attempts,
+			RanksPending: row.RanksPending,
+		})
+	}
+	return events, nil
+}
+
+// MarkOutboxPublished records that the events reached the sink.
+func (r *LeaderboardRepository) MarkOutboxPublished(ctx context.Context, ids []int64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).Exec(`
+		UPDATE gaming.event_outbox
+		SET published_at = ?, last_error = NULL
+		WHERE id IN ?
+	`, at, ids).Error; err != nil {
+		return fmt.Errorf("failed to mark outbox events published: %w", err)
+	}
+	return nil
+}
+
+// RetryOutboxEvent schedules another attempt of an event the sink refused.
+func (r *LeaderboardRepository) RetryOutboxEvent(ctx context.Context, id int64, nextAttempt time.Time, cause string) error {
+	if err := r.db.WithContext(ctx).Exec(`
+		UPDATE gaming.event_outbox
+		SET next_attempt_at = ?, last_error = ?
+		WHERE id = ? AND published_at IS NULL
+	`, nextAttempt, cause, id).Error; err != nil {
+		return fmt.Errorf("failed to reschedule outbox event: %w", err)
+	}
+	return nil
+}
+
+// PruneOutbox deletes events published before the given time.
+func (r *LeaderboardRepository) PruneOutbox(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		DELETE FROM gaming.event_outbox
+		WHERE published_at IS NOT NULL AND published_at < ?
+	`, before)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to prune outbox: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
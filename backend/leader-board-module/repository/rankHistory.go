@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"gorm.io/gorm"
+)
+
+const jobLockKey = "leaderboard:job:%s:%d" // job name, run slot
+
+type RankHistoryEntry struct {
+	CapturedAt  time.Time `gorm:"column:captured_at"`
+	Granularity string    `gorm:"column:granularity"`
+	Rank        int       `gorm:"column:rank"`
+	TotalScore  int64     `gorm:"column:total_score"`
+}
+
+// AcquireJobLock makes sure a periodic job runs on a single instance per slot.
+// Without Redis every instance is allowed to run; jobs must stay idempotent.
+func (r *LeaderboardRepository) AcquireJobLock(ctx context.Context, job string, slot time.Time, ttl time.Duration) bool {
+	if r.redis == nil {
+		return true
+	}
+
+	acquired, err := r.redis.SetNX(ctx, fmt.Sprintf(jobLockKey, job, slot.Unix()), 1, ttl).Result()
+	if err != nil {
+		r.logger.Warn("Failed to acquire job lock", "job", job, "error", err)
+		return false
+	}
+	return acquired
+}
+
+// SnapshotRanks ranks every player of a board period, refreshes
+// gaming.leaderboard.rank and records the result in rank_history.
+// Re-running for the same capturedAt is a no-op.
+func (r *LeaderboardRepository) SnapshotRanks(ctx context.Context, scope BoardScope, capturedAt time.Time) (int64, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	result := r.db.WithContext(ctx).Exec(`
+		WITH ranked AS (
+			SELECT
+				id,
+				user_id,
+				total_score,
+				RANK() OVER (ORDER BY `+orderExpr(scope.SortOrder)+`) AS rank
+			FROM gaming.leaderboard
+			WHERE game_id = ? AND board_id = ? AND period_start = ?
+		),
+		refreshed AS (
+			UPDATE gaming.leaderboard lb
+			SET rank = ranked.rank
+			FROM ranked
+			WHERE lb.id = ranked.id
+				AND lb.rank IS DISTINCT FROM ranked.rank
+		)
+		INSERT INTO gaming.rank_history (game_id, board_id, user_id, captured_at, granularity, rank, total_score)
+		SELECT ?, ?, user_id, ?, ?, rank, total_score
+		FROM ranked
+		ON CONFLICT (game_id, board_id, user_id, captured_at) DO NOTHING
+	`, gameID, scope.BoardID, scope.PeriodStart, gameID, scope.BoardID, capturedAt, constants.RankHistoryHourly)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to snapshot ranks: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// CompactRankHistory keeps only the last hourly snapshot of each day for
+// snapshots older than compactBefore (re-labelled as daily) and drops
+// everything older than retainAfter.
+func (r *LeaderboardRepository) CompactRankHistory(ctx context.Context, compactBefore, retainAfter time.Time) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			CREATE TEMP TABLE rank_history_keep ON COMMIT DROP AS
+			SELECT DISTINCT ON (board_id, user_id, date_trunc('day', captured_at))
+				board_id, user_id, captured_at
+			FROM gaming.rank_history
+			WHERE game_id = ? AND granularity = ? AND captured_at < ?
+			ORDER BY board_id, user_id, date_trunc('day', captured_at), captured_at DESC
+		`, gameID, constants.RankHistoryHourly, compactBefore).Error; err != nil {
+			return fmt.Errorf("failed to select daily rank snapshots: %w", err)
+		}
+
+		if err := tx.Exec(`
+			DELETE FROM gaming.rank_history rh
+			WHERE rh.game_id = ?
+				AND rh.granularity = ?
+				AND rh.captured_at < ?
+				AND NOT EXISTS (
+					SELECT 1 FROM rank_history_keep k
+					WHERE k.board_id = rh.board_id
+						AND k.user_id = rh.user_id
+						AND k.captured_at = rh.captured_at
+				)
+		`, gameID, constants.RankHistoryHourly, compactBefore).Error; err != nil {
+			return fmt.Errorf("failed to delete hourly rank snapshots: %w", err)
+		}
+
+		if err := tx.Exec(`
+			UPDATE gaming.rank_history rh
+			SET granularity = ?
+			FROM rank_history_keep k
+			WHERE rh.game_id = ?
+				AND k.board_id = rh.board_id
+				AND k.user_id = rh.user_id
+				AND k.captured_at = rh.captured_at
+		`, constants.RankHistoryDaily, gameID).Error; err != nil {
+			return fmt.Errorf("failed to compact rank snapshots: %w", err)
+		}
+
+		if err := tx.Exec(`
+			DELETE FROM gaming.rank_history WHERE game_id = ? AND captured_at < ?
+		`, gameID, retainAfter).Error; err != nil {
+			return fmt.Errorf("failed to expire rank snapshots: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *LeaderboardRepository) GetRankHistory(
+	ctx context.Context,
+	boardID string,
+	userID int64,
+	from time.Time,
+	to time.Time,
+) ([]RankHistoryEntry, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []RankHistoryEntry
+	err = r.db.WithContext(ctx).
+		Table("gaming.rank_history").
+		Select("captured_at, granularity, rank, total_score").
+		Where("game_id = ? AND board_id = ? AND user_id = ? AND captured_at BETWEEN ? AND ?", gameID, boardID, userID, from, to).
+		Order("captured_at").
+		Find(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rank history: %w", err)
+	}
+
+	return entries, nil
+}
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
 	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
 	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
 	"github.com/go-redis/redis/v8"
 	"gorm.io/gorm"
@@ -17,12 +20,26 @@ const (
 	cacheTTL          = 5 * time.Minute
 	maxRetries        = 3
 	initialRetryDelay = 100 * time.Millisecond
+	playerCountTTL    = time.Hour
+	friendsCacheTTL   = 30 * time.Second
 
-	leaderboardVersionKey = "leaderboard:version"
-	topPlayersCacheKey    = "leaderboard:top:%d:%d"    // version, limit
-	playerRankCacheKey    = "leaderboard:player:%d:%d" // version, userID
+	// Every key is prefixed with the game (tenant) it belongs to
+	leaderboardVersionKey = "leaderboard:%s:version:%s"          // gameID, boardID
+	playerCountKey        = "leaderboard:%s:players:%s:%d"       // gameID, boardID, period
+	topPlayersCacheKey    = "leaderboard:%s:top:%s:%d:%d:%d"     // gameID, boardID, period, version, limit
+	playerRankCacheKey    = "leaderboard:%s:player:%s:%d:%d:%d"  // gameID, boardID, period, version, userID
+	friendsCacheKey       = "leaderboard:%s:friends:%s:%d:%d:%d" // gameID, boardID, period, version, userID
 )
 
+// incrIfExistsScript only bumps the player count once it has been seeded,
+// so a missing key is recounted from the database instead of starting at 1.
+var incrIfExistsScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return redis.call('INCR', KEYS[1])
+end
+return 0
+`)
+
 type LeaderboardEntry struct {
 	UserID     int64 `gorm:"column:user_id" json:"user_id"`
 	TotalScore int64 `gorm:"column:total_score" json:"total_score"`
@@ -34,10 +51,43 @@ type PlayerRank struct {
 	Score  int64 `gorm:"column:total_score" json:"score"`
 }
 
+// BoardScope identifies the board, and the period of that board, a read or
+// write applies to, along with how the board aggregates and orders scores.
+type BoardScope struct {
+	BoardID     string
+	PeriodStart time.Time
+	Aggregation string
+	SortOrder   string
+}
+
+// BoardSubmission is the effect of a submit on a single board.
+type BoardSubmission struct {
+	BoardID       string
+	TotalScore    int64
+	PreviousScore int64
+	IsNewPlayer   bool
+}
+
+// ScoreSubmission is the outcome of a successful SubmitScore call. EventID
+// is the submit's ScoreSubmitted outbox event, whose ranks are recorded with
+// RecordSubmitRanks.
+type ScoreSubmission struct {
+	Timestamp time.Time
+	Boards    []BoardSubmission
+	EventID   int64
+}
+
 type ILeaderboardRepository interface {
-	SubmitScore(ctx context.Context, userID, score int64, gameMode string) (time.Time, error)
-	GetTopPlayers(ctx context.Context, limit int) ([]LeaderboardEntry, error)
-	GetPlayerRank(ctx context.Context, userID int64) (*PlayerRank, error)
+	SubmitScore(ctx context.Context, userID, score int64, gameMode, outcome, trackingID string, scopes []BoardScope) (*ScoreSubmission, error)
+	GetTopPlayers(ctx context.Context, scope BoardScope, limit int) ([]LeaderboardEntry, error)
+	GetPlayerRank(ctx context.Context, scope BoardScope, userID int64) (*PlayerRank, error)
+	GetFriendsLeaderboard(ctx context.Context, scope BoardScope, userID int64) ([]PlayerRank, error)
+	TotalPlayers(ctx context.Context, scope BoardScope) (int, error)
+	CountPlayersAhead(ctx context.Context, scope BoardScope, score int64) (int, error)
+	PublishTierChange(ctx context.Context, event *model.TierChangeEvent) error
+	CreateBoard(ctx context.Context, board *model.Board) error
+	GetBoard(ctx context.Context, boardID string) (*model.Board, error)
+	ListBoards(ctx context.Context) ([]model.Board, error)
 }
 
 type LeaderboardRepository struct {
@@ -62,14 +112,15 @@ func NewLeaderBoardRepository(
    Internal helpers
 ============================ */
 
-func (r *LeaderboardRepository) leaderboardVersion(ctx context.Context) int64 {
+func (r *LeaderboardRepository) leaderboardVersion(ctx context.Context, gameID, boardID string) int64 {
 	if r.redis == nil {
 		return 1
 	}
 
-	version, err := r.redis.Get(ctx, leaderboardVersionKey).Int64()
+	key := fmt.Sprintf(leaderboardVersionKey, gameID, boardID)
+	version, err := r.redis.Get(ctx, key).Int64()
 	if err == redis.Nil {
-		r.redis.Set(ctx, leaderboardVersionKey, 1, 0)
+		r.redis.Set(ctx, key, 1, 0)
 		return 1
 	}
 	if err != nil {
@@ -79,84 +130,524 @@ func (r *LeaderboardRepository) leaderboardVersion(ctx context.Context) int64 {
 	return version
 }
 
-func (r *LeaderboardRepository) bumpLeaderboardVersion(ctx context.Context) {
+// LeaderboardVersion returns the board's change counter, which every write
+// bumps. ok is false when versions are not tracked because Redis is disabled.
+func (r *LeaderboardRepository) LeaderboardVersion(ctx context.Context, boardID string) (version int64, ok bool) {
+	if r.redis == nil {
+		return 0, false
+	}
+
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return 0, false
+	}
+	return r.leaderboardVersion(ctx, gameID, boardID), true
+}
+
+// bumpLeaderboardVersion invalidates cached reads of the board and tells
+// every instance that the board changed.
+func (r *LeaderboardRepository) bumpLeaderboardVersion(ctx context.Context, gameID, boardID string) {
 	if r.redis == nil {
 		return
 	}
-	if err := r.redis.Incr(ctx, leaderboardVersionKey).Err(); err != nil {
+	version, err := r.redis.Incr(ctx, fmt.Sprintf(leaderboardVersionKey, gameID, boardID)).Result()
+	if err != nil {
 		r.logger.Warn("Failed to bump leaderboard version", "error", err)
+		return
+	}
+
+	data, err := json.Marshal(model.LeaderboardChangedEvent{GameID: gameID, BoardID: boardID, Version: version})
+	if err != nil {
+		return
+	}
+	if err := r.redis.Publish(ctx, constants.LeaderboardEventsChannel, data).Err(); err != nil {
+		r.logger.Warn("Failed to publish leaderboard change", "error", err)
+	}
+}
+
+func (r *LeaderboardRepository) incrPlayerCount(ctx context.Context, gameID string, scope BoardScope) {
+	if r.redis == nil {
+		return
+	}
+	key := fmt.Sprintf(playerCountKey, gameID, scope.BoardID, scope.PeriodStart.Unix())
+	if err := incrIfExistsScript.Run(ctx, r.redis, []string{key}).Err(); err != nil {
+		r.logger.Warn("Failed to increment player count", "error", err)
+	}
+}
+
+// storedScoreExpr is the score a submit aggregates onto: the undecayed score
+// when decay has lowered total_score, so returning players get it back.
+const storedScoreExpr = "COALESCE(leaderboard.raw_score, leaderboard.total_score)"
+
+// aggregateExpr returns the upsert expression combining the stored score with
+// the submitted one. Only whitelisted aggregations reach the SQL.
+func aggregateExpr(aggregation, sortOrder string) string {
+	if aggregation == constants.AggregationBest {
+		aggregation = constants.AggregationMax
+		if sortOrder == constants.SortAscending {
+			aggregation = constants.AggregationMin
+		}
+	}
+
+	switch aggregation {
+	case constants.AggregationMax:
+		return "GREATEST(" + storedScoreExpr + ", EXCLUDED.total_score)"
+	case constants.AggregationMin:
+		return "LEAST(" + storedScoreExpr + ", EXCLUDED.total_score)"
+	case constants.AggregationLatest:
+		// A write-behind flush may carry an older score than a submit that overtook it
+		return "CASE WHEN EXCLUDED.updated_at >= leaderboard.updated_at THEN EXCLUDED.total_score ELSE " + storedScoreExpr + " END"
+	default:
+		// Counting boards are written SubmitValue, 1 per submit, so they add up too
+		return storedScoreExpr + " + EXCLUDED.total_score"
+	}
+}
+
+// SubmitValue is what a submitted score adds to the board: the score itself,
+// or 1 on a counting board.
+func (s BoardScope) SubmitValue(score int64) int64 {
+	if s.Aggregation == constants.AggregationCount {
+		return 1
 	}
+	return score
+}
+
+// Combine folds value into stored the way the board's upsert does, so that
+// values can be coalesced before they reach the database.
+func (s BoardScope) Combine(stored, value int64) int64 {
+	aggregation := s.Aggregation
+	if aggregation == constants.AggregationBest {
+		aggregation = constants.AggregationMax
+		if s.SortOrder == constants.SortAscending {
+			aggregation = constants.AggregationMin
+		}
+	}
+
+	switch aggregation {
+	case constants.AggregationMax:
+		return max(stored, value)
+	case constants.AggregationMin:
+		return min(stored, value)
+	case constants.AggregationLatest:
+		return value
+	default:
+		return stored + value
+	}
+}
+
+// orderExpr and aheadOp express "better than" for the board's sort order.
+func orderExpr(sortOrder string) string {
+	if sortOrder == constants.SortAscending {
+		return "total_score ASC"
+	}
+	return "total_score DESC"
+}
+
+func aheadOp(sortOrder string) string {
+	if sortOrder == constants.SortAscending {
+		return "<"
+	}
+	return ">"
 }
 
 /* ============================
    Submit Score
 ============================ */
 
+// SubmitScore stores a single submit. It fails with ErrUserNotFound or
+// ErrSubmissionApplied when the submit is refused.
 func (r *LeaderboardRepository) SubmitScore(
 	ctx context.Context,
 	userID int64,
 	score int64,
 	gameMode string,
-) (time.Time, error) {
+	outcome string,
+	trackingID string,
+	scopes []BoardScope,
+) (*ScoreSubmission, error) {
+
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := r.SubmitScores(ctx, []QueuedScore{{
+		GameID:     gameID,
+		UserID:     userID,
+		Score:      score,
+		GameMode:   gameMode,
+		Outcome:    outcome,
+		TrackingID: trackingID,
+		Scopes:     scopes,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return results[0].Submission, results[0].Err
+}
+
+// QueuedScore is one submit of a batch applied by SubmitScores.
+type QueuedScore struct {
+	GameID     string
+	UserID     int64
+	Score      int64
+	GameMode   string
+	Outcome    string
+	TrackingID string
+	Scopes     []BoardScope
+}
+
+// SubmitResult is the outcome of one submit of a batch: its submission, or
+// why it was refused (ErrUserNotFound or ErrSubmissionApplied).
+type SubmitResult struct {
+	Submission *ScoreSubmission
+	Err        error
+}
+
+// SubmitScores applies a batch of submits in one transaction and returns
+// their results in order. A refused submit leaves the rest of the batch to
+// be applied; any other failure rolls the whole batch back. Submits are
+// applied grouped by player, keeping their order within each player, so
+// concurrent batches lock rows in the same order. Like the outbox, a batch
+// may span games, so every submit names its own.
+func (r *LeaderboardRepository) SubmitScores(ctx context.Context, scores []QueuedScore) ([]SubmitResult, error) {
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		first, second := &scores[order[a]], &scores[order[b]]
+		if first.GameID != second.GameID {
+			return first.GameID < second.GameID
+		}
+		return first.UserID < second.UserID
+	})
 
 	var lastErr error
 	now := time.Now().UTC()
 
 	for attempt := 0; attempt < maxRetries; attempt++ {
-		tx := r.db.WithContext(ctx).Begin()
-		if tx.Error != nil {
-			lastErr = tx.Error
+		results := make([]SubmitResult, len(scores))
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, i := range order {
+				submission, err := r.applySubmit(tx, &scores[i], now)
+				if err != nil {
+					switch err.Error() {
+					case constants.ErrUserNotFound, constants.ErrSubmissionApplied:
+						results[i].Err = err
+						continue
+					}
+					return err
+				}
+				results[i].Submission = submission
+			}
+			return nil
+		})
+		if err != nil {
+			lastErr = err
 			time.Sleep(initialRetryDelay * time.Duration(attempt+1))
 			continue
 		}
 
-		// Check user exists (fast EXISTS)
-		var exists bool
-		if err := tx.Raw(
-			`SELECT EXISTS (SELECT 1 FROM gaming.users WHERE id = ?)`,
-			userID,
-		).Scan(&exists).Error; err != nil || !exists {
-			tx.Rollback()
-			return time.Time{}, errors.New(constants.ErrUserNotFound)
+		// Cache invalidation (O(1) per board)
+		bumped := make(map[[2]string]bool)
+		for i := range scores {
+			submission := results[i].Submission
+			if submission == nil {
+				continue
+			}
+			for j, scope := range scores[i].Scopes {
+				if key := [2]string{scores[i].GameID, scope.BoardID}; !bumped[key] {
+					bumped[key] = true
+					r.bumpLeaderboardVersion(ctx, scores[i].GameID, scope.BoardID)
+				}
+				if submission.Boards[j].IsNewPlayer {
+					r.incrPlayerCount(ctx, scores[i].GameID, scope)
+				}
+			}
 		}
+		return results, nil
+	}
 
-		// Insert game session
-		if err := tx.Exec(`
-			INSERT INTO gaming.game_sessions (user_id, score, game_mode, timestamp)
-			VALUES (?, ?, ?, ?)
-		`, userID, score, gameMode, now).Error; err != nil {
-			tx.Rollback()
-			lastErr = err
-			time.Sleep(initialRetryDelay * time.Duration(attempt+1))
-			continue
+	return nil, fmt.Errorf("submit score failed after retries: %w", lastErr)
+}
+
+// applySubmit stores one submit in tx: its session, the player's stats, the
+// board scores and the events describing them, which commit or roll back
+// together with the scores.
+func (r *LeaderboardRepository) applySubmit(tx *gorm.DB, score *QueuedScore, now time.Time) (*ScoreSubmission, error) {
+	// Check user exists (fast EXISTS)
+	var exists bool
+	if err := tx.Raw(
+		`SELECT EXISTS (SELECT 1 FROM gaming.users WHERE game_id = ? AND id = ?)`,
+		score.GameID,
+		score.UserID,
+	).Scan(&exists).Error; err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New(constants.ErrUserNotFound)
+	}
+
+	if err := insertSession(tx, score.GameID, score.UserID, score.Score, score.GameMode, score.Outcome, score.TrackingID, false, now); err != nil {
+		return nil, err
+	}
+
+	// Keep the per-player stats rows in step with the new session
+	stats := newPlayerStatsDelta(score.GameID, score.UserID)
+	stats.add(score.Score, score.GameMode, score.Outcome, now)
+	if err := r.upsertPlayerStats(tx, stats); err != nil {
+		return nil, err
+	}
+
+	boards, err := r.upsertBoardScores(tx, score.GameID, score.UserID, score.Score, score.Scopes, now)
+	if err != nil {
+		return nil, err
+	}
+
+	eventID, err := r.writeSubmitEvents(tx, score.GameID, score.UserID, score.Score, score.GameMode, score.Outcome, score.Scopes, boards, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScoreSubmission{
+		Timestamp: now,
+		Boards:    boards,
+		EventID:   eventID,
+	}, nil
+}
+
+// insertSession records the game session of a submit. A submit whose
+// tracking id already has a session was applied before, and is refused with
+// ErrSubmissionApplied before it changes anything else. A session with
+// statsPending set is left for the buffer flush to fold into the stats.
+func insertSession(
+	tx *gorm.DB,
+	gameID string,
+	userID int64,
+	score int64,
+	gameMode string,
+	outcome string,
+	trackingID string,
+	statsPending bool,
+	now time.Time,
+) error {
+	result := tx.Exec(`
+		INSERT INTO gaming.game_sessions (game_id, user_id, score, game_mode, outcome, tracking_id, stats_pending, timestamp)
+		VALUES (?, ?, ?, ?, NULLIF(?, ''), NULLIF(?, ''), ?, ?)
+		ON CONFLICT (game_id, tracking_id) WHERE tracking_id IS NOT NULL DO NOTHING
+	`, gameID, userID, score, gameMode, outcome, trackingID, statsPending, now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(constants.ErrSubmissionApplied)
+	}
+	return nil
+}
+
+// upsertBoardScores applies the score to every targeted board. The existing
+// row is locked first so the previous score is exact under concurrent submits.
+func (r *LeaderboardRepository) upsertBoardScores(
+	tx *gorm.DB,
+	gameID string,
+	userID int64,
+	score int64,
+	scopes []BoardScope,
+	now time.Time,
+) ([]BoardSubmission, error) {
+	boards := make([]BoardSubmission, 0, len(scopes))
+
+	for _, scope := range scopes {
+		var previous []int64
+		if err := tx.Raw(`
+			SELECT total_score
+			FROM gaming.leaderboard
+			WHERE game_id = ? AND board_id = ? AND period_start = ? AND user_id = ?
+			FOR UPDATE
+		`, gameID, scope.BoardID, scope.PeriodStart, userID).Scan(&previous).Error; err != nil {
+			return nil, err
 		}
 
-		// Atomic upsert leaderboard score
-		if err := tx.Exec(`
-			INSERT INTO gaming.leaderboard (user_id, total_score)
-			VALUES (?, ?)
-			ON CONFLICT (user_id)
-			DO UPDATE SET total_score = leaderboard.total_score + EXCLUDED.total_score
-		`, userID, score).Error; err != nil {
-			tx.Rollback()
-			lastErr = err
-			time.Sleep(initialRetryDelay * time.Duration(attempt+1))
-			continue
+		value := scope.SubmitValue(score)
+
+		// xmax = 0 only for freshly inserted rows
+		var upserted struct {
+			TotalScore int64
+			Inserted   bool
+		}
+		if err := tx.Raw(`
+			INSERT INTO gaming.leaderboard (game_id, board_id, period_start, user_id, total_score, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (game_id, board_id, period_start, user_id)
+			DO UPDATE SET
+				total_score = `+aggregateExpr(scope.Aggregation, scope.SortOrder)+`,
+				raw_score = NULL,
+				decayed_through = NULL,
+				updated_at = EXCLUDED.updated_at
+			RETURNING total_score, (xmax = 0) AS inserted
+		`, gameID, scope.BoardID, scope.PeriodStart, userID, value, now).Scan(&upserted).Error; err != nil {
+			return nil, err
 		}
 
-		if err := tx.Commit().Error; err != nil {
-			lastErr = err
-			time.Sleep(initialRetryDelay * time.Duration(attempt+1))
-			continue
+		submission := BoardSubmission{
+			BoardID:     scope.BoardID,
+			TotalScore:  upserted.TotalScore,
+			IsNewPlayer: upserted.Inserted,
+		}
+		if len(previous) > 0 {
+			submission.PreviousScore = previous[0]
 		}
+		boards = append(boards, submission)
+	}
 
-		// Cache invalidation (O(1))
-		r.bumpLeaderboardVersion(ctx)
+	return boards, nil
+}
 
-		return now, nil
+// playerStatsDelta is what a run of one player's sessions, in the order they
+// were played, adds to their stats. Wins extend the streak, losses and draws
+// reset it, undecided games leave it alone: Reset is set once the run ended
+// the streak it started on, LeadingWins are the wins before that,
+// TrailingWins the wins since the last reset and LongestWins the longest
+// streak within the run.
+type playerStatsDelta struct {
+	GameID       string
+	UserID       int64
+	Games        int
+	TotalScore   int64
+	BestScore    int64
+	Wins         int
+	Decided      int
+	Reset        bool
+	LeadingWins  int
+	TrailingWins int
+	LongestWins  int
+	LastPlayedAt time.Time
+	Modes        map[string]*modeStatsDelta
+}
+
+// modeStatsDelta is what a run of sessions adds to one game mode's stats.
+type modeStatsDelta struct {
+	Games      int
+	TotalScore int64
+	BestScore  int64
+	Wins       int
+	Decided    int
+}
+
+func newPlayerStatsDelta(gameID string, userID int64) *playerStatsDelta {
+	return &playerStatsDelta{
+		GameID: gameID,
+		UserID: userID,
+		Modes:  make(map[string]*modeStatsDelta),
+	}
+}
+
+// add counts a session played after those already in the delta.
+func (d *playerStatsDelta) add(score int64, gameMode, outcome string, playedAt time.Time) {
+	if d.Games == 0 || score > d.BestScore {
+		d.BestScore = score
+	}
+	d.Games++
+	d.TotalScore += score
+	if playedAt.After(d.LastPlayedAt) {
+		d.LastPlayedAt = playedAt
 	}
 
-	return time.Time{}, fmt.Errorf("submit score failed after retries: %w", lastErr)
+	mode, ok := d.Modes[gameMode]
+	if !ok {
+		mode = &modeStatsDelta{}
+		d.Modes[gameMode] = mode
+	}
+	if mode.Games == 0 || score > mode.BestScore {
+		mode.BestScore = score
+	}
+	mode.Games++
+	mode.TotalScore += score
+
+	if outcome == "" {
+		return
+	}
+	d.Decided++
+	mode.Decided++
+	if outcome != constants.OutcomeWin {
+		d.Reset = true
+		d.TrailingWins = 0
+		return
+	}
+
+	d.Wins++
+	mode.Wins++
+	if !d.Reset {
+		d.LeadingWins++
+	}
+	d.TrailingWins++
+	if d.TrailingWins > d.LongestWins {
+		d.LongestWins = d.TrailingWins
+	}
+}
+
+// upsertPlayerStats folds a delta into the incrementally maintained stats
+// rows, so reading a player's stats never scans their session history.
+func (r *LeaderboardRepository) upsertPlayerStats(tx *gorm.DB, delta *playerStatsDelta) error {
+	// The stored streak carries on into the run's leading wins unless the
+	// run reset it, in which case only the trailing wins count
+	if err := tx.Exec(`
+		INSERT INTO gaming.player_stats AS ps (
+			game_id, user_id, games_played, total_score, best_score, wins, decided_games,
+			current_streak, longest_streak, last_played_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			games_played   = ps.games_played + EXCLUDED.games_played,
+			total_score    = ps.total_score + EXCLUDED.total_score,
+			best_score     = GREATEST(ps.best_score, EXCLUDED.best_score),
+			wins           = ps.wins + EXCLUDED.wins,
+			decided_games  = ps.decided_games + EXCLUDED.decided_games,
+			current_streak = CASE
+				WHEN ? THEN EXCLUDED.current_streak
+				ELSE ps.current_streak + EXCLUDED.current_streak
+			END,
+			longest_streak = GREATEST(
+				ps.longest_streak,
+				ps.current_streak + ?,
+				EXCLUDED.longest_streak
+			),
+			last_played_at = GREATEST(ps.last_played_at, EXCLUDED.last_played_at)
+	`,
+		delta.GameID, delta.UserID, delta.Games, delta.TotalScore, delta.BestScore, delta.Wins, delta.Decided,
+		delta.TrailingWins, delta.LongestWins, delta.LastPlayedAt,
+		delta.Reset, delta.LeadingWins,
+	).Error; err != nil {
+		return fmt.Errorf("failed to update player stats: %w", err)
+	}
+
+	// Modes in a fixed order, so concurrent upserts cannot deadlock
+	modes := make([]string, 0, len(delta.Modes))
+	for gameMode := range delta.Modes {
+		modes = append(modes, gameMode)
+	}
+	sort.Strings(modes)
+
+	for _, gameMode := range modes {
+		mode := delta.Modes[gameMode]
+		if err := tx.Exec(`
+			INSERT INTO gaming.player_mode_stats AS pms (
+				game_id, user_id, game_mode, games_played, total_score, best_score, wins, decided_games
+			)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (user_id, game_mode) DO UPDATE SET
+				games_played  = pms.games_played + EXCLUDED.games_played,
+				total_score   = pms.total_score + EXCLUDED.total_score,
+				best_score    = GREATEST(pms.best_score, EXCLUDED.best_score),
+				wins          = pms.wins + EXCLUDED.wins,
+				decided_games = pms.decided_games + EXCLUDED.decided_games
+		`, delta.GameID, delta.UserID, gameMode, mode.Games, mode.TotalScore, mode.BestScore, mode.Wins, mode.Decided).Error; err != nil {
+			return fmt.Errorf("failed to update player mode stats: %w", err)
+		}
+	}
+
+	return nil
 }
 
 /* ============================
@@ -165,34 +656,55 @@ func (r *LeaderboardRepository) SubmitScore(
 
 func (r *LeaderboardRepository) GetTopPlayers(
 	ctx context.Context,
+	scope BoardScope,
 	limit int,
 ) ([]LeaderboardEntry, error) {
+	entries, _, err := r.GetVersionedTopPlayers(ctx, scope, limit)
+	return entries, err
+}
+
+// GetVersionedTopPlayers is GetTopPlayers that also returns the board version
+// the entries are at, or 0 when versions are not tracked. The version is read
+// first, so the entries are never older than it.
+func (r *LeaderboardRepository) GetVersionedTopPlayers(
+	ctx context.Context,
+	scope BoardScope,
+	limit int,
+) ([]LeaderboardEntry, int64, error) {
 
 	if limit <= 0 {
-		return nil, errors.New("limit must be positive")
+		return nil, 0, errors.New("limit must be positive")
 	}
 
-	version := r.leaderboardVersion(ctx)
-	cacheKey := fmt.Sprintf(topPlayersCacheKey, version, limit)
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	if r.redis != nil {
+	version := r.leaderboardVersion(ctx, gameID, scope.BoardID)
+	cacheKey := fmt.Sprintf(topPlayersCacheKey, gameID, scope.BoardID, scope.PeriodStart.Unix(), version, limit)
+
+	if r.redis == nil {
+		version = 0
+	} else {
 		if cached, err := r.redis.Get(ctx, cacheKey).Result(); err == nil {
 			var entries []LeaderboardEntry
 			if json.Unmarshal([]byte(cached), &entries) == nil {
-				return entries, nil
+				return entries, version, nil
 			}
 		}
 	}
 
 	var entries []LeaderboardEntry
-	err := r.db.WithContext(ctx).
+	err = r.db.WithContext(ctx).
 		Table("gaming.leaderboard").
 		Select("user_id, total_score").
-		Order("total_score DESC").
+		Where("game_id = ? AND board_id = ? AND period_start = ?", gameID, scope.BoardID, scope.PeriodStart).
+		Order(orderExpr(scope.SortOrder)).
 		Limit(limit).
 		Find(&entries).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch top players: %w", err)
+		return nil, 0, fmt.Errorf("failed to fetch top players: %w", err)
 	}
 
 	if r.redis != nil {
@@ -201,6 +713,64 @@ func (r *LeaderboardRepository) GetTopPlayers(
 		}
 	}
 
+	return entries, version, nil
+}
+
+// GetCachedTopPlayers returns the top players as they were cached at an
+// earlier version of the board. ok is false once that version has expired
+// from the cache or was never read.
+func (r *LeaderboardRepository) GetCachedTopPlayers(
+	ctx context.Context,
+	scope BoardScope,
+	version int64,
+	limit int,
+) (entries []LeaderboardEntry, ok bool) {
+	if r.redis == nil {
+		return nil, false
+	}
+
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, false
+	}
+
+	cacheKey := fmt.Sprintf(topPlayersCacheKey, gameID, scope.BoardID, scope.PeriodStart.Unix(), version, limit)
+	cached, err := r.redis.Get(ctx, cacheKey).Result()
+	if err != nil {
+		return nil, false
+	}
+	if json.Unmarshal([]byte(cached), &entries) != nil {
+		return nil, false
+	}
+	return entries, true
+}
+
+// GetPlayersPage returns limit players starting at offset in the board's
+// order. Ties are broken by user id so consecutive pages never overlap.
+func (r *LeaderboardRepository) GetPlayersPage(
+	ctx context.Context,
+	scope BoardScope,
+	offset int,
+	limit int,
+) ([]LeaderboardEntry, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []LeaderboardEntry
+	err = r.db.WithContext(ctx).
+		Table("gaming.leaderboard").
+		Select("user_id, total_score").
+		Where("game_id = ? AND board_id = ? AND period_start = ?", gameID, scope.BoardID, scope.PeriodStart).
+		Order(orderExpr(scope.SortOrder) + ", user_id").
+		Offset(offset).
+		Limit(limit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch players page: %w", err)
+	}
+
 	return entries, nil
 }
 
@@ -210,11 +780,17 @@ func (r *LeaderboardRepository) GetTopPlayers(
 
 func (r *LeaderboardRepository) GetPlayerRank(
 	ctx context.Context,
+	scope BoardScope,
 	userID int64,
 ) (*PlayerRank, error) {
 
-	version := r.leaderboardVersion(ctx)
-	cacheKey := fmt.Sprintf(playerRankCacheKey, version, userID)
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	version := r.leaderboardVersion(ctx, gameID, scope.BoardID)
+	cacheKey := fmt.Sprintf(playerRankCacheKey, gameID, scope.BoardID, scope.PeriodStart.Unix(), version, userID)
 
 	if r.redis != nil {
 		if cached, err := r.redis.Get(ctx, cacheKey).Result(); err == nil {
@@ -225,26 +801,29 @@ func (r *LeaderboardRepository) GetPlayerRank(
 		}
 	}
 
-	var rank PlayerRank
-	err := r.db.WithContext(ctx).Raw(`
+	var ranks []PlayerRank
+	err = r.db.WithContext(ctx).Raw(`
 		SELECT
 			user_id,
 			total_score,
 			1 + (
 				SELECT COUNT(*)
 				FROM gaming.leaderboard
-				WHERE total_score > lb.total_score
+				WHERE game_id = lb.game_id
+					AND board_id = lb.board_id
+					AND period_start = lb.period_start
+					AND total_score `+aheadOp(scope.SortOrder)+` lb.total_score
 			) AS rank
 		FROM gaming.leaderboard lb
-		WHERE user_id = ?
-	`, userID).Scan(&rank).Error
-
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, errors.New(constants.ErrUserNotFound)
-	}
+		WHERE game_id = ? AND board_id = ? AND period_start = ? AND user_id = ?
+	`, gameID, scope.BoardID, scope.PeriodStart, userID).Scan(&ranks).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get player rank: %w", err)
 	}
+	if len(ranks) == 0 {
+		return nil, errors.New(constants.ErrUserNotFound)
+	}
+	rank := ranks[0]
 
 	if r.redis != nil {
 		if data, err := json.Marshal(rank); err == nil {
@@ -254,3 +833,252 @@ func (r *LeaderboardRepository) GetPlayerRank(
 
 	return &rank, nil
 }
+
+/* ============================
+   Get Friends Leaderboard
+============================ */
+
+// GetFriendsLeaderboard ranks a user among their accepted friends. Both sides
+// are primary-key lookups, so the cost grows with the friend list rather than
+// with the size of the leaderboard. Friendship changes do not bump the
+// leaderboard version, hence the short cache TTL.
+func (r *LeaderboardRepository) GetFriendsLeaderboard(
+	ctx context.Context,
+	scope BoardScope,
+	userID int64,
+) ([]PlayerRank, error) {
+
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	version := r.leaderboardVersion(ctx, gameID, scope.BoardID)
+	cacheKey := fmt.Sprintf(friendsCacheKey, gameID, scope.BoardID, scope.PeriodStart.Unix(), version, userID)
+
+	if r.redis != nil {
+		if cached, err := r.redis.Get(ctx, cacheKey).Result(); err == nil {
+			var ranks []PlayerRank
+			if json.Unmarshal([]byte(cached), &ranks) == nil {
+				return ranks, nil
+			}
+		}
+	}
+
+	var ranks []PlayerRank
+	err = r.db.WithContext(ctx).Raw(`
+		SELECT
+			lb.user_id,
+			lb.total_score,
+			RANK() OVER (ORDER BY lb.`+orderExpr(scope.SortOrder)+`) AS rank
+		FROM gaming.leaderboard lb
+		WHERE lb.game_id = ?
+			AND lb.board_id = ?
+			AND lb.period_start = ?
+			AND (
+				lb.user_id = ?
+				OR lb.user_id IN (
+					SELECT friend_id
+					FROM gaming.friendships
+					WHERE game_id = lb.game_id AND user_id = ? AND status = 'accepted'
+				)
+			)
+		ORDER BY rank, lb.user_id
+	`, gameID, scope.BoardID, scope.PeriodStart, userID, userID).Scan(&ranks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get friends leaderboard: %w", err)
+	}
+
+	if r.redis != nil {
+		if data, err := json.Marshal(ranks); err == nil {
+			r.redis.Set(ctx, cacheKey, data, friendsCacheTTL)
+		}
+	}
+
+	return ranks, nil
+}
+
+/* ============================
+   Player Counts
+============================ */
+
+// TotalPlayers returns the number of ranked players on a board period. The
+// count is kept in Redis and maintained on insert, so the table is only
+// counted on a cache miss.
+func (r *LeaderboardRepository) TotalPlayers(ctx context.Context, scope BoardScope) (int, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	key := fmt.Sprintf(playerCountKey, gameID, scope.BoardID, scope.PeriodStart.Unix())
+
+	if r.redis != nil {
+		if count, err := r.redis.Get(ctx, key).Int64(); err == nil {
+			return int(count), nil
+		}
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Table("gaming.leaderboard").
+		Where("game_id = ? AND board_id = ? AND period_start = ?", gameID, scope.BoardID, scope.PeriodStart).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count players: %w", err)
+	}
+
+	if r.redis != nil {
+		r.redis.SetNX(ctx, key, count, playerCountTTL)
+	}
+
+	return int(count), nil
+}
+
+// CountPlayersAhead returns how many players have a strictly better score.
+func (r *LeaderboardRepository) CountPlayersAhead(ctx context.Context, scope BoardScope, score int64) (int, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Table("gaming.leaderboard").
+		Where("game_id = ? AND board_id = ? AND period_start = ?", gameID, scope.BoardID, scope.PeriodStart).
+		Where("total_score "+aheadOp(scope.SortOrder)+" ?", score).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count players ahead: %w", err)
+	}
+	return int(count), nil
+}
+
+// RankOf returns the rank score has on the board against everyone but the
+// player, whose own row may still trail what the write-behind buffer holds.
+func (r *LeaderboardRepository) RankOf(ctx context.Context, scope BoardScope, userID int64, score int64) (int, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	ahead, err := countAhead(r.db.WithContext(ctx), gameID, scope, score, userID)
+	if err != nil {
+		return 0, err
+	}
+	return ahead + 1, nil
+}
+
+// ListOvertaken returns the players a submit moved past: those that were not
+// behind previousScore and are now behind score, nearest to score first, with
+// their rank after the submit. previousScore is nil for a player new to the
+// period, in which case everyone behind score counts.
+func (r *LeaderboardRepository) ListOvertaken(
+	ctx context.Context,
+	scope BoardScope,
+	userID int64,
+	previousScore *int64,
+	score int64,
+	limit int,
+) ([]PlayerRank, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.db.WithContext(ctx).
+		Table("gaming.leaderboard").
+		Select("game_id, board_id, period_start, user_id, total_score").
+		Where("game_id = ? AND board_id = ? AND period_start = ? AND user_id <> ?", gameID, scope.BoardID, scope.PeriodStart, userID).
+		Where("? "+aheadOp(scope.SortOrder)+" total_score", score)
+	if previousScore != nil {
+		// Players tied with the previous score lose their shared place too
+		query = query.Where("total_score "+aheadOp(scope.SortOrder)+"= ?", *previousScore)
+	}
+	query = query.Order(orderExpr(scope.SortOrder)).Limit(limit)
+
+	var ranks []PlayerRank
+	err = r.db.WithContext(ctx).Raw(`
+		SELECT
+			user_id,
+			total_score,
+			1 + (
+				SELECT COUNT(*)
+				FROM gaming.leaderboard
+				WHERE game_id = lb.game_id
+					AND board_id = lb.board_id
+					AND period_start = lb.period_start
+					AND total_score `+aheadOp(scope.SortOrder)+` lb.total_score
+			) AS rank
+		FROM (?) lb
+		ORDER BY `+orderExpr(scope.SortOrder)+`
+	`, query).Scan(&ranks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overtaken players: %w", err)
+	}
+
+	return ranks, nil
+}
+
+/* ============================
+   Events
+============================ */
+
+// PublishesEvents reports whether events reach other instances. Without
+// Redis every instance only sees its own events.
+func (r *LeaderboardRepository) PublishesEvents() bool {
+	return r.redis != nil
+}
+
+// SubscribeEvents subscribes to the given event channels until ctx is
+// cancelled, when the returned channel is closed. It returns nil when Redis
+// is disabled.
+func (r *LeaderboardRepository) SubscribeEvents(ctx context.Context, channels ...string) <-chan *redis.Message {
+	if r.redis == nil {
+		return nil
+	}
+
+	pubsub := r.redis.Subscribe(ctx, channels...)
+	go func() {
+		<-ctx.Done()
+		pubsub.Close()
+	}()
+	return pubsub.Channel()
+}
+
+func (r *LeaderboardRepository) PublishRankNotification(ctx context.Context, notification *model.RankNotification) error {
+	if r.redis == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rank notification: %w", err)
+	}
+
+	if err := r.redis.Publish(ctx, constants.RankNotificationsChannel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish rank notification: %w", err)
+	}
+	return nil
+}
+
+func (r *LeaderboardRepository) PublishTierChange(ctx context.Context, event *model.TierChangeEvent) error {
+	if r.redis == nil {
+		return nil
+	}
+
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+	// Subscribers share one channel across games
+	event.GameID = gameID
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tier change event: %w", err)
+	}
+
+	if err := r.redis.Publish(ctx, constants.TierEventsChannel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish tier change event: %w", err)
+	}
+	return nil
+}
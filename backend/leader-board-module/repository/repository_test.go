@@ -1 +1,234 @@
 package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+)
+
+// applyStreak mirrors how upsertPlayerStats folds a delta into a stored
+// streak.
+func applyStreak(current, longest int, delta *playerStatsDelta) (int, int) {
+	nextLongest := max(longest, current+delta.LeadingWins, delta.LongestWins)
+	if delta.Reset {
+		return delta.TrailingWins, nextLongest
+	}
+	return current + delta.TrailingWins, nextLongest
+}
+
+func TestPlayerStatsDeltaStreak(t *testing.T) {
+	const (
+		win  = constants.OutcomeWin
+		loss = constants.OutcomeLoss
+		draw = constants.OutcomeDraw
+	)
+
+	tests := []struct {
+		name        string
+		current     int
+		longest     int
+		outcomes    []string
+		wantCurrent int
+		wantLongest int
+		wantWins    int
+		wantDecided int
+	}{
+		{name: "wins extend the streak", current: 2, longest: 4, outcomes: []string{win, win, win}, wantCurrent: 5, wantLongest: 5, wantWins: 3, wantDecided: 3},
+		{name: "loss resets the streak", current: 3, longest: 3, outcomes: []string{win, loss}, wantCurrent: 0, wantLongest: 4, wantWins: 1, wantDecided: 2},
+		{name: "draw resets the streak", current: 1, longest: 1, outcomes: []string{draw, win}, wantCurrent: 1, wantLongest: 1, wantWins: 1, wantDecided: 2},
+		{name: "undecided games leave the streak alone", current: 2, longest: 2, outcomes: []string{"", win, ""}, wantCurrent: 3, wantLongest: 3, wantWins: 1, wantDecided: 1},
+		{name: "longest streak within the run", current: 1, longest: 2, outcomes: []string{loss, win, win, win, loss, win}, wantCurrent: 1, wantLongest: 3, wantWins: 4, wantDecided: 6},
+		{name: "leading wins carry the stored streak", current: 3, longest: 3, outcomes: []string{win, win, loss, win}, wantCurrent: 1, wantLongest: 5, wantWins: 3, wantDecided: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta := newPlayerStatsDelta("game", 1)
+			for _, outcome := range tt.outcomes {
+				delta.add(10, "solo", outcome, time.Time{})
+			}
+			if delta.Wins != tt.wantWins || delta.Decided != tt.wantDecided {
+				t.Errorf("wins, decided = %d, %d, want %d, %d", delta.Wins, delta.Decided, tt.wantWins, tt.wantDecided)
+			}
+
+			current, longest := applyStreak(tt.current, tt.longest, delta)
+			if current != tt.wantCurrent || longest != tt.wantLongest {
+				t.Errorf("streak = %d, %d, want %d, %d", current, longest, tt.wantCurrent, tt.wantLongest)
+			}
+
+			// Folding the sessions one by one ends in the same streak
+			current, longest = tt.current, tt.longest
+			for _, outcome := range tt.outcomes {
+				single := newPlayerStatsDelta("game", 1)
+				single.add(10, "solo", outcome, time.Time{})
+				current, longest = applyStreak(current, longest, single)
+			}
+			if current != tt.wantCurrent || longest != tt.wantLongest {
+				t.Errorf("streak one by one = %d, %d, want %d, %d", current, longest, tt.wantCurrent, tt.wantLongest)
+			}
+		})
+	}
+}
+
+func TestCoalescePlayerStats(t *testing.T) {
+	base := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	rows := []pendingSessionRow{
+		{ID: 4, GameID: "game", UserID: 2, Score: 50, GameMode: "solo", Outcome: constants.OutcomeWin, Timestamp: base.Add(3 * time.Second)},
+		{ID: 1, GameID: "game", UserID: 2, Score: 30, GameMode: "solo", Outcome: constants.OutcomeLoss, Timestamp: base},
+		{ID: 2, GameID: "game", UserID: 1, Score: 70, GameMode: "duo", Timestamp: base.Add(time.Second)},
+		{ID: 3, GameID: "game", UserID: 2, Score: 20, GameMode: "duo", Outcome: constants.OutcomeWin, Timestamp: base.Add(2 * time.Second)},
+		{ID: 5, GameID: "other", UserID: 1, Score: 5, GameMode: "solo", Timestamp: base.Add(4 * time.Second)},
+	}
+
+	got := coalescePlayerStats(rows)
+
+	want := []*playerStatsDelta{
+		{
+			GameID: "game", UserID: 1, Games: 1, TotalScore: 70, BestScore: 70,
+			LastPlayedAt: base.Add(time.Second),
+			Modes: map[string]*modeStatsDelta{
+				"duo": {Games: 1, TotalScore: 70, BestScore: 70},
+			},
+		},
+		{
+			GameID: "game", UserID: 2, Games: 3, TotalScore: 100, BestScore: 50,
+			Wins: 2, Decided: 3, Reset: true, TrailingWins: 2, LongestWins: 2,
+			LastPlayedAt: base.Add(3 * time.Second),
+			Modes: map[string]*modeStatsDelta{
+				"solo": {Games: 2, TotalScore: 80, BestScore: 50, Wins: 1, Decided: 2},
+				"duo":  {Games: 1, TotalScore: 20, BestScore: 20, Wins: 1, Decided: 1},
+			},
+		},
+		{
+			GameID: "other", UserID: 1, Games: 1, TotalScore: 5, BestScore: 5,
+			LastPlayedAt: base.Add(4 * time.Second),
+			Modes: map[string]*modeStatsDelta{
+				"solo": {Games: 1, TotalScore: 5, BestScore: 5},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("coalescePlayerStats() differs from want, got:")
+		for _, delta := range got {
+			t.Errorf("  %+v", *delta)
+		}
+	}
+}
+
+func TestCoalesceBufferedScores(t *testing.T) {
+	period := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return period.Add(time.Duration(seconds) * time.Second)
+	}
+	row := func(boardID string, userID, value int64, seconds int, aggregation, sortOrder string) bufferedScoreRow {
+		return bufferedScoreRow{
+			GameID:      "game",
+			BoardID:     boardID,
+			PeriodStart: period,
+			UserID:      userID,
+			Value:       value,
+			CreatedAt:   at(seconds),
+			Aggregation: aggregation,
+			SortOrder:   sortOrder,
+		}
+	}
+
+	rows := []bufferedScoreRow{
+		row("total", 2, 10, 1, constants.AggregationSum, constants.SortDescending),
+		row("speedrun", 1, 90, 2, constants.AggregationBest, constants.SortAscending),
+		row("total", 1, 5, 3, constants.AggregationSum, constants.SortDescending),
+		row("total", 2, 15, 4, constants.AggregationSum, constants.SortDescending),
+		row("speedrun", 1, 70, 5, constants.AggregationBest, constants.SortAscending),
+		row("speedrun", 1, 80, 6, constants.AggregationBest, constants.SortAscending),
+		row("latest", 1, 3, 7, constants.AggregationLatest, constants.SortDescending),
+		row("latest", 1, 1, 8, constants.AggregationLatest, constants.SortDescending),
+	}
+
+	got := coalesceBufferedScores(rows)
+
+	type coalesced struct {
+		boardID   string
+		userID    int64
+		value     int64
+		updatedAt time.Time
+	}
+	want := []coalesced{
+		{boardID: "latest", userID: 1, value: 1, updatedAt: at(8)},
+		{boardID: "speedrun", userID: 1, value: 70, updatedAt: at(6)},
+		{boardID: "total", userID: 1, value: 5, updatedAt: at(3)},
+		{boardID: "total", userID: 2, value: 25, updatedAt: at(4)},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("coalesceBufferedScores() returned %d boards, want %d", len(got), len(want))
+	}
+	for i, board := range got {
+		g := coalesced{boardID: board.Scope.BoardID, userID: board.UserID, value: board.Value, updatedAt: board.UpdatedAt}
+		if g != want[i] {
+			t.Errorf("board %d = %+v, want %+v", i, g, want[i])
+		}
+	}
+}
+
+func TestDecayedScore(t *testing.T) {
+	const day = 24 * time.Hour
+	halfLife := &model.DecayPolicy{Type: constants.DecayHalfLife, HalfLifeDays: 7}
+	weekly := &model.DecayPolicy{Type: constants.DecayWeekly, WeeklyDeduction: 100}
+
+	tests := []struct {
+		name    string
+		policy  *model.DecayPolicy
+		raw     int64
+		elapsed time.Duration
+		want    int64
+	}{
+		{name: "half-life not started", policy: halfLife, raw: 1000, elapsed: 0, want: 1000},
+		{name: "half-life before decay starts", policy: halfLife, raw: 1000, elapsed: -day, want: 1000},
+		{name: "one half-life", policy: halfLife, raw: 1000, elapsed: 7 * day, want: 500},
+		{name: "two half-lives", policy: halfLife, raw: 1000, elapsed: 14 * day, want: 250},
+		{name: "half a half-life rounds down", policy: halfLife, raw: 1000, elapsed: 3*day + 12*time.Hour, want: 707},
+		{name: "half-life of a small score", policy: halfLife, raw: 1, elapsed: 7 * day, want: 0},
+		{name: "weekly within the first week", policy: weekly, raw: 1000, elapsed: 6 * day, want: 1000},
+		{name: "weekly one full week", policy: weekly, raw: 1000, elapsed: 7 * day, want: 900},
+		{name: "weekly counts whole weeks", policy: weekly, raw: 1000, elapsed: 20 * day, want: 800},
+		{name: "weekly stops at zero", policy: weekly, raw: 250, elapsed: 21 * day, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decayedScore(tt.policy, tt.raw, tt.elapsed); got != tt.want {
+				t.Errorf("decayedScore(%d, %v) = %d, want %d", tt.raw, tt.elapsed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecayRows(t *testing.T) {
+	inactiveSince := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	policy := &model.DecayPolicy{Type: constants.DecayWeekly, WeeklyDeduction: 100}
+
+	rows := []decayRow{
+		// Decays from the raw score, not the already decayed one
+		{ID: 1, UpdatedAt: inactiveSince.AddDate(0, 0, -14), TotalScore: 900, RawScore: 1000},
+		// Already decayed for the whole inactive time
+		{ID: 2, UpdatedAt: inactiveSince.AddDate(0, 0, -7), TotalScore: 900, RawScore: 1000},
+		// Not a full week inactive yet
+		{ID: 3, UpdatedAt: inactiveSince.AddDate(0, 0, -3), TotalScore: 500, RawScore: 500},
+		{ID: 4, UpdatedAt: inactiveSince.AddDate(0, 0, -7), TotalScore: 50, RawScore: 50},
+	}
+
+	got := decayRows(rows, policy, inactiveSince)
+
+	want := map[int64]int64{1: 800, 4: 0}
+	if len(got) != len(want) {
+		t.Fatalf("decayRows() returned %d rows, want %d", len(got), len(want))
+	}
+	for _, row := range got {
+		if decayed, ok := want[row.ID]; !ok || row.Decayed != decayed {
+			t.Errorf("row %d decayed to %d, want %d", row.ID, row.Decayed, want[row.ID])
+		}
+	}
+}
@@ -0,0 +1,352 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"gorm.io/gorm"
+)
+
+// BufferedBoardScore is the coalesced effect of several submits on one
+// player's board row: Value is what they add up to under the board's
+// aggregation.
+type BufferedBoardScore struct {
+	GameID    string
+	Scope     BoardScope
+	UserID    int64
+	Value     int64
+	UpdatedAt time.Time
+}
+
+type pendingSessionRow struct {
+	ID        int64     `gorm:"column:id"`
+	GameID    string    `gorm:"column:game_id"`
+	UserID    int64     `gorm:"column:user_id"`
+	Score     int64     `gorm:"column:score"`
+	GameMode  string    `gorm:"column:game_mode"`
+	Outcome   string    `gorm:"column:outcome"`
+	Timestamp time.Time `gorm:"column:timestamp"`
+}
+
+type bufferedScoreRow struct {
+	GameID      string    `gorm:"column:game_id"`
+	BoardID     string    `gorm:"column:board_id"`
+	PeriodStart time.Time `gorm:"column:period_start"`
+	UserID      int64     `gorm:"column:user_id"`
+	Value       int64     `gorm:"column:value"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	Aggregation string    `gorm:"column:aggregation"`
+	SortOrder   string    `gorm:"column:sort_order"`
+}
+
+// RecordSession stores a submit whose board scores and stats are left to the
+// write-behind buffer: the session and events are written as usual, but the
+// session is marked stats_pending and the board scores are only queued in
+// buffered_scores, so the submit takes none of the player's row locks. Both
+// commit with the session, so nothing is lost when the process dies before a
+// flush. Until then the player's stats, and the achievements read from them,
+// lag behind. The board results are estimated from the stored scores and
+// what is still queued for them.
+func (r *LeaderboardRepository) RecordSession(
+	ctx context.Context,
+	userID int64,
+	score int64,
+	gameMode string,
+	outcome string,
+	trackingID string,
+	scopes []BoardScope,
+) (*ScoreSubmission, error) {
+
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	var boards []BoardSubmission
+	var eventID int64
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var exists bool
+		if err := tx.Raw(
+			`SELECT EXISTS (SELECT 1 FROM gaming.users WHERE game_id = ? AND id = ?)`,
+			gameID,
+			userID,
+		).Scan(&exists).Error; err != nil {
+			return err
+		}
+		if !exists {
+			return errors.New(constants.ErrUserNotFound)
+		}
+
+		// The session's stats are folded in by the flush, as the stats row
+		// is locked by every submit just like the board rows
+		if err := insertSession(tx, gameID, userID, score, gameMode, outcome, trackingID, true, now); err != nil {
+			return err
+		}
+
+		boards = make([]BoardSubmission, 0, len(scopes))
+		for _, scope := range scopes {
+			// The flush aggregates onto the undecayed score, as a submit does
+			var stored []struct {
+				TotalScore int64
+				RawScore   int64
+			}
+			if err := tx.Raw(`
+				SELECT total_score, COALESCE(raw_score, total_score) AS raw_score
+				FROM gaming.leaderboard
+				WHERE game_id = ? AND board_id = ? AND period_start = ? AND user_id = ?
+			`, gameID, scope.BoardID, scope.PeriodStart, userID).Scan(&stored).Error; err != nil {
+				return err
+			}
+
+			var pending []int64
+			if err := tx.Raw(`
+				SELECT value
+				FROM gaming.buffered_scores
+				WHERE game_id = ? AND board_id = ? AND period_start = ? AND user_id = ?
+				ORDER BY id
+			`, gameID, scope.BoardID, scope.PeriodStart, userID).Scan(&pending).Error; err != nil {
+				return err
+			}
+
+			board := BoardSubmission{BoardID: scope.BoardID}
+			previous, raw, known := int64(0), int64(0), len(stored) > 0
+			if known {
+				previous, raw = stored[0].TotalScore, stored[0].RawScore
+			}
+			for _, queued := range pending {
+				if known {
+					previous = scope.Combine(previous, queued)
+					raw = scope.Combine(raw, queued)
+				} else {
+					previous, raw, known = queued, queued, true
+				}
+			}
+
+			value := scope.SubmitValue(score)
+			if known {
+				board.PreviousScore = previous
+				board.TotalScore = scope.Combine(raw, value)
+			} else {
+				board.TotalScore = value
+				board.IsNewPlayer = true
+			}
+			boards = append(boards, board)
+
+			if err := tx.Exec(`
+				INSERT INTO gaming.buffered_scores (game_id, board_id, period_start, user_id, value, created_at)
+				VALUES (?, ?, ?, ?, ?, ?)
+			`, gameID, scope.BoardID, scope.PeriodStart, userID, value, now).Error; err != nil {
+				return fmt.Errorf("failed to buffer score: %w", err)
+			}
+		}
+
+		eventID, err = r.writeSubmitEvents(tx, gameID, userID, score, gameMode, outcome, scopes, boards, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScoreSubmission{
+		Timestamp: now,
+		Boards:    boards,
+		EventID:   eventID,
+	}, nil
+}
+
+// FlushBufferedScores applies up to limit of the oldest queued board scores
+// and up to limit of the oldest sessions whose stats are pending, and
+// returns the larger of the two counts. They are taken off the queue and
+// applied in one transaction, so each is applied exactly once whichever
+// instance flushes it. The queue spans games, so unlike most writes this is
+// not scoped to the context's game. Stats rows are locked before board rows,
+// as a submit does, and each in a fixed order, so concurrent flushes and
+// submits cannot deadlock each other.
+func (r *LeaderboardRepository) FlushBufferedScores(ctx context.Context, limit int) (int, error) {
+	var claimed int
+	var boards []*BufferedBoardScore
+	var inserted []bool
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var sessions []pendingSessionRow
+		if err := tx.Raw(`
+			UPDATE gaming.game_sessions
+			SET stats_pending = FALSE
+			WHERE id IN (
+				SELECT id
+				FROM gaming.game_sessions
+				WHERE stats_pending
+				ORDER BY id
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, game_id, user_id, score, game_mode, COALESCE(outcome, '') AS outcome, timestamp
+		`, limit).Scan(&sessions).Error; err != nil {
+			return fmt.Errorf("failed to claim pending session stats: %w", err)
+		}
+
+		for _, stats := range coalescePlayerStats(sessions) {
+			if err := r.upsertPlayerStats(tx, stats); err != nil {
+				return err
+			}
+		}
+
+		var rows []bufferedScoreRow
+		if err := tx.Raw(`
+			WITH claimed AS (
+				DELETE FROM gaming.buffered_scores
+				WHERE id IN (
+					SELECT id
+					FROM gaming.buffered_scores
+					ORDER BY id
+					LIMIT ?
+					FOR UPDATE SKIP LOCKED
+				)
+				RETURNING id, game_id, board_id, period_start, user_id, value, created_at
+			)
+			SELECT c.game_id, c.board_id, c.period_start, c.user_id, c.value, c.created_at, b.aggregation, b.sort_order
+			FROM claimed c
+			JOIN gaming.leaderboards b ON b.game_id = c.game_id AND b.id = c.board_id
+			ORDER BY c.id
+		`, limit).Scan(&rows).Error; err != nil {
+			return fmt.Errorf("failed to claim buffered scores: %w", err)
+		}
+		claimed = max(len(sessions), len(rows))
+
+		boards = coalesceBufferedScores(rows)
+		inserted = make([]bool, len(boards))
+		for i, board := range boards {
+			var upserted struct {
+				Inserted bool
+			}
+			if err := tx.Raw(`
+				INSERT INTO gaming.leaderboard (game_id, board_id, period_start, user_id, total_score, updated_at)
+				VALUES (?, ?, ?, ?, ?, ?)
+				ON CONFLICT (game_id, board_id, period_start, user_id)
+				DO UPDATE SET
+					total_score = `+aggregateExpr(board.Scope.Aggregation, board.Scope.SortOrder)+`,
+					raw_score = NULL,
+					decayed_through = NULL,
+					updated_at = GREATEST(leaderboard.updated_at, EXCLUDED.updated_at)
+				RETURNING (xmax = 0) AS inserted
+			`, board.GameID, board.Scope.BoardID, board.Scope.PeriodStart, board.UserID, board.Value, board.UpdatedAt).Scan(&upserted).Error; err != nil {
+				return fmt.Errorf("failed to apply buffered score: %w", err)
+			}
+			inserted[i] = upserted.Inserted
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Cache invalidation, once per changed board
+	bumped := make(map[[2]string]bool)
+	for i, board := range boards {
+		key := [2]string{board.GameID, board.Scope.BoardID}
+		if !bumped[key] {
+			bumped[key] = true
+			r.bumpLeaderboardVersion(ctx, board.GameID, board.Scope.BoardID)
+		}
+		if inserted[i] {
+			r.incrPlayerCount(ctx, board.GameID, board.Scope)
+		}
+	}
+	return claimed, nil
+}
+
+// coalesceBufferedScores folds queued scores, oldest first, into one score
+// per player board row, sorted in the order rows are locked in.
+func coalesceBufferedScores(rows []bufferedScoreRow) []*BufferedBoardScore {
+	type rowKey struct {
+		gameID      string
+		boardID     string
+		periodStart int64
+		userID      int64
+	}
+
+	coalesced := make(map[rowKey]*BufferedBoardScore)
+	boards := make([]*BufferedBoardScore, 0, len(rows))
+	for _, row := range rows {
+		key := rowKey{row.GameID, row.BoardID, row.PeriodStart.UnixNano(), row.UserID}
+		if board, ok := coalesced[key]; ok {
+			board.Value = board.Scope.Combine(board.Value, row.Value)
+			board.UpdatedAt = row.CreatedAt
+			continue
+		}
+
+		board := &BufferedBoardScore{
+			GameID: row.GameID,
+			Scope: BoardScope{
+				BoardID:     row.BoardID,
+				PeriodStart: row.PeriodStart,
+				Aggregation: row.Aggregation,
+				SortOrder:   row.SortOrder,
+			},
+			UserID:    row.UserID,
+			Value:     row.Value,
+			UpdatedAt: row.CreatedAt,
+		}
+		coalesced[key] = board
+		boards = append(boards, board)
+	}
+
+	sort.Slice(boards, func(i, j int) bool {
+		a, b := boards[i], boards[j]
+		if a.GameID != b.GameID {
+			return a.GameID < b.GameID
+		}
+		if a.Scope.BoardID != b.Scope.BoardID {
+			return a.Scope.BoardID < b.Scope.BoardID
+		}
+		if !a.Scope.PeriodStart.Equal(b.Scope.PeriodStart) {
+			return a.Scope.PeriodStart.Before(b.Scope.PeriodStart)
+		}
+		return a.UserID < b.UserID
+	})
+	return boards
+}
+
+// coalescePlayerStats folds sessions, in the order they were played, into
+// one stats delta per player, sorted in the order stats rows are locked in.
+func coalescePlayerStats(rows []pendingSessionRow) []*playerStatsDelta {
+	sorted := make([]pendingSessionRow, len(rows))
+	copy(sorted, rows)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	type playerKey struct {
+		gameID string
+		userID int64
+	}
+
+	coalesced := make(map[playerKey]*playerStatsDelta)
+	deltas := make([]*playerStatsDelta, 0, len(sorted))
+	for _, row := range sorted {
+		key := playerKey{row.GameID, row.UserID}
+		delta, ok := coalesced[key]
+		if !ok {
+			delta = newPlayerStatsDelta(row.GameID, row.UserID)
+			coalesced[key] = delta
+			deltas = append(deltas, delta)
+		}
+		delta.add(row.Score, row.GameMode, row.Outcome, row.Timestamp)
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		a, b := deltas[i], deltas[j]
+		if a.GameID != b.GameID {
+			return a.GameID < b.GameID
+		}
+		return a.UserID < b.UserID
+	})
+	return deltas
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"gorm.io/gorm/clause"
+)
+
+// snapshotEncodingV1 is a gzip stream of (uvarint user id, varint score delta)
+// pairs in rank order. Neighbouring scores are close, so deltas stay small.
+const snapshotEncodingV1 byte = 1
+
+type snapshotRow struct {
+	ID          int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	GameID      string    `gorm:"column:game_id"`
+	BoardID     string    `gorm:"column:board_id"`
+	PeriodStart time.Time `gorm:"column:period_start"`
+	CapturedAt  time.Time `gorm:"column:captured_at"`
+	Trigger     string    `gorm:"column:trigger"`
+	Players     int       `gorm:"column:players"`
+	Data        []byte    `gorm:"column:data"`
+}
+
+func (snapshotRow) TableName() string {
+	return "gaming.leaderboard_snapshots"
+}
+
+// StoredSnapshot is a snapshot with its decoded standings, in rank order.
+type StoredSnapshot struct {
+	model.Snapshot
+	Entries []LeaderboardEntry
+}
+
+// CreateSnapshot copies the standings of a board period into a compressed
+// snapshot. Re-running a scheduled snapshot for the same capturedAt is a
+// no-op and returns nil.
+func (r *LeaderboardRepository) CreateSnapshot(ctx context.Context, scope BoardScope, capturedAt time.Time, trigger string) (*model.Snapshot, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.WithContext(ctx).
+		Table("gaming.leaderboard").
+		Select("user_id, total_score").
+		Where("game_id = ? AND board_id = ? AND period_start = ?", gameID, scope.BoardID, scope.PeriodStart).
+		Order(orderExpr(scope.SortOrder) + ", user_id").
+		Rows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read standings: %w", err)
+	}
+	defer rows.Close()
+
+	encoder := newSnapshotEncoder()
+	for rows.Next() {
+		var entry LeaderboardEntry
+		if err := rows.Scan(&entry.UserID, &entry.TotalScore); err != nil {
+			return nil, fmt.Errorf("failed to read standings: %w", err)
+		}
+		if err := encoder.add(entry); err != nil {
+			return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read standings: %w", err)
+	}
+
+	data, err := encoder.finish()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	row := snapshotRow{
+		GameID:      gameID,
+		BoardID:     scope.BoardID,
+		PeriodStart: scope.PeriodStart,
+		CapturedAt:  capturedAt,
+		Trigger:     trigger,
+		Players:     encoder.count,
+		Data:        data,
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&row)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to store snapshot: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return row.toModel(), nil
+}
+
+// ListSnapshots returns the snapshots of a board captured between from and
+// to, newest first. The standings are not loaded.
+func (r *LeaderboardRepository) ListSnapshots(ctx context.Context, boardID string, from, to time.Time, limit int) ([]model.Snapshot, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []model.Snapshot
+	err = r.db.WithContext(ctx).
+		Table("gaming.leaderboard_snapshots").
+		Select("id, board_id, period_start, captured_at, trigger, players, octet_length(data) AS size_bytes").
+		Where("game_id = ? AND board_id = ? AND captured_at BETWEEN ? AND ?", gameID, boardID, from, to).
+		Order("captured_at DESC, id DESC").
+		Limit(limit).
+		Scan(&snapshots).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// GetSnapshotAsOf loads the latest snapshot of a board captured at or before
+// asOf, failing with ErrSnapshotNotFound when there is none.
+func (r *LeaderboardRepository) GetSnapshotAsOf(ctx context.Context, boardID string, asOf time.Time) (*StoredSnapshot, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []snapshotRow
+	if err := r.db.WithContext(ctx).
+		Where("game_id = ? AND board_id = ? AND captured_at <= ?", gameID, boardID, asOf).
+		Order("captured_at DESC, id DESC").
+		Limit(1).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New(constants.ErrSnapshotNotFound)
+	}
+
+	entries, err := decodeSnapshot(rows[0].Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot %d: %w", rows[0].ID, err)
+	}
+
+	return &StoredSnapshot{
+		Snapshot: *rows[0].toModel(),
+		Entries:  entries,
+	}, nil
+}
+
+// PruneSnapshots drops scheduled snapshots captured before the cutoff.
+// Manual snapshots are kept until their board is deleted.
+func (r *LeaderboardRepository) PruneSnapshots(ctx context.Context, before time.Time) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Exec(`
+		DELETE FROM gaming.leaderboard_snapshots
+		WHERE game_id = ? AND trigger = ? AND captured_at < ?
+	`, gameID, constants.SnapshotScheduled, before).Error; err != nil {
+		return fmt.Errorf("failed to prune snapshots: %w", err)
+	}
+	return nil
+}
+
+func (row snapshotRow) toModel() *model.Snapshot {
+	return &model.Snapshot{
+		ID:          row.ID,
+		BoardID:     row.BoardID,
+		PeriodStart: row.PeriodStart,
+		CapturedAt:  row.CapturedAt,
+		Trigger:     row.Trigger,
+		Players:     row.Players,
+		SizeBytes:   len(row.Data),
+	}
+}
+
+type snapshotEncoder struct {
+	buf       bytes.Buffer
+	gz        *gzip.Writer
+	scratch   [2 * binary.MaxVarintLen64]byte
+	lastScore int64
+	count     int
+}
+
+func newSnapshotEncoder() *snapshotEncoder {
+	e := &snapshotEncoder{}
+	e.buf.WriteByte(snapshotEncodingV1)
+	e.gz = gzip.NewWriter(&e.buf)
+	return e
+}
+
+func (e *snapshotEncoder) add(entry LeaderboardEntry) error {
+	n := binary.PutUvarint(e.scratch[:], uint64(entry.UserID))
+	n += binary.PutVarint(e.scratch[n:], entry.TotalScore-e.lastScore)
+	if _, err := e.gz.Write(e.scratch[:n]); err != nil {
+		return err
+	}
+	e.lastScore = entry.TotalScore
+	e.count++
+	return nil
+}
+
+func (e *snapshotEncoder) finish() ([]byte, error) {
+	if err := e.gz.Close(); err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+func decodeSnapshot(data []byte) ([]LeaderboardEntry, error) {
+	if len(data) == 0 || data[0] != snapshotEncodingV1 {
+		return nil, errors.New("unknown snapshot encoding")
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data[1:]))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	reader := bufio.NewReader(gz)
+	var entries []LeaderboardEntry
+	var score int64
+	for {
+		userID, err := binary.ReadUvarint(reader)
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		delta, err := binary.ReadVarint(reader)
+		if err != nil {
+			return nil, err
+		}
+		score += delta
+		entries = append(entries, LeaderboardEntry{UserID: int64(userID), TotalScore: score})
+	}
+}
@@ -0,0 +1,140 @@
+package grpc
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/core"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/server/grpc/leaderboardpb"
+)
+
+func submitRequest(req *leaderboardpb.SubmitScoreRequest) *model.SubmitScoreRequest {
+	return &model.SubmitScoreRequest{
+		UserID:   req.GetUserId(),
+		Score:    req.GetScore(),
+		GameMode: req.GetGameMode(),
+		Outcome:  req.GetOutcome(),
+		BoardIDs: req.GetBoardIds(),
+	}
+}
+
+// viewMode returns the stream mode of a view, or "" for an unknown one. An
+// unspecified mode is left to ValidateView to default.
+func viewMode(mode leaderboardpb.ViewMode) string {
+	switch mode {
+	case leaderboardpb.ViewMode_VIEW_MODE_TOP:
+		return constants.StreamModeTop
+	case leaderboardpb.ViewMode_VIEW_MODE_PAGE:
+		return constants.StreamModePage
+	case leaderboardpb.ViewMode_VIEW_MODE_AROUND:
+		return constants.StreamModeAround
+	}
+	return ""
+}
+
+func scoreData(data *model.ScoreData) *leaderboardpb.ScoreData {
+	if data == nil {
+		return nil
+	}
+
+	boards := make([]*leaderboardpb.BoardScore, 0, len(data.Boards))
+	for _, board := range data.Boards {
+		boardScore := &leaderboardpb.BoardScore{
+			BoardId:    board.BoardID,
+			TotalScore: board.TotalScore,
+			Rank:       int32(board.Rank),
+			Tier:       tier(board.Tier),
+		}
+		if change := board.TierChange; change != nil {
+			boardScore.TierChange = &leaderboardpb.TierChange{
+				Direction: change.Direction,
+				From:      tier(change.From),
+				To:        tier(change.To),
+			}
+		}
+		boards = append(boards, boardScore)
+	}
+
+	return &leaderboardpb.ScoreData{
+		UserId:    data.UserID,
+		Score:     data.Score,
+		Timestamp: timestamppb.New(data.Timestamp),
+		Boards:    boards,
+	}
+}
+
+func tier(info *model.TierInfo) *leaderboardpb.Tier {
+	if info == nil {
+		return nil
+	}
+	return &leaderboardpb.Tier{
+		Name:     info.Name,
+		Division: info.Division,
+		Label:    info.Label,
+	}
+}
+
+func playerScores(players []model.PlayerScore) []*leaderboardpb.PlayerScore {
+	converted := make([]*leaderboardpb.PlayerScore, 0, len(players))
+	for _, player := range players {
+		converted = append(converted, &leaderboardpb.PlayerScore{
+			UserId: player.UserID,
+			Rank:   int32(player.Rank),
+			Score:  player.Score,
+			Tier:   tier(player.Tier),
+		})
+	}
+	return converted
+}
+
+func leaderboardDiff(diff *model.LeaderboardDiff) *leaderboardpb.LeaderboardDiff {
+	if diff == nil {
+		return nil
+	}
+
+	moved := make([]*leaderboardpb.PlayerMove, 0, len(diff.Moved))
+	for _, move := range diff.Moved {
+		moved = append(moved, &leaderboardpb.PlayerMove{
+			UserId:        move.UserID,
+			Rank:          int32(move.Rank),
+			PreviousRank:  int32(move.PreviousRank),
+			Score:         move.Score,
+			PreviousScore: move.PreviousScore,
+			Tier:          tier(move.Tier),
+		})
+	}
+
+	return &leaderboardpb.LeaderboardDiff{
+		Entered: playerScores(diff.Entered),
+		Left:    diff.Left,
+		Moved:   moved,
+	}
+}
+
+// leaderboardUpdate carries the whole view, or only the diff when the client
+// asked for diffs and the update has one.
+func leaderboardUpdate(update *core.StreamUpdate, diffMode bool) *leaderboardpb.LeaderboardUpdate {
+	if diffMode && update.Diff != nil {
+		return &leaderboardpb.LeaderboardUpdate{
+			BoardId:     update.BoardID,
+			Version:     update.Version,
+			BaseVersion: update.BaseVersion,
+			Diff:        leaderboardDiff(update.Diff),
+		}
+	}
+	return &leaderboardpb.LeaderboardUpdate{
+		BoardId: update.BoardID,
+		Version: update.Version,
+		Players: playerScores(update.Players),
+	}
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,4 @@
+package grpc
+
+// Regenerating the stubs needs protoc with protoc-gen-go and protoc-gen-go-grpc on the PATH.
+//go:generate protoc -I proto --go_out=../../.. --go_opt=module=github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend --go-grpc_out=../../.. --go-grpc_opt=module=github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend leaderboard/v1/leaderboard.proto
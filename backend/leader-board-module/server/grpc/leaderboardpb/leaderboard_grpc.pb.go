@@ -0,0 +1,362 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v5.27.2
+// source: leaderboard/v1/leaderboard.proto
+
+package leaderboardpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	LeaderboardService_SubmitScore_FullMethodName      = "/leaderboard.v1.LeaderboardService/SubmitScore"
+	LeaderboardService_SubmitScores_FullMethodName     = "/leaderboard.v1.LeaderboardService/SubmitScores"
+	LeaderboardService_GetTopPlayers_FullMethodName    = "/leaderboard.v1.LeaderboardService/GetTopPlayers"
+	LeaderboardService_GetPlayerRank_FullMethodName    = "/leaderboard.v1.LeaderboardService/GetPlayerRank"
+	LeaderboardService_WatchLeaderboard_FullMethodName = "/leaderboard.v1.LeaderboardService/WatchLeaderboard"
+)
+
+// LeaderboardServiceClient is the client API for LeaderboardService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LeaderboardService is the gRPC API for game servers. It mirrors the
+// /api/leaderboard HTTP endpoints.
+//
+// Every call is scoped to a game by the x-api-key or x-game-id metadata,
+// like the X-Api-Key and X-Game-Id headers of the HTTP API. A call that is
+// refused fails with a google.rpc.ErrorInfo detail whose reason is the error
+// code the HTTP API would return, e.g. BOARD_NOT_FOUND.
+type LeaderboardServiceClient interface {
+	// SubmitScore records a single score. When the server queues submits for
+	// asynchronous ingestion the response carries a tracking id instead of
+	// the resulting standings.
+	SubmitScore(ctx context.Context, in *SubmitScoreRequest, opts ...grpc.CallOption) (*SubmitScoreResponse, error)
+	// SubmitScores records a batch of scores sent over one stream, in order.
+	// Submits are applied as they arrive and a refused submit does not end
+	// the stream; the response reports the outcome of each.
+	SubmitScores(ctx context.Context, opts ...grpc.CallOption) (LeaderboardService_SubmitScoresClient, error)
+	GetTopPlayers(ctx context.Context, in *GetTopPlayersRequest, opts ...grpc.CallOption) (*GetTopPlayersResponse, error)
+	GetPlayerRank(ctx context.Context, in *GetPlayerRankRequest, opts ...grpc.CallOption) (*GetPlayerRankResponse, error)
+	// WatchLeaderboard streams a view of a board. The first update carries
+	// the whole view unless the call resumed from since_version; later ones
+	// are sent whenever the view changes. A client that falls too far behind
+	// is dropped with RESOURCE_EXHAUSTED and can resume from the last version
+	// it received.
+	WatchLeaderboard(ctx context.Context, in *WatchLeaderboardRequest, opts ...grpc.CallOption) (LeaderboardService_WatchLeaderboardClient, error)
+}
+
+type leaderboardServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeaderboardServiceClient(cc grpc.ClientConnInterface) LeaderboardServiceClient {
+	return &leaderboardServiceClient{cc}
+}
+
+func (c *leaderboardServiceClient) SubmitScore(ctx context.Context, in *SubmitScoreRequest, opts ...grpc.CallOption) (*SubmitScoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitScoreResponse)
+	err := c.cc.Invoke(ctx, LeaderboardService_SubmitScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) SubmitScores(ctx context.Context, opts ...grpc.CallOption) (LeaderboardService_SubmitScoresClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LeaderboardService_ServiceDesc.Streams[0], LeaderboardService_SubmitScores_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &leaderboardServiceSubmitScoresClient{ClientStream: stream}
+	return x, nil
+}
+
+type LeaderboardService_SubmitScoresClient interface {
+	Send(*SubmitScoreRequest) error
+	CloseAndRecv() (*SubmitScoresResponse, error)
+	grpc.ClientStream
+}
+
+type leaderboardServiceSubmitScoresClient struct {
+	grpc.ClientStream
+}
+
+func (x *leaderboardServiceSubmitScoresClient) Send(m *SubmitScoreRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *leaderboardServiceSubmitScoresClient) CloseAndRecv() (*SubmitScoresResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(SubmitScoresResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *leaderboardServiceClient) GetTopPlayers(ctx context.Context, in *GetTopPlayersRequest, opts ...grpc.CallOption) (*GetTopPlayersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTopPlayersResponse)
+	err := c.cc.Invoke(ctx, LeaderboardService_GetTopPlayers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) GetPlayerRank(ctx context.Context, in *GetPlayerRankRequest, opts ...grpc.CallOption) (*GetPlayerRankResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPlayerRankResponse)
+	err := c.cc.Invoke(ctx, LeaderboardService_GetPlayerRank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) WatchLeaderboard(ctx context.Context, in *WatchLeaderboardRequest, opts ...grpc.CallOption) (LeaderboardService_WatchLeaderboardClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LeaderboardService_ServiceDesc.Streams[1], LeaderboardService_WatchLeaderboard_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &leaderboardServiceWatchLeaderboardClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LeaderboardService_WatchLeaderboardClient interface {
+	Recv() (*LeaderboardUpdate, error)
+	grpc.ClientStream
+}
+
+type leaderboardServiceWatchLeaderboardClient struct {
+	grpc.ClientStream
+}
+
+func (x *leaderboardServiceWatchLeaderboardClient) Recv() (*LeaderboardUpdate, error) {
+	m := new(LeaderboardUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LeaderboardServiceServer is the server API for LeaderboardService service.
+// All implementations must embed UnimplementedLeaderboardServiceServer
+// for forward compatibility
+//
+// LeaderboardService is the gRPC API for game servers. It mirrors the
+// /api/leaderboard HTTP endpoints.
+//
+// Every call is scoped to a game by the x-api-key or x-game-id metadata,
+// like the X-Api-Key and X-Game-Id headers of the HTTP API. A call that is
+// refused fails with a google.rpc.ErrorInfo detail whose reason is the error
+// code the HTTP API would return, e.g. BOARD_NOT_FOUND.
+type LeaderboardServiceServer interface {
+	// SubmitScore records a single score. When the server queues submits for
+	// asynchronous ingestion the response carries a tracking id instead of
+	// the resulting standings.
+	SubmitScore(context.Context, *SubmitScoreRequest) (*SubmitScoreResponse, error)
+	// SubmitScores records a batch of scores sent over one stream, in order.
+	// Submits are applied as they arrive and a refused submit does not end
+	// the stream; the response reports the outcome of each.
+	SubmitScores(LeaderboardService_SubmitScoresServer) error
+	GetTopPlayers(context.Context, *GetTopPlayersRequest) (*GetTopPlayersResponse, error)
+	GetPlayerRank(context.Context, *GetPlayerRankRequest) (*GetPlayerRankResponse, error)
+	// WatchLeaderboard streams a view of a board. The first update carries
+	// the whole view unless the call resumed from since_version; later ones
+	// are sent whenever the view changes. A client that falls too far behind
+	// is dropped with RESOURCE_EXHAUSTED and can resume from the last version
+	// it received.
+	WatchLeaderboard(*WatchLeaderboardRequest, LeaderboardService_WatchLeaderboardServer) error
+	mustEmbedUnimplementedLeaderboardServiceServer()
+}
+
+// UnimplementedLeaderboardServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedLeaderboardServiceServer struct {
+}
+
+func (UnimplementedLeaderboardServiceServer) SubmitScore(context.Context, *SubmitScoreRequest) (*SubmitScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitScore not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) SubmitScores(LeaderboardService_SubmitScoresServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubmitScores not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) GetTopPlayers(context.Context, *GetTopPlayersRequest) (*GetTopPlayersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopPlayers not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) GetPlayerRank(context.Context, *GetPlayerRankRequest) (*GetPlayerRankResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlayerRank not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) WatchLeaderboard(*WatchLeaderboardRequest, LeaderboardService_WatchLeaderboardServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLeaderboard not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) mustEmbedUnimplementedLeaderboardServiceServer() {}
+
+// UnsafeLeaderboardServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeaderboardServiceServer will
+// result in compilation errors.
+type UnsafeLeaderboardServiceServer interface {
+	mustEmbedUnimplementedLeaderboardServiceServer()
+}
+
+func RegisterLeaderboardServiceServer(s grpc.ServiceRegistrar, srv LeaderboardServiceServer) {
+	s.RegisterService(&LeaderboardService_ServiceDesc, srv)
+}
+
+func _LeaderboardService_SubmitScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).SubmitScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_SubmitScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).SubmitScore(ctx, req.(*SubmitScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_SubmitScores_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LeaderboardServiceServer).SubmitScores(&leaderboardServiceSubmitScoresServer{ServerStream: stream})
+}
+
+type LeaderboardService_SubmitScoresServer interface {
+	SendAndClose(*SubmitScoresResponse) error
+	Recv() (*SubmitScoreRequest, error)
+	grpc.ServerStream
+}
+
+type leaderboardServiceSubmitScoresServer struct {
+	grpc.ServerStream
+}
+
+func (x *leaderboardServiceSubmitScoresServer) SendAndClose(m *SubmitScoresResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *leaderboardServiceSubmitScoresServer) Recv() (*SubmitScoreRequest, error) {
+	m := new(SubmitScoreRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _LeaderboardService_GetTopPlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopPlayersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).GetTopPlayers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_GetTopPlayers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).GetTopPlayers(ctx, req.(*GetTopPlayersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_GetPlayerRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlayerRankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).GetPlayerRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_GetPlayerRank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).GetPlayerRank(ctx, req.(*GetPlayerRankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_WatchLeaderboard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLeaderboardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LeaderboardServiceServer).WatchLeaderboard(m, &leaderboardServiceWatchLeaderboardServer{ServerStream: stream})
+}
+
+type LeaderboardService_WatchLeaderboardServer interface {
+	Send(*LeaderboardUpdate) error
+	grpc.ServerStream
+}
+
+type leaderboardServiceWatchLeaderboardServer struct {
+	grpc.ServerStream
+}
+
+func (x *leaderboardServiceWatchLeaderboardServer) Send(m *LeaderboardUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// LeaderboardService_ServiceDesc is the grpc.ServiceDesc for LeaderboardService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LeaderboardService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaderboard.v1.LeaderboardService",
+	HandlerType: (*LeaderboardServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitScore",
+			Handler:    _LeaderboardService_SubmitScore_Handler,
+		},
+		{
+			MethodName: "GetTopPlayers",
+			Handler:    _LeaderboardService_GetTopPlayers_Handler,
+		},
+		{
+			MethodName: "GetPlayerRank",
+			Handler:    _LeaderboardService_GetPlayerRank_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubmitScores",
+			Handler:       _LeaderboardService_SubmitScores_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchLeaderboard",
+			Handler:       _LeaderboardService_WatchLeaderboard_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "leaderboard/v1/leaderboard.proto",
+}
@@ -0,0 +1,308 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/core"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/server/grpc/leaderboardpb"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+)
+
+// errorDomain is the domain of the ErrorInfo details calls are refused with.
+const errorDomain = "leaderboard"
+
+// LeaderboardServer serves the leaderboard to game servers over gRPC. Like
+// the HTTP handler it only translates between the wire and the core.
+type LeaderboardServer struct {
+	leaderboardpb.UnimplementedLeaderboardServiceServer
+
+	core   *core.LeaderboardCore
+	logger *providers.ConsoleLogger
+}
+
+func NewLeaderboardServer(core *core.LeaderboardCore, logger *providers.ConsoleLogger) *LeaderboardServer {
+	return &LeaderboardServer{
+		core:   core,
+		logger: logger,
+	}
+}
+
+// Register serves the leaderboard service on server.
+func (s *LeaderboardServer) Register(server *grpc.Server) {
+	leaderboardpb.RegisterLeaderboardServiceServer(server, s)
+}
+
+func (s *LeaderboardServer) SubmitScore(ctx context.Context, req *leaderboardpb.SubmitScoreRequest) (*leaderboardpb.SubmitScoreResponse, error) {
+	if req.GetUserId() <= 0 {
+		return nil, statusError(codes.InvalidArgument, "Invalid user_id", constants.ErrUserNotFound)
+	}
+
+	resp, err := s.submit(ctx, submitRequest(req))
+	if err != nil {
+		s.logger.Error(
+			"SubmitScore failed",
+			zap.Int64("user_id", req.GetUserId()),
+			zap.Error(err),
+		)
+		return nil, internalError()
+	}
+
+	if !resp.Success {
+		return nil, statusError(rejectionCode(resp.Code), resp.Error, resp.Code)
+	}
+
+	return &leaderboardpb.SubmitScoreResponse{
+		Message:    resp.Message,
+		Data:       scoreData(resp.Data),
+		TrackingId: resp.TrackingID,
+		Status:     resp.Status,
+	}, nil
+}
+
+// SubmitScores applies the submits of a stream one by one. A stream that
+// carries more than MaxSubmitBatchSize submits is answered as soon as the
+// first one too many arrives; that submit is refused with ErrBatchTooLarge
+// and whatever the client sends after it is not read.
+func (s *LeaderboardServer) SubmitScores(stream leaderboardpb.LeaderboardService_SubmitScoresServer) error {
+	ctx := stream.Context()
+	batch := &leaderboardpb.SubmitScoresResponse{}
+
+	for index := int32(0); ; index++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(batch)
+		}
+		if err != nil {
+			return err
+		}
+
+		result := &leaderboardpb.SubmitScoreResult{Index: index}
+		switch {
+		case index >= constants.MaxSubmitBatchSize:
+			result.Error = fmt.Sprintf("A batch may carry at most %d submits", constants.MaxSubmitBatchSize)
+			result.Code = constants.ErrBatchTooLarge
+		case req.GetUserId() <= 0:
+			result.Error = "Invalid user_id"
+			result.Code = constants.ErrUserNotFound
+		default:
+			s.applyBatchSubmit(ctx, req, result)
+		}
+
+		batch.Results = append(batch.Results, result)
+		if result.Success {
+			batch.Accepted++
+		} else {
+			batch.Rejected++
+		}
+
+		if result.Code == constants.ErrBatchTooLarge {
+			return stream.SendAndClose(batch)
+		}
+	}
+}
+
+// applyBatchSubmit submits one score of a batch and records the outcome in
+// result. Internal errors only fail that submit, so the client can retry it.
+func (s *LeaderboardServer) applyBatchSubmit(ctx context.Context, req *leaderboardpb.SubmitScoreRequest, result *leaderboardpb.SubmitScoreResult) {
+	resp, err := s.submit(ctx, submitRequest(req))
+	if err != nil {
+		s.logger.Error(
+			"SubmitScores failed",
+			zap.Int32("index", result.Index),
+			zap.Int64("user_id", req.GetUserId()),
+			zap.Error(err),
+		)
+		result.Error = "Internal server error"
+		result.Code = constants.ErrInternalServer
+		return
+	}
+
+	if !resp.Success {
+		result.Error = resp.Error
+		result.Code = resp.Code
+		return
+	}
+
+	result.Success = true
+	result.Data = scoreData(resp.Data)
+	result.TrackingId = resp.TrackingID
+	result.Status = resp.Status
+}
+
+// submit applies a score, or queues it when submits are ingested asynchronously.
+func (s *LeaderboardServer) submit(ctx context.Context, req *model.SubmitScoreRequest) (*model.SubmitScoreResponse, error) {
+	if s.core.AsyncIngest() {
+		return s.core.EnqueueScore(ctx, req)
+	}
+	return s.core.SubmitScore(ctx, req)
+}
+
+func (s *LeaderboardServer) GetTopPlayers(ctx context.Context, req *leaderboardpb.GetTopPlayersRequest) (*leaderboardpb.GetTopPlayersResponse, error) {
+	// Default to top 10 players if limit is not specified or invalid
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = 10
+	}
+
+	// as_of reads the standings from the latest snapshot at or before it
+	var resp *model.GetTopPlayersResponse
+	var err error
+	if req.GetAsOf() != nil {
+		if err := req.GetAsOf().CheckValid(); err != nil {
+			return nil, statusError(codes.InvalidArgument, "Invalid as_of timestamp", constants.ErrInvalidRequest)
+		}
+		resp, err = s.core.GetTopPlayersAsOf(ctx, req.GetBoardId(), limit, req.GetAsOf().AsTime())
+	} else {
+		if req.GetSinceVersion() < 0 {
+			return nil, statusError(codes.InvalidArgument, "Invalid since_version", constants.ErrInvalidRequest)
+		}
+		resp, err = s.core.GetTopPlayersSince(ctx, req.GetBoardId(), limit, req.GetSinceVersion())
+	}
+	if err != nil {
+		s.logger.Error(
+			"GetTopPlayers failed",
+			zap.String("board_id", req.GetBoardId()),
+			zap.Error(err),
+		)
+		return nil, internalError()
+	}
+
+	if !resp.Success {
+		return nil, statusError(rejectionCode(resp.Code), resp.Error, resp.Code)
+	}
+
+	return &leaderboardpb.GetTopPlayersResponse{
+		BoardId:     resp.BoardID,
+		ScoreUnit:   resp.ScoreUnit,
+		PeriodStart: timestamp(resp.PeriodStart),
+		SnapshotAt:  timestamp(resp.SnapshotAt),
+		Version:     resp.Version,
+		BaseVersion: resp.BaseVersion,
+		Diff:        leaderboardDiff(resp.Diff),
+		Players:     playerScores(resp.Players),
+	}, nil
+}
+
+func (s *LeaderboardServer) GetPlayerRank(ctx context.Context, req *leaderboardpb.GetPlayerRankRequest) (*leaderboardpb.GetPlayerRankResponse, error) {
+	if req.GetUserId() <= 0 {
+		return nil, statusError(codes.InvalidArgument, "Invalid user ID", constants.ErrInvalidRequest)
+	}
+
+	var resp *model.PlayerRankResponse
+	var err error
+	if req.GetAsOf() != nil {
+		if err := req.GetAsOf().CheckValid(); err != nil {
+			return nil, statusError(codes.InvalidArgument, "Invalid as_of timestamp", constants.ErrInvalidRequest)
+		}
+		resp, err = s.core.GetPlayerRankAsOf(ctx, req.GetBoardId(), req.GetUserId(), req.GetAsOf().AsTime())
+	} else {
+		resp, err = s.core.GetPlayerRank(ctx, req.GetBoardId(), req.GetUserId())
+	}
+	if err != nil {
+		s.logger.Error(
+			"GetPlayerRank failed",
+			zap.Int64("user_id", req.GetUserId()),
+			zap.Error(err),
+		)
+		return nil, internalError()
+	}
+
+	if !resp.Success {
+		return nil, statusError(rejectionCode(resp.Code), resp.Error, resp.Code)
+	}
+
+	data := resp.Data
+	return &leaderboardpb.GetPlayerRankResponse{
+		BoardId:      data.BoardID,
+		ScoreUnit:    data.ScoreUnit,
+		UserId:       data.UserID,
+		Rank:         int32(data.Rank),
+		Score:        data.Score,
+		TotalPlayers: int32(data.TotalPlayers),
+		Percentile:   data.Percentile,
+		Tier:         tier(data.Tier),
+		SnapshotAt:   timestamp(data.SnapshotAt),
+	}, nil
+}
+
+func (s *LeaderboardServer) WatchLeaderboard(req *leaderboardpb.WatchLeaderboardRequest, stream leaderboardpb.LeaderboardService_WatchLeaderboardServer) error {
+	ctx := stream.Context()
+
+	view := model.LeaderboardView{
+		Mode:   viewMode(req.GetMode()),
+		Limit:  int(req.GetLimit()),
+		Page:   int(req.GetPage()),
+		UserID: req.GetUserId(),
+		Window: int(req.GetWindow()),
+	}
+	if view.Mode == "" && req.GetMode() != leaderboardpb.ViewMode_VIEW_MODE_UNSPECIFIED {
+		return statusError(codes.InvalidArgument, "Unknown view mode", constants.ErrInvalidView)
+	}
+	if problem := core.ValidateView(&view); problem != "" {
+		return statusError(codes.InvalidArgument, problem, constants.ErrInvalidView)
+	}
+
+	// Updates come from the shared broadcaster, as for SSE and WebSocket clients
+	sub, err := s.core.SubscribeLeaderboard(ctx, req.GetBoardId(), view, req.GetSinceVersion())
+	if err != nil {
+		if err.Error() == constants.ErrBoardNotFound {
+			return statusError(codes.NotFound, "Leaderboard not found", constants.ErrBoardNotFound)
+		}
+		s.logger.Error("Failed to subscribe to leaderboard updates", zap.Error(err))
+		return internalError()
+	}
+	defer s.core.UnsubscribeLeaderboard(sub)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-sub.C:
+			// A closed channel means this client fell behind and was evicted
+			if !ok {
+				return statusError(codes.ResourceExhausted, "Client fell behind and was dropped", constants.ErrSubscriptionEvicted)
+			}
+			if err := stream.Send(leaderboardUpdate(update, req.GetDiff())); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// statusError refuses a call, attaching the code the HTTP API would answer
+// with as the ErrorInfo reason.
+func statusError(c codes.Code, message string, code string) error {
+	st := status.New(c, message)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: code, Domain: errorDomain}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func internalError() error {
+	return statusError(codes.Internal, "Internal server error", constants.ErrInternalServer)
+}
+
+// rejectionCode maps a core error code to a status code the way the HTTP
+// handler maps it to a status: missing entities are NOT_FOUND, anything
+// else the caller got wrong is INVALID_ARGUMENT.
+func rejectionCode(code string) codes.Code {
+	switch code {
+	case constants.ErrUserNotFound,
+		constants.ErrBoardNotFound,
+		constants.ErrSnapshotNotFound,
+		constants.ErrSubmissionNotFound:
+		return codes.NotFound
+	}
+	return codes.InvalidArgument
+}
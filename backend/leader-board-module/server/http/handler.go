@@ -1,7 +1,6 @@
 package http
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -61,7 +60,14 @@ func (h *LeaderboardHandler) SubmitScore(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	resp, err := h.core.SubmitScore(ctx, &req)
+	// In async mode the score is only validated and queued; the client
+	// follows it up with the tracking id
+	submit, accepted := h.core.SubmitScore, http.StatusOK
+	if h.core.AsyncIngest() {
+		submit, accepted = h.core.EnqueueScore, http.StatusAccepted
+	}
+
+	resp, err := submit(ctx, &req)
 	if err != nil {
 		h.logger.Error(
 			"SubmitScore failed",
@@ -80,7 +86,7 @@ func (h *LeaderboardHandler) SubmitScore(w http.ResponseWriter, r *http.Request)
 
 	if !resp.Success {
 		status := http.StatusBadRequest
-		if resp.Code == constants.ErrUserNotFound {
+		if resp.Code == constants.ErrUserNotFound || resp.Code == constants.ErrBoardNotFound {
 			status = http.StatusNotFound
 		}
 
@@ -88,6 +94,34 @@ func (h *LeaderboardHandler) SubmitScore(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	h.respondWithJSON(w, accepted, resp)
+}
+
+func (h *LeaderboardHandler) GetSubmissionStatus(w http.ResponseWriter, r *http.Request) {
+	trackingID := mux.Vars(r)["tracking_id"]
+
+	resp, err := h.core.GetSubmissionStatus(r.Context(), trackingID)
+	if err != nil {
+		h.logger.Error(
+			"GetSubmissionStatus failed",
+			zap.String("tracking_id", trackingID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch submission status",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
 	h.respondWithJSON(w, http.StatusOK, resp)
 }
 
@@ -125,7 +159,28 @@ func (h *LeaderboardHandler) GetTopPlayers(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	resp, err := h.core.GetTopPlayers(ctx, limit)
+	// as_of reads the standings from the latest snapshot at or before it
+	var resp *model.GetTopPlayersResponse
+	var err error
+	if asOfParam := r.URL.Query().Get("as_of"); asOfParam != "" {
+		asOf, parseErr := parseTimeParam(asOfParam)
+		if parseErr != nil {
+			h.respondWithError(w, http.StatusBadRequest, "Invalid as_of timestamp", constants.ErrInvalidRequest)
+			return
+		}
+		resp, err = h.core.GetTopPlayersAsOf(ctx, boardParam(r), limit, asOf)
+	} else {
+		// since_version asks for only the changes since a version the client has
+		var sinceVersion int64
+		if sinceParam := r.URL.Query().Get("since_version"); sinceParam != "" {
+			sinceVersion, err = strconv.ParseInt(sinceParam, 10, 64)
+			if err != nil || sinceVersion <= 0 {
+				h.respondWithError(w, http.StatusBadRequest, "Invalid since_version", constants.ErrInvalidRequest)
+				return
+			}
+		}
+		resp, err = h.core.GetTopPlayersSince(ctx, boardParam(r), limit, sinceVersion)
+	}
 	if err != nil {
 		h.logger.Error(
 			"GetTopPlayers failed",
@@ -141,6 +196,11 @@ func (h *LeaderboardHandler) GetTopPlayers(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
 	h.respondWithJSON(w, http.StatusOK, resp)
 }
 
@@ -159,7 +219,17 @@ func (h *LeaderboardHandler) GetPlayerRank(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	resp, err := h.core.GetPlayerRank(ctx, userID)
+	var resp *model.PlayerRankResponse
+	if asOfParam := r.URL.Query().Get("as_of"); asOfParam != "" {
+		asOf, parseErr := parseTimeParam(asOfParam)
+		if parseErr != nil {
+			h.respondWithError(w, http.StatusBadRequest, "Invalid as_of timestamp", constants.ErrInvalidRequest)
+			return
+		}
+		resp, err = h.core.GetPlayerRankAsOf(ctx, boardParam(r), userID, asOf)
+	} else {
+		resp, err = h.core.GetPlayerRank(ctx, boardParam(r), userID)
+	}
 	if err != nil {
 		h.logger.Error(
 			"GetPlayerRank failed",
@@ -189,6 +259,310 @@ func (h *LeaderboardHandler) GetPlayerRank(w http.ResponseWriter, r *http.Reques
 	h.respondWithJSON(w, http.StatusOK, resp)
 }
 
+func (h *LeaderboardHandler) GetFriendsLeaderboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	userID, err := strconv.ParseInt(vars["user_id"], 10, 64)
+	if err != nil || userID <= 0 {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid user ID",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	resp, err := h.core.GetFriendsLeaderboard(ctx, boardParam(r), userID)
+	if err != nil {
+		h.logger.Error(
+			"GetFriendsLeaderboard failed",
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch friends leaderboard",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *LeaderboardHandler) GetRankHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	userID, err := strconv.ParseInt(vars["user_id"], 10, 64)
+	if err != nil || userID <= 0 {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid user ID",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	// Default to the last 7 days
+	to := time.Now().UTC()
+	from := to.Add(-7 * 24 * time.Hour)
+
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		if to, err = parseTimeParam(toParam); err != nil {
+			h.respondWithError(w, http.StatusBadRequest, "Invalid to timestamp", constants.ErrInvalidRange)
+			return
+		}
+		from = to.Add(-7 * 24 * time.Hour)
+	}
+	if fromParam := r.URL.Query().Get("from"); fromParam != "" {
+		if from, err = parseTimeParam(fromParam); err != nil {
+			h.respondWithError(w, http.StatusBadRequest, "Invalid from timestamp", constants.ErrInvalidRange)
+			return
+		}
+	}
+
+	resp, err := h.core.GetRankHistory(ctx, boardParam(r), userID, from, to)
+	if err != nil {
+		h.logger.Error(
+			"GetRankHistory failed",
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch rank history",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		status := http.StatusBadRequest
+		if resp.Code == constants.ErrBoardNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.respondWithJSON(w, status, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *LeaderboardHandler) CreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	boardID := mux.Vars(r)["board_id"]
+
+	resp, err := h.core.CreateSnapshot(r.Context(), boardID)
+	if err != nil {
+		h.logger.Error(
+			"CreateSnapshot failed",
+			zap.String("board_id", boardID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to snapshot leaderboard",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, resp)
+}
+
+func (h *LeaderboardHandler) ListSnapshots(w http.ResponseWriter, r *http.Request) {
+	boardID := mux.Vars(r)["board_id"]
+	query := r.URL.Query()
+
+	// Default to the last 30 days
+	to := time.Now().UTC()
+	from := to.Add(-30 * 24 * time.Hour)
+
+	var err error
+	if toParam := query.Get("to"); toParam != "" {
+		if to, err = parseTimeParam(toParam); err != nil {
+			h.respondWithError(w, http.StatusBadRequest, "Invalid to timestamp", constants.ErrInvalidRange)
+			return
+		}
+		from = to.Add(-30 * 24 * time.Hour)
+	}
+	if fromParam := query.Get("from"); fromParam != "" {
+		if from, err = parseTimeParam(fromParam); err != nil {
+			h.respondWithError(w, http.StatusBadRequest, "Invalid from timestamp", constants.ErrInvalidRange)
+			return
+		}
+	}
+
+	limit := 0
+	if limitParam := query.Get("limit"); limitParam != "" {
+		if l, err := strconv.Atoi(limitParam); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	resp, err := h.core.ListSnapshots(r.Context(), boardID, from, to, limit)
+	if err != nil {
+		h.logger.Error(
+			"ListSnapshots failed",
+			zap.String("board_id", boardID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch snapshots",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		status := http.StatusBadRequest
+		if resp.Code == constants.ErrBoardNotFound {
+			status = http.StatusNotFound
+		}
+
+		h.respondWithJSON(w, status, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+// parseTimeParam accepts either an RFC3339 timestamp or a plain YYYY-MM-DD date.
+func parseTimeParam(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// boardParam reads the board id from the path, falling back to the ?board=
+// query parameter. An empty result selects the default board.
+func boardParam(r *http.Request) string {
+	if boardID := mux.Vars(r)["board_id"]; boardID != "" {
+		return boardID
+	}
+	return r.URL.Query().Get("board")
+}
+
+func (h *LeaderboardHandler) CreateBoard(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	ctx := r.Context()
+
+	var req model.CreateBoardRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid request payload",
+			constants.ErrInvalidBoard,
+		)
+		return
+	}
+
+	resp, err := h.core.CreateBoard(ctx, &req)
+	if err != nil {
+		h.logger.Error(
+			"CreateBoard failed",
+			zap.String("board_id", req.ID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to create leaderboard",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		status := http.StatusBadRequest
+		if resp.Code == constants.ErrBoardExists {
+			status = http.StatusConflict
+		}
+
+		h.respondWithJSON(w, status, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, resp)
+}
+
+func (h *LeaderboardHandler) ListBoards(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.core.ListBoards(r.Context())
+	if err != nil {
+		h.logger.Error(
+			"ListBoards failed",
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch leaderboards",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *LeaderboardHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
+	boardID := mux.Vars(r)["board_id"]
+
+	resp, err := h.core.GetBoard(r.Context(), boardID)
+	if err != nil {
+		h.logger.Error(
+			"GetBoard failed",
+			zap.String("board_id", boardID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch leaderboard",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
 func (h *LeaderboardHandler) StreamLeaderboard(w http.ResponseWriter, r *http.Request) {
 	// Set headers for SSE
 	w.Header().Set("Content-Type", "text/event-stream")
@@ -212,23 +586,71 @@ func (h *LeaderboardHandler) StreamLeaderboard(w http.ResponseWriter, r *http.Re
 	}
 
 	ctx := r.Context()
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
 
-	// Initial data send
-	if err := sendLeaderboardUpdate(ctx, w, *h.core); err != nil {
-		h.logger.Error("Failed to send initial leaderboard data", zap.Error(err))
+	view, err := streamViewParam(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "Invalid stream parameters", constants.ErrInvalidView)
+		return
+	}
+	if problem := core.ValidateView(&view); problem != "" {
+		h.respondWithError(w, http.StatusBadRequest, problem, constants.ErrInvalidView)
+		return
+	}
+
+	// diff=true sends only the changes after the first full update
+	diffMode := false
+	if value := r.URL.Query().Get("diff"); value != "" {
+		if diffMode, err = strconv.ParseBool(value); err != nil {
+			h.respondWithError(w, http.StatusBadRequest, "Invalid diff parameter", constants.ErrInvalidRequest)
+			return
+		}
+	}
+
+	// A reconnecting client resumes from the version it last received
+	sinceVersion, _ := strconv.ParseInt(r.Header.Get("Last-Event-ID"), 10, 64)
+	if value := r.URL.Query().Get("since_version"); value != "" && sinceVersion == 0 {
+		sinceVersion, _ = strconv.ParseInt(value, 10, 64)
+	}
+
+	// Updates come from the shared broadcaster instead of per-client polling
+	sub, err := h.core.SubscribeLeaderboard(ctx, boardParam(r), view, sinceVersion)
+	if err != nil {
+		if err.Error() == constants.ErrBoardNotFound {
+			h.respondWithError(w, http.StatusNotFound, "Leaderboard not found", constants.ErrBoardNotFound)
+			return
+		}
+		h.logger.Error("Failed to subscribe to leaderboard updates", zap.Error(err))
+		h.respondWithError(w, http.StatusInternalServerError, "Failed to stream leaderboard", constants.ErrInternalServer)
 		return
 	}
+	defer h.core.UnsubscribeLeaderboard(sub)
+
+	liftWriteDeadline(w)
+	w.WriteHeader(http.StatusOK)
 	flusher.Flush()
 
-	// Periodic updates
+	keepalive := time.NewTicker(streamKeepalive)
+	defer keepalive.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-ticker.C:
-			if err := sendLeaderboardUpdate(ctx, w, *h.core); err != nil {
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case update, ok := <-sub.C:
+			// A closed channel means this client fell behind and was evicted
+			if !ok {
+				return
+			}
+			event, data := "leaderboard", update.Payload
+			if diffMode && update.Diff != nil {
+				event, data = "diff", update.DiffPayload
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", update.Version, event, data); err != nil {
 				h.logger.Error("Failed to send leaderboard update", zap.Error(err))
 				return
 			}
@@ -237,30 +659,117 @@ func (h *LeaderboardHandler) StreamLeaderboard(w http.ResponseWriter, r *http.Re
 	}
 }
 
-// Helper function to send leaderboard updates
-func sendLeaderboardUpdate(ctx context.Context, w http.ResponseWriter, core core.LeaderboardCore) error {
-	// Get top players
-	players, err := core.GetTopPlayers(ctx, 10)
-	if err != nil {
-		return fmt.Errorf("failed to get top players: %w", err)
+// streamViewParam reads the watched view from the mode, limit, page, user_id
+// and window query parameters. Missing parameters keep their defaults.
+func streamViewParam(r *http.Request) (model.LeaderboardView, error) {
+	query := r.URL.Query()
+	view := model.LeaderboardView{Mode: query.Get("mode")}
+
+	var err error
+	if value := query.Get("limit"); value != "" {
+		if view.Limit, err = strconv.Atoi(value); err != nil {
+			return view, err
+		}
+	}
+	if value := query.Get("page"); value != "" {
+		if view.Page, err = strconv.Atoi(value); err != nil {
+			return view, err
+		}
+	}
+	if value := query.Get("user_id"); value != "" {
+		if view.UserID, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return view, err
+		}
+	}
+	if value := query.Get("window"); value != "" {
+		if view.Window, err = strconv.Atoi(value); err != nil {
+			return view, err
+		}
+	}
+	return view, nil
+}
+
+// streamKeepalive keeps idle streams open through proxies.
+const streamKeepalive = 15 * time.Second
+
+// liftWriteDeadline lets a stream outlive the server's WriteTimeout where the
+// response writer supports it.
+func liftWriteDeadline(w http.ResponseWriter) {
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+}
+
+// StreamNotifications streams the rank notifications of a single player as
+// server-sent events named after the notification type.
+func (h *LeaderboardHandler) StreamNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := strconv.ParseInt(mux.Vars(r)["user_id"], 10, 64)
+	if err != nil || userID <= 0 {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid user ID",
+			constants.ErrInvalidRequest,
+		)
+		return
 	}
 
-	// Create response with players array
-	response := map[string]interface{}{
-		"players":   players,
-		"updatedAt": time.Now().UTC().Format(time.RFC3339),
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
 	}
 
-	// Convert to JSON
-	data, err := json.Marshal(response)
+	sub, err := h.core.SubscribeNotifications(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("failed to marshal leaderboard data: %w", err)
-	}
+		h.logger.Error(
+			"SubscribeNotifications failed",
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
 
-	// Write SSE format
-	if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
-		return fmt.Errorf("failed to write SSE data: %w", err)
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to subscribe to notifications",
+			constants.ErrInternalServer,
+		)
+		return
 	}
+	defer h.core.UnsubscribeNotifications(sub)
 
-	return nil
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	liftWriteDeadline(w)
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepalive := time.NewTicker(streamKeepalive)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case notification, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(notification)
+			if err != nil {
+				h.logger.Error("Failed to marshal rank notification", zap.Error(err))
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", notification.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
 }
@@ -12,6 +12,10 @@ func (h *LeaderboardHandler) RegisterRoutes(router *mux.Router) {
 	_, submitHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboard/submit", http.HandlerFunc(h.SubmitScore))
 	router.Handle("/api/leaderboard/submit", submitHandler).Methods(http.MethodPost)
 
+	// Status of a score queued for asynchronous submission
+	_, submissionStatusHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboard/submissions/{tracking_id}", http.HandlerFunc(h.GetSubmissionStatus))
+	router.Handle("/api/leaderboard/submissions/{tracking_id}", submissionStatusHandler).Methods(http.MethodGet)
+
 	// Get top players endpoint
 	_, topPlayersHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboard/top", http.HandlerFunc(h.GetTopPlayers))
 	router.Handle("/api/leaderboard/top", topPlayersHandler).Methods(http.MethodGet)
@@ -20,9 +24,52 @@ func (h *LeaderboardHandler) RegisterRoutes(router *mux.Router) {
 	_, playerRankHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboard/rank/{user_id}", http.HandlerFunc(h.GetPlayerRank))
 	router.Handle("/api/leaderboard/rank/{user_id}", playerRankHandler).Methods(http.MethodGet)
 
+	// Get player rank history endpoint
+	_, rankHistoryHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboard/rank/{user_id}/history", http.HandlerFunc(h.GetRankHistory))
+	router.Handle("/api/leaderboard/rank/{user_id}/history", rankHistoryHandler).Methods(http.MethodGet)
+
+	// Get friends leaderboard endpoint
+	_, friendsHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboard/friends/{user_id}", http.HandlerFunc(h.GetFriendsLeaderboard))
+	router.Handle("/api/leaderboard/friends/{user_id}", friendsHandler).Methods(http.MethodGet)
+
 	// Get leaderboard stream endpoint
 	_, leaderboardStreamHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboard/stream", http.HandlerFunc(h.StreamLeaderboard))
 	router.Handle("/api/leaderboard/stream", leaderboardStreamHandler).Methods(http.MethodGet)
 
-	
+	// Live leaderboard subscriptions over WebSocket
+	_, webSocketHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboard/ws", http.HandlerFunc(h.StreamWebSocket))
+	router.Handle("/api/leaderboard/ws", webSocketHandler).Methods(http.MethodGet)
+
+	// Per-player rank notification stream
+	_, notificationsHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboard/notifications/{user_id}/stream", http.HandlerFunc(h.StreamNotifications))
+	router.Handle("/api/leaderboard/notifications/{user_id}/stream", notificationsHandler).Methods(http.MethodGet)
+
+	// Leaderboard definition endpoints
+	_, createBoardHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboards/create", http.HandlerFunc(h.CreateBoard))
+	router.Handle("/api/leaderboards", createBoardHandler).Methods(http.MethodPost)
+
+	_, listBoardsHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboards", http.HandlerFunc(h.ListBoards))
+	router.Handle("/api/leaderboards", listBoardsHandler).Methods(http.MethodGet)
+
+	_, getBoardHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboards/{board_id}", http.HandlerFunc(h.GetBoard))
+	router.Handle("/api/leaderboards/{board_id}", getBoardHandler).Methods(http.MethodGet)
+
+	// Board scoped reads share the default board handlers
+	_, boardTopHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboards/{board_id}/top", http.HandlerFunc(h.GetTopPlayers))
+	router.Handle("/api/leaderboards/{board_id}/top", boardTopHandler).Methods(http.MethodGet)
+
+	_, boardRankHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboards/{board_id}/rank/{user_id}", http.HandlerFunc(h.GetPlayerRank))
+	router.Handle("/api/leaderboards/{board_id}/rank/{user_id}", boardRankHandler).Methods(http.MethodGet)
+
+	_, boardHistoryHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboards/{board_id}/rank/{user_id}/history", http.HandlerFunc(h.GetRankHistory))
+	router.Handle("/api/leaderboards/{board_id}/rank/{user_id}/history", boardHistoryHandler).Methods(http.MethodGet)
+
+	_, createSnapshotHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboards/{board_id}/snapshots/create", http.HandlerFunc(h.CreateSnapshot))
+	router.Handle("/api/leaderboards/{board_id}/snapshots", createSnapshotHandler).Methods(http.MethodPost)
+
+	_, listSnapshotsHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboards/{board_id}/snapshots", http.HandlerFunc(h.ListSnapshots))
+	router.Handle("/api/leaderboards/{board_id}/snapshots", listSnapshotsHandler).Methods(http.MethodGet)
+
+	_, boardFriendsHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboards/{board_id}/friends/{user_id}", http.HandlerFunc(h.GetFriendsLeaderboard))
+	router.Handle("/api/leaderboards/{board_id}/friends/{user_id}", boardFriendsHandler).Methods(http.MethodGet)
 }
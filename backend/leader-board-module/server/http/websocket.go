@@ -0,0 +1,247 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/core"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+)
+
+const (
+	wsWriteWait      = 10 * time.Second
+	wsPongWait       = 60 * time.Second
+	wsPingInterval   = 30 * time.Second
+	wsMaxMessageSize = 4096
+	// wsSendBuffer bounds the messages queued for one connection; a client
+	// that falls this far behind is disconnected.
+	wsSendBuffer = 64
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Matches the SSE endpoints, which allow any origin
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// wsConnection is one WebSocket client and the subscriptions it holds.
+// Only writeLoop writes to the socket; everything else queues on send.
+type wsConnection struct {
+	h    *LeaderboardHandler
+	conn *websocket.Conn
+	ctx  context.Context
+	send chan interface{}
+	done chan struct{}
+
+	mu   sync.Mutex
+	subs map[string]*core.StreamSubscription
+}
+
+// StreamWebSocket serves live leaderboards over a WebSocket. Clients send
+// subscribe, unsubscribe and ping messages and receive a snapshot of every
+// subscribed view followed by diffs as it changes.
+func (h *LeaderboardHandler) StreamWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already answered the request
+		h.logger.Warn("WebSocket upgrade failed", zap.Error(err))
+		return
+	}
+
+	c := &wsConnection{
+		h:    h,
+		conn: conn,
+		ctx:  r.Context(),
+		send: make(chan interface{}, wsSendBuffer),
+		done: make(chan struct{}),
+		subs: make(map[string]*core.StreamSubscription),
+	}
+
+	go c.writeLoop()
+	c.readLoop()
+
+	close(c.done)
+	c.mu.Lock()
+	for id, sub := range c.subs {
+		delete(c.subs, id)
+		h.core.UnsubscribeLeaderboard(sub)
+	}
+	c.mu.Unlock()
+	conn.Close()
+}
+
+func (c *wsConnection) readLoop() {
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				c.h.logger.Warn("WebSocket read failed", zap.Error(err))
+			}
+			return
+		}
+		// Any message proves the client is alive
+		_ = c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+
+		var msg model.StreamClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.queue(&model.StreamMessage{Type: "error", Error: "Invalid message", Code: constants.ErrInvalidRequest})
+			continue
+		}
+
+		switch msg.Type {
+		case "subscribe":
+			c.subscribe(&msg)
+		case "unsubscribe":
+			c.unsubscribe(msg.ID)
+		case "ping":
+			c.queue(&model.StreamMessage{Type: "pong"})
+		default:
+			c.queue(&model.StreamMessage{Type: "error", ID: msg.ID, Error: "type must be subscribe, unsubscribe or ping", Code: constants.ErrInvalidRequest})
+		}
+	}
+}
+
+func (c *wsConnection) writeLoop() {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case msg := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				// Closing the socket ends readLoop, which cleans up
+				c.conn.Close()
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				c.conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// queue hands a message to writeLoop. A client whose queue is full is
+// disconnected rather than slowing down everyone sharing its feeds.
+func (c *wsConnection) queue(msg interface{}) {
+	select {
+	case <-c.done:
+	case c.send <- msg:
+	default:
+		c.h.logger.Warn("Disconnecting slow WebSocket client")
+		c.conn.Close()
+	}
+}
+
+func (c *wsConnection) subscribe(msg *model.StreamClientMessage) {
+	fail := func(message, code string) {
+		c.queue(&model.StreamMessage{Type: "error", ID: msg.ID, BoardID: msg.BoardID, Error: message, Code: code})
+	}
+
+	if msg.ID == "" {
+		fail("id is required", constants.ErrInvalidRequest)
+		return
+	}
+	view := msg.LeaderboardView
+	if problem := core.ValidateView(&view); problem != "" {
+		fail(problem, constants.ErrInvalidView)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.subs[msg.ID]; ok {
+		fail("A subscription with this id already exists", constants.ErrSubscriptionExists)
+		return
+	}
+	if len(c.subs) >= constants.MaxStreamSubscriptions {
+		fail("Too many subscriptions on this connection", constants.ErrTooManySubscriptions)
+		return
+	}
+
+	sub, err := c.h.core.SubscribeLeaderboard(c.ctx, msg.BoardID, view, msg.SinceVersion)
+	if err != nil {
+		if err.Error() == constants.ErrBoardNotFound {
+			fail("Leaderboard not found", constants.ErrBoardNotFound)
+			return
+		}
+		c.h.logger.Error("SubscribeLeaderboard failed", zap.String("board_id", msg.BoardID), zap.Error(err))
+		fail("Failed to subscribe", constants.ErrInternalServer)
+		return
+	}
+	c.subs[msg.ID] = sub
+
+	c.queue(&model.StreamMessage{Type: "subscribed", ID: msg.ID, BoardID: msg.BoardID})
+	go c.forward(msg.ID, sub)
+}
+
+func (c *wsConnection) unsubscribe(id string) {
+	c.mu.Lock()
+	sub, ok := c.subs[id]
+	delete(c.subs, id)
+	c.mu.Unlock()
+
+	if !ok {
+		c.queue(&model.StreamMessage{Type: "error", ID: id, Error: "Subscription not found", Code: constants.ErrSubscriptionNotFound})
+		return
+	}
+	c.h.core.UnsubscribeLeaderboard(sub)
+	c.queue(&model.StreamMessage{Type: "unsubscribed", ID: id})
+}
+
+// forward turns a subscription's updates into snapshot and diff messages
+// until the subscription is closed. A resumed subscription that is already
+// current gets no snapshot.
+func (c *wsConnection) forward(id string, sub *core.StreamSubscription) {
+	for update := range sub.C {
+		if update.Diff == nil {
+			c.queue(&model.StreamSnapshotMessage{
+				Type:    "snapshot",
+				ID:      id,
+				BoardID: update.BoardID,
+				Version: update.Version,
+				Players: update.Players,
+			})
+			continue
+		}
+		c.queue(&model.StreamDiffMessage{
+			Type:            "diff",
+			ID:              id,
+			BoardID:         update.BoardID,
+			Version:         update.Version,
+			BaseVersion:     update.BaseVersion,
+			LeaderboardDiff: *update.Diff,
+		})
+	}
+
+	// A subscription closed while still registered was evicted by the broadcaster
+	c.mu.Lock()
+	evicted := c.subs[id] == sub
+	if evicted {
+		delete(c.subs, id)
+	}
+	c.mu.Unlock()
+
+	if evicted {
+		c.queue(&model.StreamMessage{Type: "error", ID: id, Error: "Subscription dropped for falling behind", Code: constants.ErrSubscriptionEvicted})
+	}
+}
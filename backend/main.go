@@ -2,25 +2,53 @@ package main
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
 	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
 	"github.com/go-redis/redis/v8"
 	"github.com/gorilla/mux"
 	"github.com/newrelic/go-agent/v3/newrelic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	achievementCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/achievement-module/core"
+	achievementRepo "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/achievement-module/repository"
+	achievementHttp "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/achievement-module/server/http"
 	dataMigrationCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/data-migration-module/core"
 	dataMigrationRepo "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/data-migration-module/repository"
 	dataMigrationHttpModule "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/data-migration-module/server/http"
+	leaderBoardConstants "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
 	leaderBoardCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/core"
 	leaderBoardRepo "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/repository"
+	leaderBoardGrpc "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/server/grpc"
 	leaderBoardHttp "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/server/http"
+	rewardCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/core"
+	rewardRepo "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/repository"
+	rewardHttp "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/server/http"
+	tenantCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tenant-module/core"
+	tenantRepo "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tenant-module/repository"
+	tenantGrpc "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tenant-module/server/grpc"
+	tenantHttp "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tenant-module/server/http"
+	tournamentCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/core"
+	tournamentRepo "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/repository"
+	tournamentHttp "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/server/http"
 	userCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/core"
 	httpModule "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/server/http"
+	webhookCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/core"
+	webhookRepo "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/repository"
+	webhookHttp "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/server/http"
 )
 
 func main() {
@@ -104,6 +132,35 @@ func main() {
 		logger.Info("New Relic initialized successfully")
 	}
 
+	// ------------------------------------------------------------------
+	// Tenant Module
+	// ------------------------------------------------------------------
+	logger.Info("Initializing Tenant module")
+
+	// Requests without an API key or X-Game-Id header fall back to this game;
+	// set TENANT_DEFAULT_GAME to an empty string to require one.
+	tenantsRepo := tenantRepo.NewTenantRepository(db, logger)
+	tenantsCore := tenantCore.NewTenantCore(tenantsRepo, getEnv("TENANT_DEFAULT_GAME", global.DefaultGameID), logger)
+	tenantHandler := tenantHttp.NewTenantHandler(tenantsCore, logger)
+	router.Use(tenantHandler.Middleware)
+
+	logger.Info("Tenant middleware registered")
+
+	// ------------------------------------------------------------------
+	// gRPC Server
+	// ------------------------------------------------------------------
+	// Game servers call the same cores over gRPC; calls are scoped to their
+	// game by the tenant interceptor, as requests are by the middleware.
+	tenantInterceptor := tenantGrpc.NewTenantInterceptor(tenantsCore, logger)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcUnaryMiddleware(logger), tenantInterceptor.Unary()),
+		grpc.ChainStreamInterceptor(grpcStreamMiddleware(logger), tenantInterceptor.Stream()),
+		// Keeps idle watch streams open through proxies
+		grpc.KeepaliveParams(keepalive.ServerParameters{Time: 15 * time.Second}),
+	)
+
+	logger.Info("gRPC server created")
+
 	// ------------------------------------------------------------------
 	// User Module
 	// ------------------------------------------------------------------
@@ -136,13 +193,261 @@ func main() {
 	// ------------------------------------------------------------------
 	logger.Info("Initializing Leader Board module")
 
+	tierDefinitions := leaderBoardCore.DefaultTierDefinitions()
+	if tierConfigPath := getEnv("LEADERBOARD_TIERS_FILE", ""); tierConfigPath != "" {
+		tierDefinitions, err = leaderBoardCore.LoadTierDefinitions(tierConfigPath)
+		if err != nil {
+			logger.Fatalf("Failed to load tier definitions: %v", err)
+		}
+	}
+
+	tierEvaluator, err := leaderBoardCore.NewTierEvaluator(tierDefinitions)
+	if err != nil {
+		logger.Fatalf("Invalid tier definitions: %v", err)
+	}
+
 	leaderboardRepo := leaderBoardRepo.NewLeaderBoardRepository(db, redisClient, logger)
-	leaderboardCore := leaderBoardCore.NewLeaderboardCore(leaderboardRepo, logger)
+	leaderboardCore := leaderBoardCore.NewLeaderboardCore(leaderboardRepo, tierEvaluator, logger)
+
+	ingestMode := getEnv("SCORE_INGEST_MODE", leaderBoardConstants.IngestModeSync)
+	switch ingestMode {
+	case leaderBoardConstants.IngestModeSync:
+	case leaderBoardConstants.IngestModeAsync:
+		leaderboardCore.EnableAsyncIngest()
+	default:
+		logger.Fatalf("Unknown SCORE_INGEST_MODE %q: expected sync or async", ingestMode)
+	}
+
+	// Hot players' board scores are written behind when a buffer window is set
+	var scoreBuffer *leaderBoardCore.ScoreBuffer
+	if bufferWindow := getEnvDuration("SCORE_BUFFER_WINDOW", 0); bufferWindow > 0 {
+		scoreBuffer = leaderBoardCore.NewScoreBuffer(
+			leaderboardRepo,
+			logger,
+			bufferWindow,
+			getEnvInt("SCORE_BUFFER_HOT_THRESHOLD", 5),
+		)
+		leaderboardCore.EnableScoreBuffer(scoreBuffer)
+	}
+
 	leaderboardHandler := leaderBoardHttp.NewLeaderboardHandler(leaderboardCore, logger, nrApp)
 	leaderboardHandler.RegisterRoutes(router)
 
 	logger.Info("Leaderboard routes registered")
 
+	leaderboardGrpcServer := leaderBoardGrpc.NewLeaderboardServer(leaderboardCore, logger)
+	leaderboardGrpcServer.Register(grpcServer)
+
+	logger.Info("Leaderboard gRPC service registered")
+
+	// ------------------------------------------------------------------
+	// Achievement Module
+	// ------------------------------------------------------------------
+	logger.Info("Initializing Achievement module")
+
+	achievementDefinitions := achievementCore.DefaultAchievementDefinitions()
+	if achievementConfigPath := getEnv("ACHIEVEMENTS_FILE", ""); achievementConfigPath != "" {
+		achievementDefinitions, err = achievementCore.LoadAchievementDefinitions(achievementConfigPath)
+		if err != nil {
+			logger.Fatalf("Failed to load achievement definitions: %v", err)
+		}
+	}
+
+	achievementsRepo := achievementRepo.NewAchievementRepository(db, logger)
+	achievementsCore, err := achievementCore.NewAchievementCore(achievementsRepo, achievementDefinitions, logger)
+	if err != nil {
+		logger.Fatalf("Invalid achievement definitions: %v", err)
+	}
+	leaderboardCore.RegisterListener(achievementsCore)
+
+	achievementHandler := achievementHttp.NewAchievementHandler(achievementsCore, logger, nrApp)
+	achievementHandler.RegisterRoutes(router)
+
+	logger.Info("Achievement routes registered")
+
+	// ------------------------------------------------------------------
+	// Reward Module
+	// ------------------------------------------------------------------
+	logger.Info("Initializing Reward module")
+
+	rewardsRepo := rewardRepo.NewRewardRepository(db, logger)
+	rewardsCore := rewardCore.NewRewardCore(rewardsRepo, logger)
+	rewardHandler := rewardHttp.NewRewardHandler(rewardsCore, logger, nrApp)
+	rewardHandler.RegisterRoutes(router)
+
+	logger.Info("Reward routes registered")
+
+	// ------------------------------------------------------------------
+	// Tournament Module
+	// ------------------------------------------------------------------
+	logger.Info("Initializing Tournament module")
+
+	tournamentsRepo := tournamentRepo.NewTournamentRepository(db, logger)
+	tournamentsCore := tournamentCore.NewTournamentCore(tournamentsRepo, leaderboardCore, logger)
+	leaderboardCore.RegisterSubmitGuard(tournamentsCore)
+
+	tournamentHandler := tournamentHttp.NewTournamentHandler(tournamentsCore, logger, nrApp)
+	tournamentHandler.RegisterRoutes(router)
+
+	logger.Info("Tournament routes registered")
+
+	// ------------------------------------------------------------------
+	// Webhook Module
+	// ------------------------------------------------------------------
+	logger.Info("Initializing Webhook module")
+
+	// Receivers on loopback or private addresses are refused unless allowed,
+	// e.g. to test with scripts/webhook_receiver.py on localhost
+	allowPrivateReceivers := getEnvBool("WEBHOOK_ALLOW_PRIVATE_RECEIVERS", false)
+
+	webhooksRepo := webhookRepo.NewWebhookRepository(db, logger)
+	webhooksCore := webhookCore.NewWebhookCore(webhooksRepo, logger, allowPrivateReceivers)
+
+	webhookHandler := webhookHttp.NewWebhookHandler(webhooksCore, logger, nrApp)
+	webhookHandler.RegisterRoutes(router)
+
+	logger.Info("Webhook routes registered")
+
+	// ------------------------------------------------------------------
+	// Background Jobs
+	// ------------------------------------------------------------------
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	go leaderboardCore.RunEventRelay(jobCtx)
+
+	logger.Info("Leaderboard event relay started")
+
+	// Closed once the score buffer wrote what it held, or right away without one
+	scoreBufferFlushed := make(chan struct{})
+	if scoreBuffer != nil {
+		go func() {
+			defer close(scoreBufferFlushed)
+			scoreBuffer.Run(jobCtx)
+		}()
+
+		logger.Info("Score buffer started")
+	} else {
+		close(scoreBufferFlushed)
+	}
+
+	var eventSink leaderBoardCore.EventSink
+	switch sinkKind := getEnv("EVENT_SINK", leaderBoardConstants.EventSinkRedis); sinkKind {
+	case leaderBoardConstants.EventSinkRedis:
+		eventSink = leaderBoardCore.NewRedisStreamSink(redisClient, getEnv("EVENT_STREAM", leaderBoardConstants.EventStream), 100000)
+	case leaderBoardConstants.EventSinkHTTP:
+		sinkURL := getEnv("EVENT_SINK_URL", "")
+		if sinkURL == "" {
+			logger.Fatalf("EVENT_SINK_URL is required for the http event sink")
+		}
+		eventSink = leaderBoardCore.NewHTTPSink(sinkURL, getEnvDuration("EVENT_SINK_TIMEOUT", 10*time.Second))
+	case leaderBoardConstants.EventSinkStdout:
+		eventSink = leaderBoardCore.NewWriterSink(os.Stdout)
+	default:
+		logger.Fatalf("Unknown EVENT_SINK %q: expected redis, http or stdout", sinkKind)
+	}
+
+	// Webhooks queue their deliveries from the outbox too, so they only see
+	// committed submits and survive a crash between commit and delivery
+	outboxRelay := leaderBoardCore.NewOutboxRelay(
+		leaderboardRepo,
+		leaderBoardCore.FanOutSink{eventSink, webhooksCore},
+		logger,
+		getEnvDuration("OUTBOX_RELAY_INTERVAL", time.Second),
+		getEnvDuration("OUTBOX_RETENTION", 7*24*time.Hour),
+	)
+	go outboxRelay.Run(jobCtx)
+
+	logger.Info("Outbox relay started")
+
+	if ingestMode == leaderBoardConstants.IngestModeAsync {
+		ingestWorkers := leaderBoardCore.NewIngestWorkerPool(
+			leaderboardCore,
+			logger,
+			getEnvInt("INGEST_WORKERS", 4),
+			int64(getEnvInt("INGEST_BATCH_SIZE", 100)),
+		)
+		go ingestWorkers.Run(jobCtx)
+
+		logger.Info("Score ingest workers started")
+	}
+
+	rankHistoryJob := leaderBoardCore.NewRankHistoryJob(
+		leaderboardRepo,
+		tenantsCore,
+		logger,
+		getEnvDuration("RANK_SNAPSHOT_INTERVAL", time.Hour),
+		getEnvDuration("RANK_HISTORY_COMPACT_AFTER", 7*24*time.Hour),
+		getEnvDuration("RANK_HISTORY_RETENTION", 365*24*time.Hour),
+	)
+	go rankHistoryJob.Run(jobCtx)
+
+	logger.Info("Rank history job started")
+
+	leaderboardSnapshotJob := leaderBoardCore.NewLeaderboardSnapshotJob(
+		leaderboardRepo,
+		tenantsCore,
+		logger,
+		getEnvDuration("LEADERBOARD_SNAPSHOT_INTERVAL", 24*time.Hour),
+		getEnvDuration("LEADERBOARD_SNAPSHOT_RETENTION", 90*24*time.Hour),
+	)
+	go leaderboardSnapshotJob.Run(jobCtx)
+
+	logger.Info("Leaderboard snapshot job started")
+
+	scoreDecayJob := leaderBoardCore.NewScoreDecayJob(
+		leaderboardRepo,
+		tenantsCore,
+		logger,
+		getEnvDuration("SCORE_DECAY_INTERVAL", time.Hour),
+	)
+	go scoreDecayJob.Run(jobCtx)
+
+	logger.Info("Score decay job started")
+
+	rewardDistributionJob := rewardCore.NewRewardDistributionJob(
+		rewardsRepo,
+		tenantsCore,
+		logger,
+		getEnvDuration("REWARD_DISTRIBUTION_INTERVAL", 15*time.Minute),
+	)
+	go rewardDistributionJob.Run(jobCtx)
+
+	logger.Info("Reward distribution job started")
+
+	tournamentFinalizeJob := tournamentCore.NewTournamentFinalizeJob(
+		tournamentsRepo,
+		tenantsCore,
+		logger,
+		getEnvDuration("TOURNAMENT_FINALIZE_INTERVAL", time.Minute),
+	)
+	go tournamentFinalizeJob.Run(jobCtx)
+
+	logger.Info("Tournament finalize job started")
+
+	seasonEndJob := webhookCore.NewSeasonEndJob(
+		webhooksRepo,
+		tenantsCore,
+		logger,
+		getEnvDuration("WEBHOOK_SEASON_INTERVAL", time.Minute),
+	)
+	go seasonEndJob.Run(jobCtx)
+
+	logger.Info("Season end webhook job started")
+
+	webhookDeliveryJob := webhookCore.NewWebhookDeliveryJob(
+		webhooksRepo,
+		logger,
+		getEnvDuration("WEBHOOK_DELIVERY_INTERVAL", time.Second),
+		getEnvDuration("WEBHOOK_DELIVERY_TIMEOUT", 10*time.Second),
+		getEnvDuration("WEBHOOK_RETRY_BASE", 30*time.Second),
+		getEnvDuration("WEBHOOK_DELIVERY_RETENTION", 30*24*time.Hour),
+		allowPrivateReceivers,
+	)
+	go webhookDeliveryJob.Run(jobCtx)
+
+	logger.Info("Webhook delivery job started")
+
 	// ------------------------------------------------------------------
 	// Health Check
 	// ------------------------------------------------------------------
@@ -164,10 +469,54 @@ func main() {
 		IdleTimeout:  120 * time.Second,
 	}
 
+	// gRPC is served on its own port; set GRPC_PORT to an empty string to disable it
+	if grpcPort := getEnv("GRPC_PORT", "9090"); grpcPort != "" {
+		listener, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			logger.Fatalf("gRPC server startup failed: %v", err)
+		}
+		go func() {
+			logger.Infof("gRPC server listening on :%s", grpcPort)
+			if err := grpcServer.Serve(listener); err != nil {
+				logger.Fatalf("gRPC server failed: %v", err)
+			}
+		}()
+	}
+
+	// Stop taking requests on SIGINT/SIGTERM, then stop the jobs
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	serverDrained := make(chan struct{})
+	go func() {
+		defer close(serverDrained)
+		<-signalCtx.Done()
+		logger.Info("Shutting down")
+
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancelShutdown()
+
+		var grpcStopped sync.WaitGroup
+		grpcStopped.Add(1)
+		go func() {
+			defer grpcStopped.Done()
+			stopGrpcServer(shutdownCtx, grpcServer)
+		}()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Errorf("Server shutdown failed: %v", err)
+		}
+		grpcStopped.Wait()
+	}()
+
 	logger.Infof("Server listening on :%s", port)
 	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		logger.Fatalf("Server startup failed: %v", err)
 	}
+
+	// In-flight submits may still add to the score buffer until drained
+	<-serverDrained
+	stopJobs()
+	<-scoreBufferFlushed
 }
 
 // Helper function to get environment variable with fallback
@@ -178,6 +527,53 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// Helper function to get a duration environment variable (e.g. "30m") with fallback
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+	}
+	return fallback
+}
+
+// Helper function to get a positive integer environment variable with fallback
+func getEnvInt(key string, fallback int) int {
+	if value, exists := os.LookupEnv(key); exists {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return n
+		}
+	}
+	return fallback
+}
+
+// Helper function to get a boolean environment variable (e.g. "true") with fallback
+func getEnvBool(key string, fallback bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+// stopGrpcServer lets in-flight calls finish, cancelling those still running
+// once ctx is done, e.g. leaderboard watches.
+func stopGrpcServer(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+		<-stopped
+	}
+}
+
 // panicRecovery middleware handles panics and logs them
 func panicRecovery(logger *providers.ConsoleLogger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -221,3 +617,40 @@ func requestLogger(logger *providers.ConsoleLogger) func(http.Handler) http.Hand
 		})
 	}
 }
+
+// grpcUnaryMiddleware handles panics and logs unary calls, like panicRecovery
+// and requestLogger do for HTTP requests
+func grpcUnaryMiddleware(logger *providers.ConsoleLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		start := time.Now()
+		logger.Infof("Incoming call | method=%s", info.FullMethod)
+
+		defer func() {
+			if rec := recover(); rec != nil {
+				logger.Errorf("Panic recovered | method=%s error=%v", info.FullMethod, rec)
+				err = status.Error(codes.Internal, "Internal Server Error")
+			}
+			logger.Infof("Call completed | method=%s code=%s duration=%s", info.FullMethod, status.Code(err), time.Since(start))
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// grpcStreamMiddleware handles panics and logs streaming calls
+func grpcStreamMiddleware(logger *providers.ConsoleLogger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		start := time.Now()
+		logger.Infof("Incoming call | method=%s", info.FullMethod)
+
+		defer func() {
+			if rec := recover(); rec != nil {
+				logger.Errorf("Panic recovered | method=%s error=%v", info.FullMethod, rec)
+				err = status.Error(codes.Internal, "Internal Server Error")
+			}
+			logger.Infof("Call completed | method=%s code=%s duration=%s", info.FullMethod, status.Code(err), time.Since(start))
+		}()
+
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,22 @@
+package constants
+
+import "time"
+
+// Grant filters accepted by GET /api/users/{id}/rewards.
+const (
+	StatusAll       = "all"
+	StatusUnclaimed = "unclaimed"
+	StatusClaimed   = "claimed"
+)
+
+const (
+	// MaxBands caps the number of bands in a reward table.
+	MaxBands = 50
+
+	// MaxRewardsPerPage caps the grants returned for a user.
+	MaxRewardsPerPage = 100
+
+	// SettleGrace lets submits accepted just before a period closed or a
+	// board ended land before the period is distributed.
+	SettleGrace = time.Minute
+)
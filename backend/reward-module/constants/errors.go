@@ -0,0 +1,12 @@
+package constants
+
+const (
+	ErrUserNotFound         = "USER_NOT_FOUND"
+	ErrBoardNotFound        = "BOARD_NOT_FOUND"
+	ErrInvalidRewardTable   = "INVALID_REWARD_TABLE"
+	ErrRewardTableExists    = "REWARD_TABLE_EXISTS"
+	ErrGrantNotFound        = "REWARD_NOT_FOUND"
+	ErrRewardAlreadyClaimed = "REWARD_ALREADY_CLAIMED"
+	ErrInternalServer       = "INTERNAL_SERVER_ERROR"
+	ErrInvalidRequest       = "INVALID_REQUEST"
+)
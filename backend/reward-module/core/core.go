@@ -0,0 +1,221 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	leaderboardConstants "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	leaderboardCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/core"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/repository"
+)
+
+type IRewardCore interface {
+	CreateRewardTable(ctx context.Context, boardID string, req *model.CreateRewardTableRequest) (*model.RewardTableResponse, error)
+	ListRewardTables(ctx context.Context, boardID string) (*model.RewardTablesResponse, error)
+	GetUserRewards(ctx context.Context, userID int64, status string) (*model.UserRewardsResponse, error)
+	ClaimReward(ctx context.Context, userID, grantID int64) (*model.ClaimRewardResponse, error)
+}
+
+type RewardCore struct {
+	repo   *repository.RewardRepository
+	logger *providers.ConsoleLogger
+}
+
+func NewRewardCore(repo *repository.RewardRepository, logger *providers.ConsoleLogger) *RewardCore {
+	return &RewardCore{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// CreateRewardTable attaches a reward table to a board. Rewards are granted
+// when a period closes, so only boards with a reset schedule or an end time
+// can have them. A period_start anywhere inside a period is moved to the
+// start of it.
+func (c *RewardCore) CreateRewardTable(ctx context.Context, boardID string, req *model.CreateRewardTableRequest) (*model.RewardTableResponse, error) {
+	board, err := c.repo.GetBoard(ctx, boardID)
+	if err != nil {
+		if err.Error() == constants.ErrBoardNotFound {
+			return &model.RewardTableResponse{
+				Success: false,
+				Error:   "Leaderboard not found",
+				Code:    constants.ErrBoardNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+
+	if board.ResetSchedule == leaderboardConstants.ResetNever && board.EndsAt == nil {
+		return &model.RewardTableResponse{
+			Success: false,
+			Error:   "Rewards can only be attached to leaderboards with a reset schedule or an end time",
+			Code:    constants.ErrInvalidRewardTable,
+		}, nil
+	}
+
+	if message := validateBands(req.Bands); message != "" {
+		return &model.RewardTableResponse{
+			Success: false,
+			Error:   message,
+			Code:    constants.ErrInvalidRewardTable,
+		}, nil
+	}
+
+	table := &model.RewardTable{
+		BoardID:   board.ID,
+		Bands:     req.Bands,
+		CreatedAt: time.Now().UTC(),
+	}
+	if req.PeriodStart != nil {
+		start := leaderboardCore.PeriodStart(board.ResetSchedule, *req.PeriodStart)
+		table.PeriodStart = &start
+	}
+
+	if err := c.repo.CreateRewardTable(ctx, table); err != nil {
+		if err.Error() == constants.ErrRewardTableExists {
+			return &model.RewardTableResponse{
+				Success: false,
+				Error:   "A reward table for this leaderboard period already exists",
+				Code:    constants.ErrRewardTableExists,
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &model.RewardTableResponse{
+		Success: true,
+		Data:    table,
+	}, nil
+}
+
+func (c *RewardCore) ListRewardTables(ctx context.Context, boardID string) (*model.RewardTablesResponse, error) {
+	board, err := c.repo.GetBoard(ctx, boardID)
+	if err != nil {
+		if err.Error() == constants.ErrBoardNotFound {
+			return &model.RewardTablesResponse{
+				Success: false,
+				BoardID: boardID,
+				Error:   "Leaderboard not found",
+				Code:    constants.ErrBoardNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+
+	tables, err := c.repo.ListRewardTables(ctx, board.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.RewardTablesResponse{
+		Success: true,
+		BoardID: board.ID,
+		Tables:  tables,
+	}, nil
+}
+
+func (c *RewardCore) GetUserRewards(ctx context.Context, userID int64, status string) (*model.UserRewardsResponse, error) {
+	if userID <= 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	exists, err := c.repo.UserExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &model.UserRewardsResponse{
+			Success: false,
+			UserID:  userID,
+			Error:   "User not found",
+			Code:    constants.ErrUserNotFound,
+		}, nil
+	}
+
+	grants, err := c.repo.ListUserGrants(ctx, userID, status, constants.MaxRewardsPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.UserRewardsResponse{
+		Success: true,
+		UserID:  userID,
+		Rewards: grants,
+	}, nil
+}
+
+func (c *RewardCore) ClaimReward(ctx context.Context, userID, grantID int64) (*model.ClaimRewardResponse, error) {
+	if userID <= 0 || grantID <= 0 {
+		return nil, errors.New("invalid user or reward ID")
+	}
+
+	grant, err := c.repo.ClaimGrant(ctx, userID, grantID, time.Now().UTC())
+	if err != nil {
+		switch err.Error() {
+		case constants.ErrGrantNotFound:
+			return &model.ClaimRewardResponse{
+				Success: false,
+				Error:   "Reward not found",
+				Code:    constants.ErrGrantNotFound,
+			}, nil
+		case constants.ErrRewardAlreadyClaimed:
+			return &model.ClaimRewardResponse{
+				Success: false,
+				Error:   "Reward has already been claimed",
+				Code:    constants.ErrRewardAlreadyClaimed,
+			}, nil
+		}
+		return nil, err
+	}
+
+	c.logger.Infof("Reward claimed | user_id=%d reward_id=%d item=%s quantity=%d", userID, grant.ID, grant.Item, grant.Quantity)
+
+	return &model.ClaimRewardResponse{
+		Success: true,
+		Data:    grant,
+	}, nil
+}
+
+// validateBands returns a human readable problem with the bands, or "". A rank
+// band without to_rank covers the single rank from_rank.
+func validateBands(bands []model.RewardBand) string {
+	if len(bands) == 0 || len(bands) > constants.MaxBands {
+		return fmt.Sprintf("bands must contain between 1 and %d entries", constants.MaxBands)
+	}
+
+	for i := range bands {
+		band := &bands[i]
+
+		if band.TopPercent != nil {
+			if band.FromRank != 0 || band.ToRank != 0 {
+				return fmt.Sprintf("band %d must use either a rank range or top_percent, not both", i+1)
+			}
+			if *band.TopPercent <= 0 || *band.TopPercent > 100 {
+				return fmt.Sprintf("band %d top_percent must be greater than 0 and at most 100", i+1)
+			}
+		} else {
+			if band.ToRank == 0 {
+				band.ToRank = band.FromRank
+			}
+			if band.FromRank < 1 || band.ToRank < band.FromRank {
+				return fmt.Sprintf("band %d needs from_rank >= 1 and to_rank >= from_rank", i+1)
+			}
+		}
+
+		band.Item = strings.TrimSpace(band.Item)
+		if band.Item == "" || len(band.Item) > 64 {
+			return fmt.Sprintf("band %d item must be between 1 and 64 characters", i+1)
+		}
+		if band.Quantity <= 0 {
+			return fmt.Sprintf("band %d quantity must be positive", i+1)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	leaderboardConstants "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/repository"
+)
+
+func TestPercentileRank(t *testing.T) {
+	tests := []struct {
+		name    string
+		percent float64
+		players int64
+		want    int
+	}{
+		{name: "exact", percent: 10, players: 1000, want: 100},
+		{name: "rounded up", percent: 10, players: 15, want: 2},
+		{name: "at least one player", percent: 1, players: 50, want: 1},
+		{name: "small percent", percent: 0.1, players: 1000, want: 1},
+		{name: "everyone", percent: 100, players: 7, want: 7},
+		{name: "no players", percent: 10, players: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentileRank(tt.percent, tt.players); got != tt.want {
+				t.Errorf("percentileRank(%v, %d) = %d, want %d", tt.percent, tt.players, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBandFor(t *testing.T) {
+	percent := func(p float64) *float64 { return &p }
+	bands := []model.RewardBand{
+		{FromRank: 1, ToRank: 1, Item: "gold"},
+		{FromRank: 2, ToRank: 3, Item: "silver"},
+		{TopPercent: percent(10), Item: "bronze"},
+		{FromRank: 4, ToRank: 500, Item: "participation"},
+	}
+
+	tests := []struct {
+		name    string
+		rank    int
+		players int64
+		want    string // item of the band, "" for none
+	}{
+		{name: "first", rank: 1, players: 1000, want: "gold"},
+		{name: "rank range", rank: 3, players: 1000, want: "silver"},
+		{name: "percent band", rank: 4, players: 1000, want: "bronze"},
+		{name: "edge of percent band", rank: 100, players: 1000, want: "bronze"},
+		{name: "past percent band", rank: 101, players: 1000, want: "participation"},
+		{name: "percent band grows with players", rank: 150, players: 2000, want: "bronze"},
+		{name: "earlier band wins", rank: 2, players: 10, want: "silver"},
+		{name: "no band", rank: 501, players: 1000, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bandFor(bands, tt.rank, tt.players)
+			switch {
+			case got == nil && tt.want != "":
+				t.Errorf("bandFor(%d, %d) = nil, want %q", tt.rank, tt.players, tt.want)
+			case got != nil && got.Item != tt.want:
+				t.Errorf("bandFor(%d, %d) = %q, want %q", tt.rank, tt.players, got.Item, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewardedRanks(t *testing.T) {
+	percent := func(p float64) *float64 { return &p }
+
+	tests := []struct {
+		name    string
+		bands   []model.RewardBand
+		players int64
+		want    int
+	}{
+		{name: "no bands", players: 1000, want: 0},
+		{name: "rank bands", bands: []model.RewardBand{{FromRank: 1, ToRank: 1}, {FromRank: 2, ToRank: 10}}, players: 1000, want: 10},
+		{name: "bands out of order", bands: []model.RewardBand{{FromRank: 11, ToRank: 50}, {FromRank: 1, ToRank: 10}}, players: 1000, want: 50},
+		{name: "percent band reaches further", bands: []model.RewardBand{{FromRank: 1, ToRank: 10}, {TopPercent: percent(5)}}, players: 1000, want: 50},
+		{name: "rank band reaches further", bands: []model.RewardBand{{FromRank: 1, ToRank: 10}, {TopPercent: percent(5)}}, players: 100, want: 10},
+		{name: "percent band without players", bands: []model.RewardBand{{TopPercent: percent(5)}}, players: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rewardedRanks(tt.bands, tt.players); got != tt.want {
+				t.Errorf("rewardedRanks() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClosedPeriods(t *testing.T) {
+	// Wednesday
+	now := time.Date(2026, 1, 7, 12, 0, 0, 0, time.UTC)
+	at := func(day, hour int) *time.Time {
+		at := time.Date(2026, 1, day, hour, 0, 0, 0, time.UTC)
+		return &at
+	}
+	epoch := time.Unix(0, 0).UTC()
+
+	tests := []struct {
+		name     string
+		schedule string
+		endsAt   *time.Time
+		want     time.Time
+	}{
+		{name: "weekly board", schedule: leaderboardConstants.ResetWeekly, want: *at(5, 0)},
+		{name: "daily board", schedule: leaderboardConstants.ResetDaily, want: *at(7, 0)},
+		{name: "evergreen board never closes", schedule: leaderboardConstants.ResetNever, want: epoch},
+		{name: "event board still running", schedule: leaderboardConstants.ResetNever, endsAt: at(7, 13), want: epoch},
+		{name: "event board ended", schedule: leaderboardConstants.ResetNever, endsAt: at(7, 12), want: epoch.Add(time.Nanosecond)},
+		{name: "daily board ended today", schedule: leaderboardConstants.ResetDaily, endsAt: at(7, 9), want: at(7, 0).Add(time.Nanosecond)},
+		{name: "daily board ending later", schedule: leaderboardConstants.ResetDaily, endsAt: at(9, 0), want: *at(7, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := &repository.Board{ID: "board", ResetSchedule: tt.schedule, EndsAt: tt.endsAt}
+			if got := closedPeriods(board, now); !got.Equal(tt.want) {
+				t.Errorf("closedPeriods() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
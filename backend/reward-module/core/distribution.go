@@ -0,0 +1,270 @@
+package core
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	leaderboardCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/core"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/repository"
+)
+
+// GameLister lists the games (tenants) background jobs have to visit.
+type GameLister interface {
+	ListGameIDs(ctx context.Context) ([]string, error)
+}
+
+// RewardDistributionJob grants rewards for board periods that have closed:
+// periods of boards with a reset schedule once the next one started, and
+// the last period of an event board once the board ended. Every period is
+// distributed in a single transaction guarded by its distribution record, so
+// overlapping runs and restarts never grant twice.
+type RewardDistributionJob struct {
+	repo     *repository.RewardRepository
+	games    GameLister
+	logger   *providers.ConsoleLogger
+	interval time.Duration
+}
+
+func NewRewardDistributionJob(
+	repo *repository.RewardRepository,
+	games GameLister,
+	logger *providers.ConsoleLogger,
+	interval time.Duration,
+) *RewardDistributionJob {
+	return &RewardDistributionJob{
+		repo:     repo,
+		games:    games,
+		logger:   logger,
+		interval: interval,
+	}
+}
+
+// Run blocks until ctx is cancelled, distributing rewards on every interval.
+func (j *RewardDistributionJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.runOnce(ctx, time.Now().UTC())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			j.runOnce(ctx, now.UTC())
+		}
+	}
+}
+
+func (j *RewardDistributionJob) runOnce(ctx context.Context, now time.Time) {
+	gameIDs, err := j.games.ListGameIDs(ctx)
+	if err != nil {
+		j.logger.Errorf("Reward distribution failed to list games | error=%v", err)
+		return
+	}
+
+	for _, gameID := range gameIDs {
+		gameCtx := global.WithGameID(ctx, gameID)
+
+		boards, err := j.repo.ListRewardedBoards(gameCtx)
+		if err != nil {
+			j.logger.Errorf("Reward distribution failed to list boards | game_id=%s error=%v", gameID, err)
+			continue
+		}
+
+		for i := range boards {
+			if err := j.distributeBoard(gameCtx, &boards[i], now); err != nil {
+				j.logger.Errorf("Reward distribution failed | game_id=%s board_id=%s error=%v", gameID, boards[i].ID, err)
+			}
+		}
+	}
+}
+
+// distributeBoard distributes every closed period of the board that a reward
+// table applies to and that has not been distributed yet.
+func (j *RewardDistributionJob) distributeBoard(ctx context.Context, board *repository.Board, now time.Time) error {
+	tables, err := j.repo.ListRewardTables(ctx, board.ID)
+	if err != nil {
+		return err
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	closedBefore := closedPeriods(board, now.Add(-constants.SettleGrace))
+	earliest := closedBefore
+	for i := range tables {
+		if start := firstPeriod(board, &tables[i]); start.Before(earliest) {
+			earliest = start
+		}
+	}
+
+	periods, err := j.repo.ListUndistributedPeriods(ctx, board.ID, earliest, closedBefore)
+	if err != nil {
+		return err
+	}
+
+	for _, period := range periods {
+		table := tableFor(board, tables, period)
+		if table == nil {
+			continue
+		}
+
+		if err := j.distributePeriod(ctx, board, table, period, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *RewardDistributionJob) distributePeriod(
+	ctx context.Context,
+	board *repository.Board,
+	table *model.RewardTable,
+	period time.Time,
+	now time.Time,
+) error {
+	// Hot players' scores may still be buffered; the period is distributed
+	// on a later run, once the buffer flushed them
+	buffered, err := j.repo.HasBufferedScores(ctx, board.ID, period)
+	if err != nil {
+		return err
+	}
+	if buffered {
+		j.logger.Infof("Reward distribution waits for buffered scores | board_id=%s period_start=%s", board.ID, period.Format(time.RFC3339))
+		return nil
+	}
+
+	players, err := j.repo.CountPlayers(ctx, board.ID, period)
+	if err != nil {
+		return err
+	}
+
+	var standings []repository.Standing
+	if maxRank := rewardedRanks(table.Bands, players); maxRank > 0 {
+		standings, err = j.repo.GetFinalStandings(ctx, board, period, maxRank)
+		if err != nil {
+			return err
+		}
+	}
+
+	distribution := &repository.Distribution{
+		BoardID:       board.ID,
+		PeriodStart:   period,
+		RewardTableID: table.ID,
+		Players:       players,
+		DistributedAt: now,
+	}
+	for _, standing := range standings {
+		band := bandFor(table.Bands, standing.Rank, players)
+		if band == nil {
+			continue
+		}
+		distribution.Grants = append(distribution.Grants, repository.Grant{
+			UserID:   standing.UserID,
+			Rank:     standing.Rank,
+			Score:    standing.Score,
+			Item:     band.Item,
+			Quantity: band.Quantity,
+		})
+	}
+
+	distributed, err := j.repo.Distribute(ctx, distribution)
+	if err != nil {
+		return err
+	}
+	if distributed {
+		j.logger.Infof(
+			"Rewards distributed | board_id=%s period_start=%s players=%d grants=%d",
+			board.ID,
+			period.Format(time.RFC3339),
+			players,
+			len(distribution.Grants),
+		)
+	}
+	return nil
+}
+
+// closedPeriods returns the bound periods of the board that closed by now
+// start before: the start of the current period, or just past the start of
+// the last one once an event board ended. Queued submits are applied to the
+// period they are applied in and refused once a board ended, so only
+// submits in flight at the close can still land, within SettleGrace.
+func closedPeriods(board *repository.Board, now time.Time) time.Time {
+	if board.EndsAt != nil && !now.Before(*board.EndsAt) {
+		return leaderboardCore.PeriodStart(board.ResetSchedule, *board.EndsAt).Add(time.Nanosecond)
+	}
+	return leaderboardCore.PeriodStart(board.ResetSchedule, now)
+}
+
+// firstPeriod returns the first period a reward table applies to. A default
+// table starts with the period it was created in, so attaching one does not
+// reward periods that closed long before.
+func firstPeriod(board *repository.Board, table *model.RewardTable) time.Time {
+	if table.PeriodStart != nil {
+		return *table.PeriodStart
+	}
+	return leaderboardCore.PeriodStart(board.ResetSchedule, table.CreatedAt)
+}
+
+// tableFor picks the reward table of a period, preferring one created for
+// that exact period over the board's default table.
+func tableFor(board *repository.Board, tables []model.RewardTable, period time.Time) *model.RewardTable {
+	var fallback *model.RewardTable
+	for i := range tables {
+		table := &tables[i]
+		if table.PeriodStart != nil {
+			if table.PeriodStart.Equal(period) {
+				return table
+			}
+			continue
+		}
+		if !period.Before(firstPeriod(board, table)) {
+			fallback = table
+		}
+	}
+	return fallback
+}
+
+// bandFor returns the first band matching a final rank, or nil.
+func bandFor(bands []model.RewardBand, rank int, players int64) *model.RewardBand {
+	for i := range bands {
+		band := &bands[i]
+		if band.TopPercent != nil {
+			if rank <= percentileRank(*band.TopPercent, players) {
+				return band
+			}
+			continue
+		}
+		if rank >= band.FromRank && rank <= band.ToRank {
+			return band
+		}
+	}
+	return nil
+}
+
+// rewardedRanks returns the worst rank any band can reward.
+func rewardedRanks(bands []model.RewardBand, players int64) int {
+	worst := 0
+	for _, band := range bands {
+		last := band.ToRank
+		if band.TopPercent != nil {
+			last = percentileRank(*band.TopPercent, players)
+		}
+		if last > worst {
+			worst = last
+		}
+	}
+	return worst
+}
+
+// percentileRank is the worst rank inside the top percent of players; any
+// non-empty period has at least one player in every percentile band.
+func percentileRank(percent float64, players int64) int {
+	return int(math.Ceil(float64(players) * percent / 100))
+}
@@ -0,0 +1,74 @@
+package model
+
+import "time"
+
+// RewardTable maps final ranks of a board period to rewards. A table without
+// a PeriodStart applies to every period of the board that closes after it was
+// created; a table for a specific period takes precedence over it.
+type RewardTable struct {
+	ID          int64        `json:"id"`
+	BoardID     string       `json:"board_id"`
+	PeriodStart *time.Time   `json:"period_start,omitempty"`
+	Bands       []RewardBand `json:"bands"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// RewardBand is matched either by an inclusive rank range (FromRank..ToRank)
+// or by a percentile band (TopPercent), e.g. TopPercent 1 means "top 1% of
+// ranked players". Bands are checked in order and the first match wins.
+type RewardBand struct {
+	FromRank   int      `json:"from_rank,omitempty"`
+	ToRank     int      `json:"to_rank,omitempty"`
+	TopPercent *float64 `json:"top_percent,omitempty"`
+	Item       string   `json:"item"`
+	Quantity   int64    `json:"quantity"`
+}
+
+type CreateRewardTableRequest struct {
+	PeriodStart *time.Time   `json:"period_start,omitempty"`
+	Bands       []RewardBand `json:"bands"`
+}
+
+type RewardTableResponse struct {
+	Success bool         `json:"success"`
+	Data    *RewardTable `json:"data,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Code    string       `json:"code,omitempty"`
+}
+
+type RewardTablesResponse struct {
+	Success bool          `json:"success"`
+	BoardID string        `json:"board_id"`
+	Tables  []RewardTable `json:"tables"`
+	Error   string        `json:"error,omitempty"`
+	Code    string        `json:"code,omitempty"`
+}
+
+// RewardGrant is a reward a player earned by their final rank in a board period.
+type RewardGrant struct {
+	ID          int64      `json:"id"`
+	BoardID     string     `json:"board_id"`
+	PeriodStart time.Time  `json:"period_start"`
+	Rank        int        `json:"rank"`
+	Score       int64      `json:"score"`
+	Item        string     `json:"item"`
+	Quantity    int64      `json:"quantity"`
+	GrantedAt   time.Time  `json:"granted_at"`
+	Claimed     bool       `json:"claimed"`
+	ClaimedAt   *time.Time `json:"claimed_at,omitempty"`
+}
+
+type UserRewardsResponse struct {
+	Success bool          `json:"success"`
+	UserID  int64         `json:"user_id"`
+	Rewards []RewardGrant `json:"rewards"`
+	Error   string        `json:"error,omitempty"`
+	Code    string        `json:"code,omitempty"`
+}
+
+type ClaimRewardResponse struct {
+	Success bool         `json:"success"`
+	Data    *RewardGrant `json:"data,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Code    string       `json:"code,omitempty"`
+}
@@ -0,0 +1,474 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	leaderboardConstants "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Board is the part of a leaderboard definition reward distribution needs.
+type Board struct {
+	ID            string     `gorm:"column:id"`
+	SortOrder     string     `gorm:"column:sort_order"`
+	ResetSchedule string     `gorm:"column:reset_schedule"`
+	EndsAt        *time.Time `gorm:"column:ends_at"`
+}
+
+// Standing is a player's final position in a closed board period.
+type Standing struct {
+	UserID int64 `gorm:"column:user_id"`
+	Score  int64 `gorm:"column:total_score"`
+	Rank   int   `gorm:"column:rank"`
+}
+
+// Distribution records the grants written for one closed board period.
+type Distribution struct {
+	BoardID       string
+	PeriodStart   time.Time
+	RewardTableID int64
+	Players       int64
+	Grants        []Grant
+	DistributedAt time.Time
+}
+
+type Grant struct {
+	UserID   int64
+	Rank     int
+	Score    int64
+	Item     string
+	Quantity int64
+}
+
+type grantRow struct {
+	ID            int64      `gorm:"column:id;primaryKey;autoIncrement"`
+	GameID        string     `gorm:"column:game_id"`
+	BoardID       string     `gorm:"column:board_id"`
+	PeriodStart   time.Time  `gorm:"column:period_start"`
+	UserID        int64      `gorm:"column:user_id"`
+	RewardTableID int64      `gorm:"column:reward_table_id"`
+	Rank          int        `gorm:"column:rank"`
+	Score         int64      `gorm:"column:score"`
+	Item          string     `gorm:"column:item"`
+	Quantity      int64      `gorm:"column:quantity"`
+	GrantedAt     time.Time  `gorm:"column:granted_at"`
+	ClaimedAt     *time.Time `gorm:"column:claimed_at"`
+}
+
+func (grantRow) TableName() string {
+	return "gaming.reward_grants"
+}
+
+type rewardTableRow struct {
+	ID          int64      `gorm:"column:id"`
+	BoardID     string     `gorm:"column:board_id"`
+	PeriodStart *time.Time `gorm:"column:period_start"`
+	Bands       string     `gorm:"column:bands"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+}
+
+type IRewardRepository interface {
+	UserExists(ctx context.Context, userID int64) (bool, error)
+	GetBoard(ctx context.Context, boardID string) (*Board, error)
+	ListRewardedBoards(ctx context.Context) ([]Board, error)
+	CreateRewardTable(ctx context.Context, table *model.RewardTable) error
+	ListRewardTables(ctx context.Context, boardID string) ([]model.RewardTable, error)
+	ListUndistributedPeriods(ctx context.Context, boardID string, from, before time.Time) ([]time.Time, error)
+	HasBufferedScores(ctx context.Context, boardID string, periodStart time.Time) (bool, error)
+	CountPlayers(ctx context.Context, boardID string, periodStart time.Time) (int64, error)
+	GetFinalStandings(ctx context.Context, board *Board, periodStart time.Time, maxRank int) ([]Standing, error)
+	Distribute(ctx context.Context, distribution *Distribution) (bool, error)
+	ListUserGrants(ctx context.Context, userID int64, status string, limit int) ([]model.RewardGrant, error)
+	ClaimGrant(ctx context.Context, userID, grantID int64, at time.Time) (*model.RewardGrant, error)
+}
+
+type RewardRepository struct {
+	db     *gorm.DB
+	logger *providers.ConsoleLogger
+}
+
+func NewRewardRepository(db *gorm.DB, logger *providers.ConsoleLogger) *RewardRepository {
+	return &RewardRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *RewardRepository) UserExists(ctx context.Context, userID int64) (bool, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	if err := r.db.WithContext(ctx).Raw(
+		`SELECT EXISTS (SELECT 1 FROM gaming.users WHERE game_id = ? AND id = ?)`,
+		gameID,
+		userID,
+	).Scan(&exists).Error; err != nil {
+		return false, fmt.Errorf("failed to check user: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *RewardRepository) GetBoard(ctx context.Context, boardID string) (*Board, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var boards []Board
+	if err := r.db.WithContext(ctx).Raw(
+		`SELECT id, sort_order, reset_schedule, ends_at FROM gaming.leaderboards WHERE game_id = ? AND id = ?`,
+		gameID,
+		boardID,
+	).Scan(&boards).Error; err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", err)
+	}
+	if len(boards) == 0 {
+		return nil, errors.New(constants.ErrBoardNotFound)
+	}
+	return &boards[0], nil
+}
+
+// ListRewardedBoards returns the boards that have at least one reward table.
+func (r *RewardRepository) ListRewardedBoards(ctx context.Context) ([]Board, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var boards []Board
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT lb.id, lb.sort_order, lb.reset_schedule, lb.ends_at
+		FROM gaming.leaderboards lb
+		WHERE lb.game_id = ?
+		  AND EXISTS (
+			SELECT 1 FROM gaming.reward_tables rt
+			WHERE rt.game_id = lb.game_id AND rt.board_id = lb.id
+		  )
+		ORDER BY lb.id
+	`, gameID).Scan(&boards).Error; err != nil {
+		return nil, fmt.Errorf("failed to list rewarded boards: %w", err)
+	}
+	return boards, nil
+}
+
+// CreateRewardTable stores a reward table and fills in its id. A board has at
+// most one table per period plus one default table; creating another fails
+// with ErrRewardTableExists.
+func (r *RewardRepository) CreateRewardTable(ctx context.Context, table *model.RewardTable) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	bands, err := json.Marshal(table.Bands)
+	if err != nil {
+		return fmt.Errorf("failed to encode reward bands: %w", err)
+	}
+
+	var ids []int64
+	if err := r.db.WithContext(ctx).Raw(`
+		INSERT INTO gaming.reward_tables (game_id, board_id, period_start, bands, created_at)
+		VALUES (?, ?, ?, ?::jsonb, ?)
+		ON CONFLICT DO NOTHING
+		RETURNING id
+	`, gameID, table.BoardID, table.PeriodStart, string(bands), table.CreatedAt).Scan(&ids).Error; err != nil {
+		return fmt.Errorf("failed to create reward table: %w", err)
+	}
+	if len(ids) == 0 {
+		return errors.New(constants.ErrRewardTableExists)
+	}
+
+	table.ID = ids[0]
+	return nil
+}
+
+func (r *RewardRepository) ListRewardTables(ctx context.Context, boardID string) ([]model.RewardTable, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []rewardTableRow
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT id, board_id, period_start, bands::text AS bands, created_at
+		FROM gaming.reward_tables
+		WHERE game_id = ? AND board_id = ?
+		ORDER BY period_start NULLS FIRST, id
+	`, gameID, boardID).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list reward tables: %w", err)
+	}
+
+	tables := make([]model.RewardTable, 0, len(rows))
+	for _, row := range rows {
+		table := model.RewardTable{
+			ID:          row.ID,
+			BoardID:     row.BoardID,
+			PeriodStart: row.PeriodStart,
+			CreatedAt:   row.CreatedAt,
+		}
+		if err := json.Unmarshal([]byte(row.Bands), &table.Bands); err != nil {
+			return nil, fmt.Errorf("failed to decode reward bands of table %d: %w", row.ID, err)
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// ListUndistributedPeriods returns the periods of the board in [from, before)
+// that have scores but no distribution yet, oldest first.
+func (r *RewardRepository) ListUndistributedPeriods(ctx context.Context, boardID string, from, before time.Time) ([]time.Time, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var periods []time.Time
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT DISTINCT lb.period_start
+		FROM gaming.leaderboard lb
+		WHERE lb.game_id = ?
+		  AND lb.board_id = ?
+		  AND lb.period_start >= ?
+		  AND lb.period_start < ?
+		  AND NOT EXISTS (
+			SELECT 1 FROM gaming.reward_distributions d
+			WHERE d.game_id = lb.game_id
+			  AND d.board_id = lb.board_id
+			  AND d.period_start = lb.period_start
+		  )
+		ORDER BY lb.period_start
+	`, gameID, boardID, from, before).Scan(&periods).Error; err != nil {
+		return nil, fmt.Errorf("failed to list undistributed periods: %w", err)
+	}
+	return periods, nil
+}
+
+// HasBufferedScores reports whether scores of hot players submitted in the
+// period are still waiting in the write-behind buffer.
+func (r *RewardRepository) HasBufferedScores(ctx context.Context, boardID string, periodStart time.Time) (bool, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	if err := r.db.WithContext(ctx).Raw(
+		`SELECT EXISTS (SELECT 1 FROM gaming.buffered_scores WHERE game_id = ? AND board_id = ? AND period_start = ?)`,
+		gameID,
+		boardID,
+		periodStart,
+	).Scan(&exists).Error; err != nil {
+		return false, fmt.Errorf("failed to check buffered scores: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *RewardRepository) CountPlayers(ctx context.Context, boardID string, periodStart time.Time) (int64, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Raw(
+		`SELECT COUNT(*) FROM gaming.leaderboard WHERE game_id = ? AND board_id = ? AND period_start = ?`,
+		gameID,
+		boardID,
+		periodStart,
+	).Scan(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count players: %w", err)
+	}
+	return count, nil
+}
+
+// GetFinalStandings ranks a closed period and returns every player ranked
+// maxRank or better. Tied players share a rank, so more than maxRank rows can
+// be returned.
+func (r *RewardRepository) GetFinalStandings(ctx context.Context, board *Board, periodStart time.Time, maxRank int) ([]Standing, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	direction := "DESC"
+	if board.SortOrder == leaderboardConstants.SortAscending {
+		direction = "ASC"
+	}
+
+	var standings []Standing
+	if err := r.db.WithContext(ctx).Raw(fmt.Sprintf(`
+		SELECT user_id, total_score, rank
+		FROM (
+			SELECT user_id, total_score, RANK() OVER (ORDER BY total_score %s) AS rank
+			FROM gaming.leaderboard
+			WHERE game_id = ? AND board_id = ? AND period_start = ?
+		) ranked
+		WHERE rank <= ?
+		ORDER BY rank, user_id
+	`, direction), gameID, board.ID, periodStart, maxRank).Scan(&standings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get final standings: %w", err)
+	}
+	return standings, nil
+}
+
+// Distribute writes the grants of a closed period together with its
+// distribution record. It returns false without writing anything when the
+// period was already distributed, so it is safe to call again.
+func (r *RewardRepository) Distribute(ctx context.Context, distribution *Distribution) (bool, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	distributed := false
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`
+			INSERT INTO gaming.reward_distributions (
+				game_id, board_id, period_start, reward_table_id, players, grants, distributed_at
+			)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (game_id, board_id, period_start) DO NOTHING
+		`,
+			gameID,
+			distribution.BoardID,
+			distribution.PeriodStart,
+			distribution.RewardTableID,
+			distribution.Players,
+			len(distribution.Grants),
+			distribution.DistributedAt,
+		)
+		if result.Error != nil {
+			return fmt.Errorf("failed to record distribution: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if len(distribution.Grants) > 0 {
+			rows := make([]grantRow, 0, len(distribution.Grants))
+			for _, g := range distribution.Grants {
+				rows = append(rows, grantRow{
+					GameID:        gameID,
+					BoardID:       distribution.BoardID,
+					PeriodStart:   distribution.PeriodStart,
+					UserID:        g.UserID,
+					RewardTableID: distribution.RewardTableID,
+					Rank:          g.Rank,
+					Score:         g.Score,
+					Item:          g.Item,
+					Quantity:      g.Quantity,
+					GrantedAt:     distribution.DistributedAt,
+				})
+			}
+
+			if err := tx.
+				Clauses(clause.OnConflict{DoNothing: true}).
+				CreateInBatches(&rows, 500).Error; err != nil {
+				return fmt.Errorf("failed to write reward grants: %w", err)
+			}
+		}
+
+		distributed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return distributed, nil
+}
+
+func (r *RewardRepository) ListUserGrants(ctx context.Context, userID int64, status string, limit int) ([]model.RewardGrant, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.db.WithContext(ctx).
+		Where("game_id = ? AND user_id = ?", gameID, userID)
+
+	switch status {
+	case constants.StatusUnclaimed:
+		query = query.Where("claimed_at IS NULL")
+	case constants.StatusClaimed:
+		query = query.Where("claimed_at IS NOT NULL")
+	}
+
+	var rows []grantRow
+	if err := query.
+		Order("granted_at DESC, id DESC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list reward grants: %w", err)
+	}
+
+	grants := make([]model.RewardGrant, 0, len(rows))
+	for _, row := range rows {
+		grants = append(grants, row.toModel())
+	}
+	return grants, nil
+}
+
+// ClaimGrant marks an unclaimed grant of the user as claimed. Grants of other
+// users are reported as not found; claiming twice fails with
+// ErrRewardAlreadyClaimed.
+func (r *RewardRepository) ClaimGrant(ctx context.Context, userID, grantID int64, at time.Time) (*model.RewardGrant, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []grantRow
+	if err := r.db.WithContext(ctx).Raw(`
+		UPDATE gaming.reward_grants
+		SET claimed_at = ?
+		WHERE game_id = ? AND id = ? AND user_id = ? AND claimed_at IS NULL
+		RETURNING *
+	`, at, gameID, grantID, userID).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to claim reward: %w", err)
+	}
+	if len(rows) > 0 {
+		grant := rows[0].toModel()
+		return &grant, nil
+	}
+
+	var exists bool
+	if err := r.db.WithContext(ctx).Raw(
+		`SELECT EXISTS (SELECT 1 FROM gaming.reward_grants WHERE game_id = ? AND id = ? AND user_id = ?)`,
+		gameID,
+		grantID,
+		userID,
+	).Scan(&exists).Error; err != nil {
+		return nil, fmt.Errorf("failed to check reward: %w", err)
+	}
+	if exists {
+		return nil, errors.New(constants.ErrRewardAlreadyClaimed)
+	}
+	return nil, errors.New(constants.ErrGrantNotFound)
+}
+
+func (row grantRow) toModel() model.RewardGrant {
+	return model.RewardGrant{
+		ID:          row.ID,
+		BoardID:     row.BoardID,
+		PeriodStart: row.PeriodStart,
+		Rank:        row.Rank,
+		Score:       row.Score,
+		Item:        row.Item,
+		Quantity:    row.Quantity,
+		GrantedAt:   row.GrantedAt,
+		Claimed:     row.ClaimedAt != nil,
+		ClaimedAt:   row.ClaimedAt,
+	}
+}
@@ -0,0 +1,245 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"go.uber.org/zap"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/core"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/reward-module/model"
+)
+
+type RewardHandler struct {
+	core     *core.RewardCore
+	logger   *providers.ConsoleLogger
+	newrelic *newrelic.Application
+}
+
+func NewRewardHandler(core *core.RewardCore, logger *providers.ConsoleLogger, newrelic *newrelic.Application) *RewardHandler {
+	return &RewardHandler{
+		core:     core,
+		logger:   logger,
+		newrelic: newrelic,
+	}
+}
+
+func (h *RewardHandler) CreateRewardTable(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	ctx := r.Context()
+	boardID := mux.Vars(r)["board_id"]
+
+	var req model.CreateRewardTableRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid request payload",
+			constants.ErrInvalidRewardTable,
+		)
+		return
+	}
+
+	resp, err := h.core.CreateRewardTable(ctx, boardID, &req)
+	if err != nil {
+		h.logger.Error(
+			"CreateRewardTable failed",
+			zap.String("board_id", boardID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to create reward table",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		status := http.StatusBadRequest
+		switch resp.Code {
+		case constants.ErrBoardNotFound:
+			status = http.StatusNotFound
+		case constants.ErrRewardTableExists:
+			status = http.StatusConflict
+		}
+
+		h.respondWithJSON(w, status, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, resp)
+}
+
+func (h *RewardHandler) ListRewardTables(w http.ResponseWriter, r *http.Request) {
+	boardID := mux.Vars(r)["board_id"]
+
+	resp, err := h.core.ListRewardTables(r.Context(), boardID)
+	if err != nil {
+		h.logger.Error(
+			"ListRewardTables failed",
+			zap.String("board_id", boardID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch reward tables",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *RewardHandler) GetUserRewards(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	userID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil || userID <= 0 {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid user ID",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = constants.StatusAll
+	case constants.StatusAll, constants.StatusUnclaimed, constants.StatusClaimed:
+	default:
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"status must be all, unclaimed or claimed",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	resp, err := h.core.GetUserRewards(ctx, userID, status)
+	if err != nil {
+		h.logger.Error(
+			"GetUserRewards failed",
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch rewards",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *RewardHandler) ClaimReward(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	userID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil || userID <= 0 {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid user ID",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	grantID, err := strconv.ParseInt(vars["reward_id"], 10, 64)
+	if err != nil || grantID <= 0 {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid reward ID",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	resp, err := h.core.ClaimReward(ctx, userID, grantID)
+	if err != nil {
+		h.logger.Error(
+			"ClaimReward failed",
+			zap.Int64("user_id", userID),
+			zap.Int64("reward_id", grantID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to claim reward",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		status := http.StatusNotFound
+		if resp.Code == constants.ErrRewardAlreadyClaimed {
+			status = http.StatusConflict
+		}
+
+		h.respondWithJSON(w, status, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *RewardHandler) respondWithJSON(
+	w http.ResponseWriter,
+	status int,
+	payload interface{},
+) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func (h *RewardHandler) respondWithError(
+	w http.ResponseWriter,
+	status int,
+	message string,
+	code string,
+) {
+	h.respondWithJSON(w, status, map[string]interface{}{
+		"success": false,
+		"error":   message,
+		"code":    code,
+	})
+}
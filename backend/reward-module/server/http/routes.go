@@ -0,0 +1,24 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+func (h *RewardHandler) RegisterRoutes(router *mux.Router) {
+	// Reward table endpoints
+	_, createTableHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboards/{board_id}/rewards/create", http.HandlerFunc(h.CreateRewardTable))
+	router.Handle("/api/leaderboards/{board_id}/rewards", createTableHandler).Methods(http.MethodPost)
+
+	_, listTablesHandler := newrelic.WrapHandle(h.newrelic, "api/leaderboards/{board_id}/rewards", http.HandlerFunc(h.ListRewardTables))
+	router.Handle("/api/leaderboards/{board_id}/rewards", listTablesHandler).Methods(http.MethodGet)
+
+	// Player reward endpoints
+	_, userRewardsHandler := newrelic.WrapHandle(h.newrelic, "api/users/{id}/rewards", http.HandlerFunc(h.GetUserRewards))
+	router.Handle("/api/users/{id}/rewards", userRewardsHandler).Methods(http.MethodGet)
+
+	_, claimHandler := newrelic.WrapHandle(h.newrelic, "api/users/{id}/rewards/{reward_id}/claim", http.HandlerFunc(h.ClaimReward))
+	router.Handle("/api/users/{id}/rewards/{reward_id}/claim", claimHandler).Methods(http.MethodPost)
+}
@@ -0,0 +1,9 @@
+package constants
+
+import "time"
+
+const (
+	// GameCacheTTL bounds how long a resolved game (or API key) is trusted
+	// before it is looked up again, e.g. after a key has been rotated.
+	GameCacheTTL = time.Minute
+)
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tenant-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tenant-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tenant-module/repository"
+)
+
+type TenantCore struct {
+	repo          repository.ITenantRepository
+	defaultGameID string
+	logger        *providers.ConsoleLogger
+
+	mu    sync.RWMutex
+	games map[string]cachedGame
+}
+
+type cachedGame struct {
+	game      *model.Game
+	expiresAt time.Time
+}
+
+// NewTenantCore creates the tenant resolver. Requests that identify no game
+// fall back to defaultGameID; an empty default makes identification mandatory.
+func NewTenantCore(repo repository.ITenantRepository, defaultGameID string, logger *providers.ConsoleLogger) *TenantCore {
+	return &TenantCore{
+		repo:          repo,
+		defaultGameID: defaultGameID,
+		logger:        logger,
+		games:         make(map[string]cachedGame),
+	}
+}
+
+// Resolve works out which game a request belongs to. An API key always wins
+// and must match the X-Game-Id header when both are sent; games with an API
+// key configured cannot be selected by the header alone.
+func (c *TenantCore) Resolve(ctx context.Context, apiKey, gameID string) (string, error) {
+	if apiKey != "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		hash := hex.EncodeToString(sum[:])
+
+		game, err := c.lookup("key:"+hash, func() (*model.Game, error) {
+			return c.repo.GetGameByAPIKeyHash(ctx, hash)
+		})
+		if err != nil {
+			return "", err
+		}
+		if gameID != "" && gameID != game.ID {
+			return "", errors.New(global.ErrInvalidAPIKey)
+		}
+		return game.ID, nil
+	}
+
+	if gameID == "" {
+		if c.defaultGameID == "" {
+			return "", errors.New(global.ErrGameRequired)
+		}
+		gameID = c.defaultGameID
+	}
+
+	game, err := c.lookup("id:"+gameID, func() (*model.Game, error) {
+		return c.repo.GetGame(ctx, gameID)
+	})
+	if err != nil {
+		return "", err
+	}
+	if game.RequiresAPIKey() {
+		return "", errors.New(global.ErrInvalidAPIKey)
+	}
+	return game.ID, nil
+}
+
+// ListGameIDs lists every game, for background jobs that visit each tenant.
+func (c *TenantCore) ListGameIDs(ctx context.Context) ([]string, error) {
+	return c.repo.ListGameIDs(ctx)
+}
+
+func (c *TenantCore) lookup(key string, load func() (*model.Game, error)) (*model.Game, error) {
+	c.mu.RLock()
+	entry, ok := c.games[key]
+	c.mu.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.game, nil
+	}
+
+	game, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.games[key] = cachedGame{game: game, expiresAt: time.Now().Add(constants.GameCacheTTL)}
+	c.mu.Unlock()
+
+	return game, nil
+}
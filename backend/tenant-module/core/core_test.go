@@ -0,0 +1,127 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tenant-module/model"
+)
+
+// fakeGames is an in-memory game registry that counts its lookups.
+type fakeGames struct {
+	games   []*model.Game
+	lookups int
+}
+
+func (f *fakeGames) GetGame(ctx context.Context, gameID string) (*model.Game, error) {
+	f.lookups++
+	for _, game := range f.games {
+		if game.ID == gameID {
+			return game, nil
+		}
+	}
+	return nil, errors.New(global.ErrUnknownGame)
+}
+
+func (f *fakeGames) GetGameByAPIKeyHash(ctx context.Context, hash string) (*model.Game, error) {
+	f.lookups++
+	for _, game := range f.games {
+		if game.APIKeyHash != "" && game.APIKeyHash == hash {
+			return game, nil
+		}
+	}
+	return nil, errors.New(global.ErrInvalidAPIKey)
+}
+
+func (f *fakeGames) ListGameIDs(ctx context.Context) ([]string, error) {
+	ids := make([]string, 0, len(f.games))
+	for _, game := range f.games {
+		ids = append(ids, game.ID)
+	}
+	return ids, nil
+}
+
+func hashKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestResolve(t *testing.T) {
+	games := []*model.Game{
+		{ID: "default"},
+		{ID: "open"},
+		{ID: "keyed", APIKeyHash: hashKey("secret")},
+	}
+
+	tests := []struct {
+		name          string
+		defaultGameID string
+		apiKey        string
+		gameID        string
+		want          string
+		wantErr       string
+	}{
+		{name: "api key", apiKey: "secret", want: "keyed"},
+		{name: "api key with matching header", apiKey: "secret", gameID: "keyed", want: "keyed"},
+		{name: "api key with other header", apiKey: "secret", gameID: "open", wantErr: global.ErrInvalidAPIKey},
+		{name: "unknown api key", apiKey: "guess", wantErr: global.ErrInvalidAPIKey},
+		{name: "api key wins over default", defaultGameID: "default", apiKey: "secret", want: "keyed"},
+		{name: "header", gameID: "open", want: "open"},
+		{name: "header for game with api key", gameID: "keyed", wantErr: global.ErrInvalidAPIKey},
+		{name: "unknown header", gameID: "missing", wantErr: global.ErrUnknownGame},
+		{name: "header wins over default", defaultGameID: "default", gameID: "open", want: "open"},
+		{name: "default", defaultGameID: "default", want: "default"},
+		{name: "default with api key", defaultGameID: "keyed", wantErr: global.ErrInvalidAPIKey},
+		{name: "no game and no default", wantErr: global.ErrGameRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewTenantCore(&fakeGames{games: games}, tt.defaultGameID, nil)
+
+			got, err := c.Resolve(context.Background(), tt.apiKey, tt.gameID)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Resolve() = %q, %v, want error %s", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveCachesGames(t *testing.T) {
+	repo := &fakeGames{games: []*model.Game{{ID: "open"}, {ID: "keyed", APIKeyHash: hashKey("secret")}}}
+	c := NewTenantCore(repo, "", nil)
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Resolve(context.Background(), "", "open"); err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+		if _, err := c.Resolve(context.Background(), "secret", ""); err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+	}
+	if repo.lookups != 2 {
+		t.Errorf("lookups = %d, want 2", repo.lookups)
+	}
+
+	// Failed lookups are not cached, so a game created since is found
+	if _, err := c.Resolve(context.Background(), "", "later"); err == nil {
+		t.Fatalf("Resolve() of a missing game succeeded")
+	}
+	repo.games = append(repo.games, &model.Game{ID: "later"})
+	if got, err := c.Resolve(context.Background(), "", "later"); err != nil || got != "later" {
+		t.Errorf("Resolve() = %q, %v, want %q", got, err, "later")
+	}
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Game is a tenant of the deployment. All player data is partitioned by it.
+type Game struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	APIKeyHash string    `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// RequiresAPIKey reports whether requests for the game must authenticate with
+// its API key rather than naming it through the X-Game-Id header.
+func (g *Game) RequiresAPIKey() bool {
+	return g.APIKeyHash != ""
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tenant-module/model"
+	"gorm.io/gorm"
+)
+
+type gameRow struct {
+	ID         string    `gorm:"column:id"`
+	Name       string    `gorm:"column:name"`
+	APIKeyHash *string   `gorm:"column:api_key_hash"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+}
+
+func (gameRow) TableName() string {
+	return "gaming.games"
+}
+
+// ITenantRepository reads the game registry. It is the only repository that
+// is not scoped to a single game, since it is what resolves the game.
+type ITenantRepository interface {
+	GetGame(ctx context.Context, gameID string) (*model.Game, error)
+	GetGameByAPIKeyHash(ctx context.Context, hash string) (*model.Game, error)
+	ListGameIDs(ctx context.Context) ([]string, error)
+}
+
+type TenantRepository struct {
+	db     *gorm.DB
+	logger *providers.ConsoleLogger
+}
+
+func NewTenantRepository(db *gorm.DB, logger *providers.ConsoleLogger) *TenantRepository {
+	return &TenantRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *TenantRepository) GetGame(ctx context.Context, gameID string) (*model.Game, error) {
+	var rows []gameRow
+	if err := r.db.WithContext(ctx).
+		Where("id = ?", gameID).
+		Limit(1).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New(global.ErrUnknownGame)
+	}
+	return rows[0].toModel(), nil
+}
+
+// GetGameByAPIKeyHash looks a game up by the SHA-256 hex digest of its API key.
+func (r *TenantRepository) GetGameByAPIKeyHash(ctx context.Context, hash string) (*model.Game, error) {
+	var rows []gameRow
+	if err := r.db.WithContext(ctx).
+		Where("api_key_hash = ?", hash).
+		Limit(1).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get game by api key: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New(global.ErrInvalidAPIKey)
+	}
+	return rows[0].toModel(), nil
+}
+
+func (r *TenantRepository) ListGameIDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	if err := r.db.WithContext(ctx).
+		Model(&gameRow{}).
+		Order("id").
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to list games: %w", err)
+	}
+	return ids, nil
+}
+
+func (row gameRow) toModel() *model.Game {
+	game := &model.Game{
+		ID:        row.ID,
+		Name:      row.Name,
+		CreatedAt: row.CreatedAt,
+	}
+	if row.APIKeyHash != nil {
+		game.APIKeyHash = *row.APIKeyHash
+	}
+	return game
+}
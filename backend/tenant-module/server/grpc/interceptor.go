@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tenant-module/core"
+)
+
+// errorDomain is the domain of the ErrorInfo details calls are refused with.
+const errorDomain = "tenant"
+
+// TenantInterceptor is the gRPC counterpart of the tenant middleware: it
+// resolves the game of every call from the x-api-key or x-game-id metadata.
+type TenantInterceptor struct {
+	core   *core.TenantCore
+	logger *providers.ConsoleLogger
+}
+
+func NewTenantInterceptor(core *core.TenantCore, logger *providers.ConsoleLogger) *TenantInterceptor {
+	return &TenantInterceptor{
+		core:   core,
+		logger: logger,
+	}
+}
+
+// Unary scopes the context of unary calls to their game.
+func (i *TenantInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		gameCtx, err := i.resolve(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(gameCtx, req)
+	}
+}
+
+// Stream scopes the context of streaming calls to their game.
+func (i *TenantInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		gameCtx, err := i.resolve(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &tenantStream{ServerStream: stream, ctx: gameCtx})
+	}
+}
+
+// resolve returns ctx scoped to the game of the call, or the status the
+// call is refused with.
+func (i *TenantInterceptor) resolve(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	gameID, err := i.core.Resolve(ctx, firstValue(md, global.APIKeyHeader), firstValue(md, global.GameIDHeader))
+	if err != nil {
+		switch err.Error() {
+		case global.ErrInvalidAPIKey:
+			return nil, statusError(codes.Unauthenticated, "Invalid or missing API key for this game", global.ErrInvalidAPIKey)
+		case global.ErrUnknownGame:
+			return nil, statusError(codes.NotFound, "Unknown game", global.ErrUnknownGame)
+		case global.ErrGameRequired:
+			return nil, statusError(codes.InvalidArgument, "An API key or x-game-id metadata is required", global.ErrGameRequired)
+		default:
+			i.logger.Error("Tenant resolution failed", zap.String("method", method), zap.Error(err))
+			return nil, statusError(codes.Internal, "Internal server error", global.ErrInternalServer)
+		}
+	}
+	return global.WithGameID(ctx, gameID), nil
+}
+
+// tenantStream hands the game scoped context to stream handlers.
+type tenantStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tenantStream) Context() context.Context {
+	return s.ctx
+}
+
+// firstValue reads a header from the metadata; keys are matched case-insensitively.
+func firstValue(md metadata.MD, header string) string {
+	if values := md.Get(header); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func statusError(c codes.Code, message string, code string) error {
+	st := status.New(c, message)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: code, Domain: errorDomain}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
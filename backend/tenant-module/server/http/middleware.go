@@ -0,0 +1,75 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"go.uber.org/zap"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tenant-module/core"
+)
+
+// Paths served without a tenant, e.g. for load balancer health checks.
+var publicPaths = map[string]bool{
+	"/health": true,
+}
+
+type TenantHandler struct {
+	core   *core.TenantCore
+	logger *providers.ConsoleLogger
+}
+
+func NewTenantHandler(core *core.TenantCore, logger *providers.ConsoleLogger) *TenantHandler {
+	return &TenantHandler{
+		core:   core,
+		logger: logger,
+	}
+}
+
+// Middleware resolves the game of every request from the X-Api-Key or
+// X-Game-Id header and scopes the request context to it. Requests that
+// cannot be attributed to a game never reach a handler.
+func (h *TenantHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if publicPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		gameID, err := h.core.Resolve(ctx, r.Header.Get(global.APIKeyHeader), r.Header.Get(global.GameIDHeader))
+		if err != nil {
+			switch err.Error() {
+			case global.ErrInvalidAPIKey:
+				h.respondWithError(w, http.StatusUnauthorized, "Invalid or missing API key for this game", global.ErrInvalidAPIKey)
+			case global.ErrUnknownGame:
+				h.respondWithError(w, http.StatusNotFound, "Unknown game", global.ErrUnknownGame)
+			case global.ErrGameRequired:
+				h.respondWithError(w, http.StatusBadRequest, "An API key or X-Game-Id header is required", global.ErrGameRequired)
+			default:
+				h.logger.Error("Tenant resolution failed", zap.String("path", r.URL.Path), zap.Error(err))
+				h.respondWithError(w, http.StatusInternalServerError, "Internal server error", global.ErrInternalServer)
+			}
+			return
+		}
+
+		if txn := newrelic.FromContext(ctx); txn != nil {
+			txn.AddAttribute("game_id", gameID)
+		}
+
+		next.ServeHTTP(w, r.WithContext(global.WithGameID(ctx, gameID)))
+	})
+}
+
+func (h *TenantHandler) respondWithError(w http.ResponseWriter, status int, message string, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   message,
+		"code":    code,
+	})
+}
@@ -0,0 +1,60 @@
+package constants
+
+import "time"
+
+// Tournament lifecycle states.
+const (
+	StatusScheduled  = "scheduled"
+	StatusOpen       = "open"
+	StatusRunning    = "running"
+	StatusFinalizing = "finalizing"
+	StatusClosed     = "closed"
+)
+
+const (
+	// BoardOwner marks the boards tournaments score on.
+	BoardOwner = "tournament"
+
+	// BoardIDPrefix is prepended to a tournament id to name its board.
+	BoardIDPrefix = "tournament-"
+
+	// FinalizeGrace lets submits accepted just before a tournament ended land
+	// before its final standings are written.
+	FinalizeGrace = time.Minute
+
+	// MaxTournamentsPerPage caps GET /api/tournaments.
+	MaxTournamentsPerPage = 100
+)
+
+// Bracket formats.
+const (
+	FormatSingleElimination = "single"
+	FormatDoubleElimination = "double"
+)
+
+// Bracket sides. The grand final of a double-elimination bracket is played
+// between the winners and losers bracket champions, and replayed once if the
+// losers bracket champion wins it.
+const (
+	SideWinners    = "winners"
+	SideLosers     = "losers"
+	SideGrandFinal = "grand_final"
+)
+
+// Match states. A match is pending until both of its players are known and
+// then ready to be played. A match with a single player is a bye that player
+// wins; one left without players is void. The grand final replay is cancelled
+// when it is not needed.
+const (
+	MatchPending   = "pending"
+	MatchReady     = "ready"
+	MatchCompleted = "completed"
+	MatchBye       = "bye"
+	MatchVoid      = "void"
+	MatchCancelled = "cancelled"
+)
+
+const (
+	MinBracketPlayers = 2
+	MaxBracketSize    = 256
+)
@@ -0,0 +1,23 @@
+package constants
+
+const (
+	ErrTournamentNotFound  = "TOURNAMENT_NOT_FOUND"
+	ErrTournamentExists    = "TOURNAMENT_ALREADY_EXISTS"
+	ErrInvalidTournament   = "INVALID_TOURNAMENT"
+	ErrUserNotFound        = "USER_NOT_FOUND"
+	ErrRegistrationClosed  = "REGISTRATION_CLOSED"
+	ErrTournamentFull      = "TOURNAMENT_FULL"
+	ErrAlreadyRegistered   = "ALREADY_REGISTERED"
+	ErrNotRegistered       = "NOT_REGISTERED"
+	ErrTournamentNotActive = "TOURNAMENT_NOT_RUNNING"
+	ErrAttemptsExhausted   = "ATTEMPTS_EXHAUSTED"
+
+	ErrBracketNotFound = "BRACKET_NOT_FOUND"
+	ErrBracketExists   = "BRACKET_ALREADY_EXISTS"
+	ErrInvalidBracket  = "INVALID_BRACKET"
+	ErrMatchNotFound   = "MATCH_NOT_FOUND"
+	ErrMatchNotReady   = "MATCH_NOT_READY"
+	ErrInvalidResult   = "INVALID_RESULT"
+	ErrInternalServer  = "INTERNAL_SERVER_ERROR"
+	ErrInvalidRequest  = "INVALID_REQUEST"
+)
@@ -0,0 +1,306 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	leaderboardConstants "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	leaderboardCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/core"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/repository"
+)
+
+const defaultRoundInterval = time.Hour
+
+// CreateBracket seeds the tournament's entrants by their current leaderboard
+// rank and generates a single or double elimination bracket for them.
+func (c *TournamentCore) CreateBracket(ctx context.Context, tournamentID string, req *model.CreateBracketRequest) (*model.BracketResponse, error) {
+	tournament, resp, err := c.resolveTournament(ctx, tournamentID)
+	if tournament == nil {
+		if resp == nil {
+			return nil, err
+		}
+		return &model.BracketResponse{
+			Success: false,
+			Error:   resp.Error,
+			Code:    resp.Code,
+		}, nil
+	}
+
+	invalid := func(message string) (*model.BracketResponse, error) {
+		return &model.BracketResponse{
+			Success: false,
+			Error:   message,
+			Code:    constants.ErrInvalidBracket,
+		}, nil
+	}
+
+	format := req.Format
+	if format == "" {
+		format = constants.FormatSingleElimination
+	}
+	if format != constants.FormatSingleElimination && format != constants.FormatDoubleElimination {
+		return invalid("format must be single or double")
+	}
+
+	maxPlayers := req.MaxPlayers
+	if maxPlayers == 0 {
+		maxPlayers = constants.MaxBracketSize
+	}
+	if maxPlayers < constants.MinBracketPlayers || maxPlayers > constants.MaxBracketSize {
+		return invalid("max_players must be between 2 and 256")
+	}
+
+	interval := time.Duration(req.RoundIntervalMinutes) * time.Minute
+	if req.RoundIntervalMinutes < 0 {
+		return invalid("round_interval_minutes cannot be negative")
+	}
+	if interval == 0 {
+		interval = defaultRoundInterval
+	}
+
+	seedBoardID := req.SeedBoardID
+	if seedBoardID == "" {
+		seedBoardID = leaderboardConstants.DefaultBoardID
+	}
+	boardResp, err := c.leaderboards.GetBoard(ctx, seedBoardID)
+	if err != nil {
+		return nil, err
+	}
+	if !boardResp.Success {
+		return invalid("Seed leaderboard not found")
+	}
+	seedBoard := boardResp.Data
+
+	now := time.Now().UTC()
+	players, err := c.repo.ListSeeds(
+		ctx,
+		tournament.ID,
+		seedBoard.ID,
+		seedBoard.SortOrder,
+		leaderboardCore.PeriodStart(seedBoard.ResetSchedule, now),
+		maxPlayers,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(players) < constants.MinBracketPlayers {
+		return invalid("A bracket needs at least two registered entrants")
+	}
+
+	matches := generateBracket(format, players)
+	if req.StartsAt != nil {
+		scheduleBracket(matches, req.StartsAt.UTC(), interval)
+	}
+
+	bracket := &model.Bracket{
+		TournamentID: tournament.ID,
+		Format:       format,
+		SeedBoardID:  seedBoard.ID,
+		Size:         bracketSize(len(players)),
+		Participants: len(players),
+		CreatedAt:    now,
+	}
+
+	if err := c.repo.CreateBracket(ctx, bracket, matches); err != nil {
+		if err.Error() == constants.ErrBracketExists {
+			return &model.BracketResponse{
+				Success: false,
+				Error:   "This tournament already has a bracket",
+				Code:    constants.ErrBracketExists,
+			}, nil
+		}
+		return nil, err
+	}
+
+	c.logger.Infof("Bracket created | tournament_id=%s format=%s players=%d matches=%d", tournament.ID, format, len(players), len(matches))
+
+	bracket.Rounds = groupRounds(matches)
+	return &model.BracketResponse{
+		Success: true,
+		Data:    bracket,
+	}, nil
+}
+
+func (c *TournamentCore) GetBracket(ctx context.Context, tournamentID string) (*model.BracketResponse, error) {
+	bracket, matches, err := c.repo.GetBracket(ctx, tournamentID)
+	if err != nil {
+		if err.Error() == constants.ErrBracketNotFound {
+			return &model.BracketResponse{
+				Success: false,
+				Error:   "Bracket not found",
+				Code:    constants.ErrBracketNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+
+	bracket.Rounds = groupRounds(matches)
+	return &model.BracketResponse{
+		Success: true,
+		Data:    bracket,
+	}, nil
+}
+
+// ReportResult records the outcome of a ready match, advances the winner and,
+// in a double elimination bracket, drops the loser into the losers bracket.
+func (c *TournamentCore) ReportResult(ctx context.Context, tournamentID string, matchID int, req *model.ReportResultRequest) (*model.MatchResponse, error) {
+	tournament, resp, err := c.resolveTournament(ctx, tournamentID)
+	if tournament == nil {
+		if resp == nil {
+			return nil, err
+		}
+		return &model.MatchResponse{
+			Success: false,
+			Error:   resp.Error,
+			Code:    resp.Code,
+		}, nil
+	}
+
+	var sessions [2]*repository.GameSession
+	for i, sessionID := range []*int64{req.Player1SessionID, req.Player2SessionID} {
+		if sessionID == nil {
+			continue
+		}
+		sessions[i], err = c.repo.GetGameSession(ctx, *sessionID)
+		if err != nil {
+			if err.Error() == constants.ErrInvalidResult {
+				return &model.MatchResponse{
+					Success: false,
+					Error:   "Game session not found",
+					Code:    constants.ErrInvalidResult,
+				}, nil
+			}
+			return nil, err
+		}
+	}
+
+	lowerIsBetter := false
+	if board, err := c.leaderboards.GetBoard(ctx, tournament.BoardID); err != nil {
+		return nil, err
+	} else if board.Success {
+		lowerIsBetter = board.Data.SortOrder == leaderboardConstants.SortAscending
+	}
+
+	now := time.Now().UTC()
+	var reported model.Match
+	_, _, err = c.repo.UpdateBracket(ctx, tournament.ID, func(bracket *model.Bracket, matches []model.Match) ([]int, error) {
+		if matchID < 1 || matchID > len(matches) {
+			return nil, errors.New(constants.ErrMatchNotFound)
+		}
+		m := &matches[matchID-1]
+		if m.Status != constants.MatchReady {
+			return nil, errors.New(constants.ErrMatchNotReady)
+		}
+
+		scores := [2]*int64{req.Player1Score, req.Player2Score}
+		players := [2]*model.MatchPlayer{m.Player1, m.Player2}
+		for i, session := range sessions {
+			if session == nil {
+				continue
+			}
+			if session.UserID != players[i].UserID {
+				return nil, errors.New(constants.ErrInvalidResult)
+			}
+			score := session.Score
+			scores[i] = &score
+		}
+
+		winnerID := req.WinnerID
+		if winnerID == 0 {
+			if scores[0] == nil || scores[1] == nil || *scores[0] == *scores[1] {
+				return nil, errors.New(constants.ErrInvalidResult)
+			}
+			first := *scores[0] > *scores[1]
+			if lowerIsBetter {
+				first = !first
+			}
+			winnerID = players[1].UserID
+			if first {
+				winnerID = players[0].UserID
+			}
+		}
+
+		changed, err := completeMatch(matches, matchID, winnerID, scores, [2]*int64{req.Player1SessionID, req.Player2SessionID}, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if winner := champion(matches); winner != nil && bracket.ChampionID == nil {
+			bracket.ChampionID = winner
+			bracket.CompletedAt = &now
+			c.logger.Infof("Bracket completed | tournament_id=%s champion_id=%d", tournament.ID, *winner)
+		}
+
+		reported = *m
+		return changed, nil
+	})
+	if err != nil {
+		if message := bracketErrorMessage(err); message != "" {
+			return &model.MatchResponse{
+				Success: false,
+				Error:   message,
+				Code:    err.Error(),
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &model.MatchResponse{
+		Success: true,
+		Data:    &reported,
+	}, nil
+}
+
+// ScheduleMatch sets when a match that has not been played yet takes place.
+func (c *TournamentCore) ScheduleMatch(ctx context.Context, tournamentID string, matchID int, at time.Time) (*model.MatchResponse, error) {
+	at = at.UTC()
+
+	var scheduled model.Match
+	_, _, err := c.repo.UpdateBracket(ctx, tournamentID, func(_ *model.Bracket, matches []model.Match) ([]int, error) {
+		if matchID < 1 || matchID > len(matches) {
+			return nil, errors.New(constants.ErrMatchNotFound)
+		}
+		m := &matches[matchID-1]
+		if m.Status != constants.MatchPending && m.Status != constants.MatchReady {
+			return nil, errors.New(constants.ErrMatchNotReady)
+		}
+
+		m.ScheduledAt = &at
+		scheduled = *m
+		return []int{m.ID}, nil
+	})
+	if err != nil {
+		if message := bracketErrorMessage(err); message != "" {
+			return &model.MatchResponse{
+				Success: false,
+				Error:   message,
+				Code:    err.Error(),
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &model.MatchResponse{
+		Success: true,
+		Data:    &scheduled,
+	}, nil
+}
+
+// bracketErrorMessage returns a human readable message for a bracket
+// business error, or "" for internal failures.
+func bracketErrorMessage(err error) string {
+	switch err.Error() {
+	case constants.ErrBracketNotFound:
+		return "Bracket not found"
+	case constants.ErrMatchNotFound:
+		return "Match not found"
+	case constants.ErrMatchNotReady:
+		return "Match is not waiting to be played"
+	case constants.ErrInvalidResult:
+		return "Result must name one of the match's players as winner, or give scores or game sessions of its players that decide it"
+	}
+	return ""
+}
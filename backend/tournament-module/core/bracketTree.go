@@ -0,0 +1,376 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/model"
+)
+
+// The bracket is kept as a flat list of matches numbered from 1, with every
+// match pointing at the matches its winner and loser move on to. Generating,
+// resolving byes and advancing winners only work on that list, so the same
+// code runs when a bracket is created and whenever a result comes in.
+
+// bracketSize returns the smallest power of two that fits the field.
+func bracketSize(players int) int {
+	size := 2
+	for size < players {
+		size *= 2
+	}
+	return size
+}
+
+// seedOrder returns the seed placed at every first round slot, so that the
+// top seeds meet as late as possible: 1 v 8, 4 v 5, 2 v 7, 3 v 6 for eight.
+func seedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, len(order)*2)
+		total := len(order)*2 + 1
+		for _, seed := range order {
+			next = append(next, seed, total-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// generateBracket lays out every match of the bracket for the seeded players,
+// best seed first, and resolves the byes of the first round.
+func generateBracket(format string, players []int64) []model.Match {
+	size := bracketSize(len(players))
+	rounds := 0
+	for s := size; s > 1; s /= 2 {
+		rounds++
+	}
+
+	var matches []model.Match
+	add := func(side string, round, position int) int {
+		matches = append(matches, model.Match{
+			ID:       len(matches) + 1,
+			Side:     side,
+			Round:    round,
+			Position: position,
+			Status:   constants.MatchPending,
+		})
+		return len(matches)
+	}
+	link := func(from, to, slot int) {
+		matches[from-1].NextMatchID = &to
+		matches[from-1].NextSlot = slot
+	}
+	linkLoser := func(from, to, slot int) {
+		matches[from-1].LoserMatchID = &to
+		matches[from-1].LoserSlot = slot
+	}
+
+	// Winners bracket
+	winners := make([][]int, rounds+1)
+	for r := 1; r <= rounds; r++ {
+		for p := 0; p < size>>r; p++ {
+			winners[r] = append(winners[r], add(constants.SideWinners, r, p+1))
+		}
+	}
+	for r := 1; r < rounds; r++ {
+		for p, id := range winners[r] {
+			link(id, winners[r+1][p/2], p%2+1)
+		}
+	}
+
+	order := seedOrder(size)
+	for p, id := range winners[1] {
+		matches[id-1].Player1 = seededPlayer(players, order[2*p])
+		matches[id-1].Player2 = seededPlayer(players, order[2*p+1])
+	}
+
+	if format == constants.FormatDoubleElimination {
+		// Losers bracket: odd rounds pair up survivors, even rounds take in
+		// the losers of the next winners round. Both rounds of a pair have
+		// size/2^(j+1) matches.
+		loserRounds := 2*rounds - 2
+		losers := make([][]int, loserRounds+1)
+		for k := 1; k <= loserRounds; k++ {
+			j := (k + 1) / 2
+			for p := 0; p < size>>(j+1); p++ {
+				losers[k] = append(losers[k], add(constants.SideLosers, k, p+1))
+			}
+		}
+
+		final := add(constants.SideGrandFinal, 1, 1)
+		add(constants.SideGrandFinal, 2, 1)
+
+		link(winners[rounds][0], final, 1)
+
+		if loserRounds == 0 {
+			linkLoser(winners[1][0], final, 2)
+		} else {
+			for p, id := range winners[1] {
+				linkLoser(id, losers[1][p/2], p%2+1)
+			}
+			// Losers from later rounds enter in reverse order to avoid
+			// immediate rematches
+			for r := 2; r <= rounds; r++ {
+				count := len(winners[r])
+				for p, id := range winners[r] {
+					linkLoser(id, losers[2*(r-1)][count-1-p], 2)
+				}
+			}
+			for k := 1; k <= loserRounds; k++ {
+				for p, id := range losers[k] {
+					switch {
+					case k == loserRounds:
+						link(id, final, 2)
+					case k%2 == 1:
+						link(id, losers[k+1][p], 1)
+					default:
+						link(id, losers[k+1][p/2], p%2+1)
+					}
+				}
+			}
+		}
+	}
+
+	settleBracket(matches)
+	return matches
+}
+
+func seededPlayer(players []int64, seed int) *model.MatchPlayer {
+	if seed > len(players) {
+		return nil
+	}
+	return &model.MatchPlayer{UserID: players[seed-1], Seed: seed}
+}
+
+func decided(match *model.Match) bool {
+	switch match.Status {
+	case constants.MatchCompleted, constants.MatchBye, constants.MatchVoid, constants.MatchCancelled:
+		return true
+	}
+	return false
+}
+
+func isGrandFinalReplay(match *model.Match) bool {
+	return match.Side == constants.SideGrandFinal && match.Round == 2
+}
+
+// feeders returns, per match and slot, the match feeding a player into it.
+func feeders(matches []model.Match) map[int][3]int {
+	fed := make(map[int][3]int, len(matches))
+	for _, m := range matches {
+		if m.NextMatchID != nil {
+			slots := fed[*m.NextMatchID]
+			slots[m.NextSlot] = m.ID
+			fed[*m.NextMatchID] = slots
+		}
+		if m.LoserMatchID != nil {
+			slots := fed[*m.LoserMatchID]
+			slots[m.LoserSlot] = m.ID
+			fed[*m.LoserMatchID] = slots
+		}
+	}
+	return fed
+}
+
+// settleBracket moves the bracket forward as far as it goes without new
+// results: matches whose players are known become ready, byes and empty
+// matches are decided and their winners advanced. It returns the ids of the
+// matches it changed.
+func settleBracket(matches []model.Match) []int {
+	fed := feeders(matches)
+	changed := make(map[int]bool)
+
+	for progress := true; progress; {
+		progress = false
+		for i := range matches {
+			m := &matches[i]
+			if m.Status != constants.MatchPending || isGrandFinalReplay(m) {
+				continue
+			}
+
+			slots := fed[m.ID]
+			if (slots[1] != 0 && !decided(&matches[slots[1]-1])) ||
+				(slots[2] != 0 && !decided(&matches[slots[2]-1])) {
+				continue
+			}
+
+			switch {
+			case m.Player1 != nil && m.Player2 != nil:
+				m.Status = constants.MatchReady
+			case m.Player1 != nil || m.Player2 != nil:
+				winner := m.Player1
+				if winner == nil {
+					winner = m.Player2
+				}
+				m.Status = constants.MatchBye
+				m.WinnerID = &winner.UserID
+				m.ScheduledAt = nil
+				advance(matches, m, changed)
+			default:
+				m.Status = constants.MatchVoid
+				m.ScheduledAt = nil
+			}
+			changed[m.ID] = true
+			progress = true
+		}
+	}
+
+	ids := make([]int, 0, len(changed))
+	for i := range matches {
+		if changed[matches[i].ID] {
+			ids = append(ids, matches[i].ID)
+		}
+	}
+	return ids
+}
+
+// advance moves the winner and, for played matches, the loser of a decided
+// match on. The grand final is replayed only when the losers bracket
+// champion wins it.
+func advance(matches []model.Match, m *model.Match, changed map[int]bool) {
+	winner, loser := m.Player1, m.Player2
+	if winner == nil || (m.WinnerID != nil && winner.UserID != *m.WinnerID) {
+		winner, loser = loser, winner
+	}
+
+	if m.Side == constants.SideGrandFinal && m.Round == 1 {
+		replay := &matches[m.ID]
+		if winner == m.Player1 || loser == nil {
+			replay.Status = constants.MatchCancelled
+			replay.ScheduledAt = nil
+		} else {
+			place(replay, 1, m.Player1)
+			place(replay, 2, m.Player2)
+			replay.Status = constants.MatchReady
+		}
+		changed[replay.ID] = true
+		return
+	}
+
+	if winner != nil && m.NextMatchID != nil {
+		place(&matches[*m.NextMatchID-1], m.NextSlot, winner)
+		changed[*m.NextMatchID] = true
+	}
+	if m.Status == constants.MatchCompleted && loser != nil && m.LoserMatchID != nil {
+		place(&matches[*m.LoserMatchID-1], m.LoserSlot, loser)
+		changed[*m.LoserMatchID] = true
+	}
+}
+
+func place(match *model.Match, slot int, player *model.MatchPlayer) {
+	entrant := &model.MatchPlayer{UserID: player.UserID, Seed: player.Seed}
+	if slot == 1 {
+		match.Player1 = entrant
+	} else {
+		match.Player2 = entrant
+	}
+}
+
+// completeMatch records the result of a ready match and advances the
+// bracket. It returns the ids of every match that changed.
+func completeMatch(matches []model.Match, matchID int, winnerID int64, scores [2]*int64, sessions [2]*int64, now time.Time) ([]int, error) {
+	m := &matches[matchID-1]
+	if m.Status != constants.MatchReady {
+		return nil, errors.New(constants.ErrMatchNotReady)
+	}
+	if winnerID != m.Player1.UserID && winnerID != m.Player2.UserID {
+		return nil, errors.New(constants.ErrInvalidResult)
+	}
+
+	m.Player1.Score, m.Player2.Score = scores[0], scores[1]
+	m.Player1.SessionID, m.Player2.SessionID = sessions[0], sessions[1]
+	m.WinnerID = &winnerID
+	m.Status = constants.MatchCompleted
+	m.CompletedAt = &now
+
+	changed := map[int]bool{m.ID: true}
+	advance(matches, m, changed)
+
+	for _, id := range settleBracket(matches) {
+		changed[id] = true
+	}
+
+	ids := make([]int, 0, len(changed))
+	for i := range matches {
+		if changed[matches[i].ID] {
+			ids = append(ids, matches[i].ID)
+		}
+	}
+	return ids, nil
+}
+
+// champion returns the winner of the bracket once its last match is decided.
+func champion(matches []model.Match) *int64 {
+	last := &matches[len(matches)-1]
+	if !isGrandFinalReplay(last) {
+		// Single elimination ends with the winners bracket final
+		if decided(last) {
+			return last.WinnerID
+		}
+		return nil
+	}
+
+	switch last.Status {
+	case constants.MatchCompleted:
+		return last.WinnerID
+	case constants.MatchCancelled:
+		return matches[len(matches)-2].WinnerID
+	}
+	return nil
+}
+
+// scheduleBracket schedules every match by the number of matches that have
+// to be played before it, one interval per step.
+func scheduleBracket(matches []model.Match, start time.Time, interval time.Duration) {
+	fed := feeders(matches)
+	stages := make(map[int]int, len(matches))
+
+	var stage func(id int) int
+	stage = func(id int) int {
+		if s, ok := stages[id]; ok {
+			return s
+		}
+		s := 0
+		m := &matches[id-1]
+		if isGrandFinalReplay(m) {
+			s = stage(id-1) + 1
+		}
+		for _, from := range fed[id] {
+			if from != 0 && stage(from)+1 > s {
+				s = stage(from) + 1
+			}
+		}
+		stages[id] = s
+		return s
+	}
+
+	for i := range matches {
+		m := &matches[i]
+		if m.Status == constants.MatchBye || m.Status == constants.MatchVoid {
+			continue
+		}
+		at := start.Add(time.Duration(stage(m.ID)) * interval)
+		m.ScheduledAt = &at
+	}
+}
+
+// groupRounds arranges matches into rounds, winners bracket first.
+func groupRounds(matches []model.Match) []model.Round {
+	sides := []string{constants.SideWinners, constants.SideLosers, constants.SideGrandFinal}
+
+	rounds := make([]model.Round, 0)
+	for _, side := range sides {
+		for _, m := range matches {
+			if m.Side != side {
+				continue
+			}
+			if n := len(rounds); n == 0 || rounds[n-1].Side != side || rounds[n-1].Round != m.Round {
+				rounds = append(rounds, model.Round{Side: side, Round: m.Round})
+			}
+			last := &rounds[len(rounds)-1]
+			last.Matches = append(last.Matches, m)
+		}
+	}
+	return rounds
+}
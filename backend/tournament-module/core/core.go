@@ -0,0 +1,376 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	leaderboardConstants "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	leaderboardCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/core"
+	leaderboardModel "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/repository"
+)
+
+// Tournament ids leave room for the board id prefix within 64 characters.
+var tournamentIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,51}$`)
+
+type ITournamentCore interface {
+	CreateTournament(ctx context.Context, req *model.CreateTournamentRequest) (*model.TournamentResponse, error)
+	GetTournament(ctx context.Context, tournamentID string) (*model.TournamentResponse, error)
+	ListTournaments(ctx context.Context, status string) (*model.TournamentsResponse, error)
+	Register(ctx context.Context, tournamentID string, userID int64) (*model.EntryResponse, error)
+	GetTopPlayers(ctx context.Context, tournamentID string, limit int) (*model.TournamentTopResponse, error)
+	GetPlayerRank(ctx context.Context, tournamentID string, userID int64) (*model.TournamentRankResponse, error)
+	CreateBracket(ctx context.Context, tournamentID string, req *model.CreateBracketRequest) (*model.BracketResponse, error)
+	GetBracket(ctx context.Context, tournamentID string) (*model.BracketResponse, error)
+	ReportResult(ctx context.Context, tournamentID string, matchID int, req *model.ReportResultRequest) (*model.MatchResponse, error)
+	ScheduleMatch(ctx context.Context, tournamentID string, matchID int, at time.Time) (*model.MatchResponse, error)
+}
+
+type TournamentCore struct {
+	repo         *repository.TournamentRepository
+	leaderboards *leaderboardCore.LeaderboardCore
+	logger       *providers.ConsoleLogger
+}
+
+func NewTournamentCore(
+	repo *repository.TournamentRepository,
+	leaderboards *leaderboardCore.LeaderboardCore,
+	logger *providers.ConsoleLogger,
+) *TournamentCore {
+	return &TournamentCore{
+		repo:         repo,
+		leaderboards: leaderboards,
+		logger:       logger,
+	}
+}
+
+// statusAt derives a tournament's lifecycle state from its schedule.
+func statusAt(t *model.Tournament, now time.Time) string {
+	switch {
+	case t.FinalizedAt != nil:
+		return constants.StatusClosed
+	case !now.Before(t.EndsAt):
+		return constants.StatusFinalizing
+	case !now.Before(t.StartsAt):
+		return constants.StatusRunning
+	case !now.Before(t.RegistrationOpensAt):
+		return constants.StatusOpen
+	default:
+		return constants.StatusScheduled
+	}
+}
+
+// CreateTournament creates a tournament together with the board it scores
+// on. Tournament boards never reset and keep the best attempt of every player
+// unless another aggregation is requested.
+func (c *TournamentCore) CreateTournament(ctx context.Context, req *model.CreateTournamentRequest) (*model.TournamentResponse, error) {
+	now := time.Now().UTC()
+
+	tournament := &model.Tournament{
+		ID:          strings.TrimSpace(req.ID),
+		Name:        strings.TrimSpace(req.Name),
+		StartsAt:    req.StartsAt.UTC(),
+		EndsAt:      req.EndsAt.UTC(),
+		MaxAttempts: req.MaxAttempts,
+		MaxEntrants: req.MaxEntrants,
+		CreatedAt:   now,
+	}
+	tournament.BoardID = constants.BoardIDPrefix + tournament.ID
+
+	tournament.RegistrationOpensAt = now
+	if req.RegistrationOpensAt != nil {
+		tournament.RegistrationOpensAt = req.RegistrationOpensAt.UTC()
+	}
+	if tournament.RegistrationOpensAt.After(tournament.StartsAt) {
+		tournament.RegistrationOpensAt = tournament.StartsAt
+	}
+
+	if message := validateTournament(tournament, now); message != "" {
+		return &model.TournamentResponse{
+			Success: false,
+			Error:   message,
+			Code:    constants.ErrInvalidTournament,
+		}, nil
+	}
+
+	if _, err := c.repo.GetTournament(ctx, tournament.ID); err == nil {
+		return &model.TournamentResponse{
+			Success: false,
+			Error:   "A tournament with this id already exists",
+			Code:    constants.ErrTournamentExists,
+		}, nil
+	} else if err.Error() != constants.ErrTournamentNotFound {
+		return nil, err
+	}
+
+	aggregation := req.Aggregation
+	if aggregation == "" {
+		aggregation = leaderboardConstants.AggregationBest
+	}
+
+	boardResp, err := c.leaderboards.CreateManagedBoard(ctx, &leaderboardModel.CreateBoardRequest{
+		ID:            tournament.BoardID,
+		Name:          tournament.Name,
+		Aggregation:   aggregation,
+		SortOrder:     req.SortOrder,
+		ScoreUnit:     req.ScoreUnit,
+		ResetSchedule: leaderboardConstants.ResetNever,
+		GameModes:     req.GameModes,
+		EndsAt:        &tournament.EndsAt,
+	}, constants.BoardOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !boardResp.Success {
+		if boardResp.Code == leaderboardConstants.ErrBoardExists {
+			return &model.TournamentResponse{
+				Success: false,
+				Error:   "A leaderboard named after this tournament already exists",
+				Code:    constants.ErrTournamentExists,
+			}, nil
+		}
+		return &model.TournamentResponse{
+			Success: false,
+			Error:   boardResp.Error,
+			Code:    constants.ErrInvalidTournament,
+		}, nil
+	}
+
+	if err := c.repo.CreateTournament(ctx, tournament); err != nil {
+		if err.Error() == constants.ErrTournamentExists {
+			return &model.TournamentResponse{
+				Success: false,
+				Error:   "A tournament with this id already exists",
+				Code:    constants.ErrTournamentExists,
+			}, nil
+		}
+		return nil, err
+	}
+
+	tournament.Status = statusAt(tournament, now)
+	return &model.TournamentResponse{
+		Success: true,
+		Data:    tournament,
+	}, nil
+}
+
+func (c *TournamentCore) GetTournament(ctx context.Context, tournamentID string) (*model.TournamentResponse, error) {
+	tournament, resp, err := c.resolveTournament(ctx, tournamentID)
+	if tournament == nil {
+		return resp, err
+	}
+
+	return &model.TournamentResponse{
+		Success: true,
+		Data:    tournament,
+	}, nil
+}
+
+// ListTournaments returns recent tournaments, optionally only those in the
+// given lifecycle state.
+func (c *TournamentCore) ListTournaments(ctx context.Context, status string) (*model.TournamentsResponse, error) {
+	all, err := c.repo.ListTournaments(ctx, constants.MaxTournamentsPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	tournaments := make([]model.Tournament, 0, len(all))
+	for _, tournament := range all {
+		tournament.Status = statusAt(&tournament, now)
+		if status == "" || tournament.Status == status {
+			tournaments = append(tournaments, tournament)
+		}
+	}
+
+	return &model.TournamentsResponse{
+		Success:     true,
+		Tournaments: tournaments,
+	}, nil
+}
+
+func (c *TournamentCore) Register(ctx context.Context, tournamentID string, userID int64) (*model.EntryResponse, error) {
+	if userID <= 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	exists, err := c.repo.UserExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &model.EntryResponse{
+			Success: false,
+			Error:   "User not found",
+			Code:    constants.ErrUserNotFound,
+		}, nil
+	}
+
+	entry, err := c.repo.Register(ctx, tournamentID, userID, time.Now().UTC())
+	if err != nil {
+		message := ""
+		switch err.Error() {
+		case constants.ErrTournamentNotFound:
+			message = "Tournament not found"
+		case constants.ErrRegistrationClosed:
+			message = "Registration for this tournament is not open"
+		case constants.ErrTournamentFull:
+			message = "Tournament has reached its maximum number of entrants"
+		case constants.ErrAlreadyRegistered:
+			message = "User is already registered for this tournament"
+		default:
+			return nil, err
+		}
+		return &model.EntryResponse{
+			Success: false,
+			Error:   message,
+			Code:    err.Error(),
+		}, nil
+	}
+
+	return &model.EntryResponse{
+		Success: true,
+		Data:    entry,
+	}, nil
+}
+
+func (c *TournamentCore) GetTopPlayers(ctx context.Context, tournamentID string, limit int) (*model.TournamentTopResponse, error) {
+	tournament, resp, err := c.resolveTournament(ctx, tournamentID)
+	if tournament == nil {
+		if resp == nil {
+			return nil, err
+		}
+		return &model.TournamentTopResponse{
+			Success:      false,
+			TournamentID: tournamentID,
+			Error:        resp.Error,
+			Code:         resp.Code,
+		}, nil
+	}
+
+	top, err := c.leaderboards.GetTopPlayers(ctx, tournament.BoardID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if !top.Success {
+		return nil, errors.New("tournament board missing: " + top.Code)
+	}
+
+	return &model.TournamentTopResponse{
+		Success:      true,
+		TournamentID: tournament.ID,
+		Status:       tournament.Status,
+		ScoreUnit:    top.ScoreUnit,
+		Players:      top.Players,
+	}, nil
+}
+
+// GetPlayerRank returns a player's standing in a tournament along with their
+// entry. Registered players that have not submitted yet have no rank.
+func (c *TournamentCore) GetPlayerRank(ctx context.Context, tournamentID string, userID int64) (*model.TournamentRankResponse, error) {
+	if userID <= 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	tournament, resp, err := c.resolveTournament(ctx, tournamentID)
+	if tournament == nil {
+		if resp == nil {
+			return nil, err
+		}
+		return &model.TournamentRankResponse{
+			Success: false,
+			Error:   resp.Error,
+			Code:    resp.Code,
+		}, nil
+	}
+
+	data := &model.TournamentRankData{
+		TournamentID: tournament.ID,
+		Status:       tournament.Status,
+	}
+
+	entry, err := c.repo.GetEntry(ctx, tournament.ID, userID)
+	if err != nil && err.Error() != constants.ErrNotRegistered {
+		return nil, err
+	}
+	if entry != nil {
+		if tournament.MaxAttempts > 0 {
+			remaining := tournament.MaxAttempts - entry.Attempts
+			if remaining < 0 {
+				remaining = 0
+			}
+			entry.AttemptsRemaining = &remaining
+		}
+		data.Entry = entry
+	}
+
+	rank, err := c.leaderboards.GetPlayerRank(ctx, tournament.BoardID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if rank.Success {
+		data.Rank = rank.Data
+	} else if entry == nil {
+		return &model.TournamentRankResponse{
+			Success: false,
+			Error:   "User is not registered for this tournament",
+			Code:    constants.ErrNotRegistered,
+		}, nil
+	}
+
+	return &model.TournamentRankResponse{
+		Success: true,
+		Data:    data,
+	}, nil
+}
+
+// resolveTournament loads a tournament with its current status. Unknown ids
+// return a not-found response instead of a tournament.
+func (c *TournamentCore) resolveTournament(ctx context.Context, tournamentID string) (*model.Tournament, *model.TournamentResponse, error) {
+	tournament, err := c.repo.GetTournament(ctx, tournamentID)
+	if err != nil {
+		if err.Error() == constants.ErrTournamentNotFound {
+			return nil, &model.TournamentResponse{
+				Success: false,
+				Error:   "Tournament not found",
+				Code:    constants.ErrTournamentNotFound,
+			}, nil
+		}
+		return nil, nil, err
+	}
+
+	tournament.Status = statusAt(tournament, time.Now().UTC())
+	return tournament, nil, nil
+}
+
+// validateTournament returns a human readable problem with the tournament, or "".
+func validateTournament(t *model.Tournament, now time.Time) string {
+	if !tournamentIDPattern.MatchString(t.ID) {
+		return "id must be 1-52 lowercase letters, digits, '-' or '_'"
+	}
+	if t.Name == "" || len(t.Name) > 255 {
+		return "name must be between 1 and 255 characters"
+	}
+	if t.StartsAt.IsZero() || t.EndsAt.IsZero() {
+		return "starts_at and ends_at are required"
+	}
+	if !t.StartsAt.Before(t.EndsAt) {
+		return "starts_at must be before ends_at"
+	}
+	if !t.EndsAt.After(now) {
+		return "ends_at must be in the future"
+	}
+	if t.MaxAttempts < 0 {
+		return "max_attempts cannot be negative"
+	}
+	if t.MaxEntrants < 0 {
+		return "max_entrants cannot be negative"
+	}
+	return ""
+}
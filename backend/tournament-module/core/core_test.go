@@ -0,0 +1,282 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/model"
+)
+
+func TestSeedOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		want []int
+	}{
+		{name: "single slot", size: 1, want: []int{1}},
+		{name: "two", size: 2, want: []int{1, 2}},
+		{name: "four", size: 4, want: []int{1, 4, 2, 3}},
+		{name: "eight", size: 8, want: []int{1, 8, 4, 5, 2, 7, 3, 6}},
+		{name: "sixteen", size: 16, want: []int{1, 16, 8, 9, 4, 13, 5, 12, 2, 15, 7, 10, 3, 14, 6, 11}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := seedOrder(tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("seedOrder(%d) = %v, want %v", tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateBracket(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		players  []int64
+		statuses []string // per match id
+		pairs    [][2]int // seeds of the first round, 0 for an empty slot
+		winners  map[int]int64
+	}{
+		{
+			name:     "single elimination of two",
+			format:   constants.FormatSingleElimination,
+			players:  []int64{10, 20},
+			statuses: []string{constants.MatchReady},
+			pairs:    [][2]int{{1, 2}},
+		},
+		{
+			name:     "single elimination of four",
+			format:   constants.FormatSingleElimination,
+			players:  []int64{10, 20, 30, 40},
+			statuses: []string{constants.MatchReady, constants.MatchReady, constants.MatchPending},
+			pairs:    [][2]int{{1, 4}, {2, 3}},
+		},
+		{
+			name:     "top seed gets the bye",
+			format:   constants.FormatSingleElimination,
+			players:  []int64{10, 20, 30},
+			statuses: []string{constants.MatchBye, constants.MatchReady, constants.MatchPending},
+			pairs:    [][2]int{{1, 0}, {2, 3}},
+			winners:  map[int]int64{1: 10},
+		},
+		{
+			name:    "byes of five in eight",
+			format:  constants.FormatSingleElimination,
+			players: []int64{10, 20, 30, 40, 50},
+			statuses: []string{
+				constants.MatchBye, constants.MatchReady, constants.MatchBye, constants.MatchBye,
+				constants.MatchPending, constants.MatchReady, constants.MatchPending,
+			},
+			pairs:   [][2]int{{1, 0}, {4, 5}, {2, 0}, {3, 0}},
+			winners: map[int]int64{1: 10, 3: 20, 4: 30},
+		},
+		{
+			name:     "double elimination of two",
+			format:   constants.FormatDoubleElimination,
+			players:  []int64{10, 20},
+			statuses: []string{constants.MatchReady, constants.MatchPending, constants.MatchPending},
+			pairs:    [][2]int{{1, 2}},
+		},
+		{
+			name:    "double elimination of four",
+			format:  constants.FormatDoubleElimination,
+			players: []int64{10, 20, 30, 40},
+			statuses: []string{
+				constants.MatchReady, constants.MatchReady, constants.MatchPending,
+				constants.MatchPending, constants.MatchPending,
+				constants.MatchPending, constants.MatchPending,
+			},
+			pairs: [][2]int{{1, 4}, {2, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := generateBracket(tt.format, tt.players)
+
+			statuses := make([]string, len(matches))
+			for i, m := range matches {
+				if m.ID != i+1 {
+					t.Fatalf("match %d has id %d", i+1, m.ID)
+				}
+				statuses[i] = m.Status
+			}
+			if !reflect.DeepEqual(statuses, tt.statuses) {
+				t.Errorf("statuses = %v, want %v", statuses, tt.statuses)
+			}
+
+			for p, pair := range tt.pairs {
+				m := matches[p]
+				if got := [2]int{seedOf(m.Player1), seedOf(m.Player2)}; got != pair {
+					t.Errorf("match %d seeds = %v, want %v", m.ID, got, pair)
+				}
+				for _, player := range []*model.MatchPlayer{m.Player1, m.Player2} {
+					if player != nil && player.UserID != tt.players[player.Seed-1] {
+						t.Errorf("match %d seed %d is user %d, want %d", m.ID, player.Seed, player.UserID, tt.players[player.Seed-1])
+					}
+				}
+			}
+
+			for _, m := range matches {
+				want, ok := tt.winners[m.ID]
+				switch {
+				case ok && (m.WinnerID == nil || *m.WinnerID != want):
+					t.Errorf("match %d winner = %v, want %d", m.ID, m.WinnerID, want)
+				case !ok && m.WinnerID != nil:
+					t.Errorf("match %d winner = %d, want none", m.ID, *m.WinnerID)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateBracketLinks(t *testing.T) {
+	matches := generateBracket(constants.FormatDoubleElimination, []int64{10, 20, 30, 40})
+
+	tests := []struct {
+		name      string
+		matchID   int
+		next      int
+		nextSlot  int
+		loser     int
+		loserSlot int
+	}{
+		{name: "first winners match", matchID: 1, next: 3, nextSlot: 1, loser: 4, loserSlot: 1},
+		{name: "second winners match", matchID: 2, next: 3, nextSlot: 2, loser: 4, loserSlot: 2},
+		{name: "winners final", matchID: 3, next: 6, nextSlot: 1, loser: 5, loserSlot: 2},
+		{name: "first losers round", matchID: 4, next: 5, nextSlot: 1},
+		{name: "losers final", matchID: 5, next: 6, nextSlot: 2},
+		{name: "grand final", matchID: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := matches[tt.matchID-1]
+			if next := derefInt(m.NextMatchID); next != tt.next || m.NextSlot != tt.nextSlot {
+				t.Errorf("winner moves to match %d slot %d, want match %d slot %d", next, m.NextSlot, tt.next, tt.nextSlot)
+			}
+			if loser := derefInt(m.LoserMatchID); loser != tt.loser || m.LoserSlot != tt.loserSlot {
+				t.Errorf("loser moves to match %d slot %d, want match %d slot %d", loser, m.LoserSlot, tt.loser, tt.loserSlot)
+			}
+		})
+	}
+}
+
+func TestSettleBracket(t *testing.T) {
+	player := func(userID int64, seed int) *model.MatchPlayer {
+		return &model.MatchPlayer{UserID: userID, Seed: seed}
+	}
+	// Two first round matches feeding a final
+	bracket := func(m1, m2 [2]*model.MatchPlayer) []model.Match {
+		final := 3
+		return []model.Match{
+			{ID: 1, Side: constants.SideWinners, Round: 1, Position: 1, Player1: m1[0], Player2: m1[1], Status: constants.MatchPending, NextMatchID: &final, NextSlot: 1},
+			{ID: 2, Side: constants.SideWinners, Round: 1, Position: 2, Player1: m2[0], Player2: m2[1], Status: constants.MatchPending, NextMatchID: &final, NextSlot: 2},
+			{ID: 3, Side: constants.SideWinners, Round: 2, Position: 1, Status: constants.MatchPending},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		matches  []model.Match
+		changed  []int
+		statuses []string
+		final    [2]int64 // players of the final, 0 for an empty slot
+		winner   int64    // of the final
+	}{
+		{
+			name:     "full first round becomes ready",
+			matches:  bracket([2]*model.MatchPlayer{player(10, 1), player(40, 4)}, [2]*model.MatchPlayer{player(20, 2), player(30, 3)}),
+			changed:  []int{1, 2},
+			statuses: []string{constants.MatchReady, constants.MatchReady, constants.MatchPending},
+		},
+		{
+			name:     "bye advances its player",
+			matches:  bracket([2]*model.MatchPlayer{player(10, 1), nil}, [2]*model.MatchPlayer{player(20, 2), player(30, 3)}),
+			changed:  []int{1, 2, 3},
+			statuses: []string{constants.MatchBye, constants.MatchReady, constants.MatchPending},
+			final:    [2]int64{10, 0},
+		},
+		{
+			name:     "two byes make the final ready",
+			matches:  bracket([2]*model.MatchPlayer{player(10, 1), nil}, [2]*model.MatchPlayer{nil, player(20, 2)}),
+			changed:  []int{1, 2, 3},
+			statuses: []string{constants.MatchBye, constants.MatchBye, constants.MatchReady},
+			final:    [2]int64{10, 20},
+		},
+		{
+			name:     "empty match is void and the final a bye",
+			matches:  bracket([2]*model.MatchPlayer{player(10, 1), nil}, [2]*model.MatchPlayer{nil, nil}),
+			changed:  []int{1, 2, 3},
+			statuses: []string{constants.MatchBye, constants.MatchVoid, constants.MatchBye},
+			final:    [2]int64{10, 0},
+			winner:   10,
+		},
+		{
+			name: "settled bracket is left alone",
+			matches: func() []model.Match {
+				matches := bracket([2]*model.MatchPlayer{player(10, 1), player(40, 4)}, [2]*model.MatchPlayer{player(20, 2), player(30, 3)})
+				matches[0].Status = constants.MatchReady
+				matches[1].Status = constants.MatchReady
+				return matches
+			}(),
+			changed:  []int{},
+			statuses: []string{constants.MatchReady, constants.MatchReady, constants.MatchPending},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := settleBracket(tt.matches)
+			if !reflect.DeepEqual(changed, tt.changed) {
+				t.Errorf("settleBracket() = %v, want %v", changed, tt.changed)
+			}
+
+			statuses := make([]string, len(tt.matches))
+			for i, m := range tt.matches {
+				statuses[i] = m.Status
+			}
+			if !reflect.DeepEqual(statuses, tt.statuses) {
+				t.Errorf("statuses = %v, want %v", statuses, tt.statuses)
+			}
+
+			final := tt.matches[2]
+			if got := [2]int64{userOf(final.Player1), userOf(final.Player2)}; got != tt.final {
+				t.Errorf("final players = %v, want %v", got, tt.final)
+			}
+			if got := derefInt64(final.WinnerID); got != tt.winner {
+				t.Errorf("final winner = %d, want %d", got, tt.winner)
+			}
+		})
+	}
+}
+
+func seedOf(player *model.MatchPlayer) int {
+	if player == nil {
+		return 0
+	}
+	return player.Seed
+}
+
+func userOf(player *model.MatchPlayer) int64 {
+	if player == nil {
+		return 0
+	}
+	return player.UserID
+}
+
+func derefInt(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
+
+func derefInt64(n *int64) int64 {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
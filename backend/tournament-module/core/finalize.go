@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	leaderboardConstants "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	leaderboardCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/core"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/repository"
+)
+
+// GameLister lists the games (tenants) background jobs have to visit.
+type GameLister interface {
+	ListGameIDs(ctx context.Context) ([]string, error)
+}
+
+// TournamentFinalizeJob closes tournaments that have ended by writing the
+// final rank and score of every entrant.
+type TournamentFinalizeJob struct {
+	repo     *repository.TournamentRepository
+	games    GameLister
+	logger   *providers.ConsoleLogger
+	interval time.Duration
+}
+
+func NewTournamentFinalizeJob(
+	repo *repository.TournamentRepository,
+	games GameLister,
+	logger *providers.ConsoleLogger,
+	interval time.Duration,
+) *TournamentFinalizeJob {
+	return &TournamentFinalizeJob{
+		repo:     repo,
+		games:    games,
+		logger:   logger,
+		interval: interval,
+	}
+}
+
+// Run blocks until ctx is cancelled, finalizing ended tournaments on every interval.
+func (j *TournamentFinalizeJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.runOnce(ctx, time.Now().UTC())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			j.runOnce(ctx, now.UTC())
+		}
+	}
+}
+
+func (j *TournamentFinalizeJob) runOnce(ctx context.Context, now time.Time) {
+	gameIDs, err := j.games.ListGameIDs(ctx)
+	if err != nil {
+		j.logger.Errorf("Tournament finalize failed to list games | error=%v", err)
+		return
+	}
+
+	// Tournament boards never reset, so their scores live in a single period
+	periodStart := leaderboardCore.PeriodStart(leaderboardConstants.ResetNever, now)
+
+	for _, gameID := range gameIDs {
+		gameCtx := global.WithGameID(ctx, gameID)
+
+		tournaments, err := j.repo.ListUnfinalized(gameCtx, now.Add(-constants.FinalizeGrace))
+		if err != nil {
+			j.logger.Errorf("Tournament finalize failed to list tournaments | game_id=%s error=%v", gameID, err)
+			continue
+		}
+
+		for i := range tournaments {
+			finalized, err := j.repo.Finalize(gameCtx, &tournaments[i], periodStart, now)
+			if err != nil {
+				j.logger.Errorf("Tournament finalize failed | game_id=%s tournament_id=%s error=%v", gameID, tournaments[i].ID, err)
+				continue
+			}
+			if finalized {
+				j.logger.Infof("Tournament closed | game_id=%s tournament_id=%s entrants=%d", gameID, tournaments[i].ID, tournaments[i].Entrants)
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	leaderboardModel "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/constants"
+)
+
+// ReserveSubmit enforces tournament rules on submits to tournament boards:
+// the tournament must be running, the player registered and attempts left.
+// Each accepted submit uses up one attempt per targeted tournament.
+func (c *TournamentCore) ReserveSubmit(
+	ctx context.Context,
+	userID int64,
+	boards []*leaderboardModel.Board,
+	at time.Time,
+) (*leaderboardModel.SubmitRejection, error) {
+	reserved := make([]*leaderboardModel.Board, 0, len(boards))
+
+	for _, board := range boards {
+		if board.Owner != constants.BoardOwner {
+			continue
+		}
+
+		err := c.repo.ReserveAttempt(ctx, board.ID, userID, at)
+		if err == nil {
+			reserved = append(reserved, board)
+			continue
+		}
+
+		c.ReleaseSubmit(ctx, userID, reserved)
+
+		switch err.Error() {
+		case constants.ErrTournamentNotActive:
+			return &leaderboardModel.SubmitRejection{
+				Code:    constants.ErrTournamentNotActive,
+				Message: fmt.Sprintf("Tournament %q is not running", board.Name),
+			}, nil
+		case constants.ErrNotRegistered:
+			return &leaderboardModel.SubmitRejection{
+				Code:    constants.ErrNotRegistered,
+				Message: fmt.Sprintf("User is not registered for tournament %q", board.Name),
+			}, nil
+		case constants.ErrAttemptsExhausted:
+			return &leaderboardModel.SubmitRejection{
+				Code:    constants.ErrAttemptsExhausted,
+				Message: fmt.Sprintf("No attempts left in tournament %q", board.Name),
+			}, nil
+		case constants.ErrTournamentNotFound:
+			return &leaderboardModel.SubmitRejection{
+				Code:    constants.ErrTournamentNotFound,
+				Message: fmt.Sprintf("Leaderboard %q belongs to no tournament", board.ID),
+			}, nil
+		}
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ReleaseSubmit gives back the attempts of a submit that was not stored.
+func (c *TournamentCore) ReleaseSubmit(ctx context.Context, userID int64, boards []*leaderboardModel.Board) {
+	for _, board := range boards {
+		if board.Owner != constants.BoardOwner {
+			continue
+		}
+		if err := c.repo.ReleaseAttempt(ctx, board.ID, userID); err != nil {
+			c.logger.Warnf("Failed to release tournament attempt | board_id=%s user_id=%d error=%v", board.ID, userID, err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package model
+
+import "time"
+
+// Bracket is an elimination bracket of a tournament's entrants, seeded by
+// their rank on SeedBoardID. Size is the field rounded up to a power of two;
+// the top seeds receive the byes.
+type Bracket struct {
+	TournamentID string     `json:"tournament_id"`
+	Format       string     `json:"format"`
+	SeedBoardID  string     `json:"seed_board_id"`
+	Size         int        `json:"size"`
+	Participants int        `json:"participants"`
+	ChampionID   *int64     `json:"champion_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	Rounds       []Round    `json:"rounds"`
+}
+
+// Round groups the matches of one side of the bracket that are played in
+// the same round; matches link to the next round by id.
+type Round struct {
+	Side    string  `json:"side"`
+	Round   int     `json:"round"`
+	Matches []Match `json:"matches"`
+}
+
+type Match struct {
+	ID           int          `json:"id"`
+	Side         string       `json:"side"`
+	Round        int          `json:"round"`
+	Position     int          `json:"position"`
+	Player1      *MatchPlayer `json:"player1,omitempty"`
+	Player2      *MatchPlayer `json:"player2,omitempty"`
+	WinnerID     *int64       `json:"winner_id,omitempty"`
+	Status       string       `json:"status"`
+	NextMatchID  *int         `json:"next_match_id,omitempty"`
+	NextSlot     int          `json:"next_slot,omitempty"`
+	LoserMatchID *int         `json:"loser_match_id,omitempty"`
+	LoserSlot    int          `json:"loser_slot,omitempty"`
+	ScheduledAt  *time.Time   `json:"scheduled_at,omitempty"`
+	CompletedAt  *time.Time   `json:"completed_at,omitempty"`
+}
+
+type MatchPlayer struct {
+	UserID    int64  `json:"user_id"`
+	Seed      int    `json:"seed"`
+	Score     *int64 `json:"score,omitempty"`
+	SessionID *int64 `json:"session_id,omitempty"`
+}
+
+// CreateBracketRequest seeds the tournament's entrants by their current rank
+// on SeedBoardID (the global board by default), keeping at most MaxPlayers of
+// them. With StartsAt set, matches are scheduled RoundIntervalMinutes apart by
+// how many matches have to finish before them.
+type CreateBracketRequest struct {
+	Format               string     `json:"format"`
+	SeedBoardID          string     `json:"seed_board_id,omitempty"`
+	MaxPlayers           int        `json:"max_players,omitempty"`
+	StartsAt             *time.Time `json:"starts_at,omitempty"`
+	RoundIntervalMinutes int        `json:"round_interval_minutes,omitempty"`
+}
+
+// ReportResultRequest reports the outcome of a ready match. Scores can be
+// given directly or taken from the players' game sessions; without a
+// WinnerID the better score wins.
+type ReportResultRequest struct {
+	WinnerID         int64  `json:"winner_id,omitempty"`
+	Player1Score     *int64 `json:"player1_score,omitempty"`
+	Player2Score     *int64 `json:"player2_score,omitempty"`
+	Player1SessionID *int64 `json:"player1_session_id,omitempty"`
+	Player2SessionID *int64 `json:"player2_session_id,omitempty"`
+}
+
+type ScheduleMatchRequest struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+type BracketResponse struct {
+	Success bool     `json:"success"`
+	Data    *Bracket `json:"data,omitempty"`
+	Error   string   `json:"error,omitempty"`
+	Code    string   `json:"code,omitempty"`
+}
+
+type MatchResponse struct {
+	Success bool   `json:"success"`
+	Data    *Match `json:"data,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"time"
+
+	leaderboardModel "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+)
+
+// Tournament is a time-limited event scored on its own board. Players
+// register from RegistrationOpensAt until the event ends and may submit while
+// it is running, at most MaxAttempts times (0 means unlimited).
+type Tournament struct {
+	ID                  string     `json:"id"`
+	Name                string     `json:"name"`
+	BoardID             string     `json:"board_id"`
+	Status              string     `json:"status"`
+	RegistrationOpensAt time.Time  `json:"registration_opens_at"`
+	StartsAt            time.Time  `json:"starts_at"`
+	EndsAt              time.Time  `json:"ends_at"`
+	MaxAttempts         int        `json:"max_attempts"`
+	MaxEntrants         int        `json:"max_entrants"`
+	Entrants            int        `json:"entrants"`
+	FinalizedAt         *time.Time `json:"finalized_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+type CreateTournamentRequest struct {
+	ID                  string     `json:"id"`
+	Name                string     `json:"name"`
+	RegistrationOpensAt *time.Time `json:"registration_opens_at,omitempty"`
+	StartsAt            time.Time  `json:"starts_at"`
+	EndsAt              time.Time  `json:"ends_at"`
+	MaxAttempts         int        `json:"max_attempts,omitempty"`
+	MaxEntrants         int        `json:"max_entrants,omitempty"`
+
+	// Settings of the tournament's board; see CreateBoardRequest.
+	Aggregation string   `json:"aggregation,omitempty"`
+	SortOrder   string   `json:"sort_order,omitempty"`
+	ScoreUnit   string   `json:"score_unit,omitempty"`
+	GameModes   []string `json:"game_modes,omitempty"`
+}
+
+// Entry is a player's registration. FinalRank and FinalScore are set once the
+// tournament closes, for players that submitted at least once.
+type Entry struct {
+	TournamentID      string    `json:"tournament_id"`
+	UserID            int64     `json:"user_id"`
+	Attempts          int       `json:"attempts"`
+	AttemptsRemaining *int      `json:"attempts_remaining,omitempty"`
+	RegisteredAt      time.Time `json:"registered_at"`
+	FinalRank         *int      `json:"final_rank,omitempty"`
+	FinalScore        *int64    `json:"final_score,omitempty"`
+}
+
+type RegisterRequest struct {
+	UserID int64 `json:"user_id"`
+}
+
+type TournamentResponse struct {
+	Success bool        `json:"success"`
+	Data    *Tournament `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+	Code    string      `json:"code,omitempty"`
+}
+
+type TournamentsResponse struct {
+	Success     bool         `json:"success"`
+	Tournaments []Tournament `json:"tournaments"`
+	Error       string       `json:"error,omitempty"`
+	Code        string       `json:"code,omitempty"`
+}
+
+type EntryResponse struct {
+	Success bool   `json:"success"`
+	Data    *Entry `json:"data,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+type TournamentTopResponse struct {
+	Success      bool                           `json:"success"`
+	TournamentID string                         `json:"tournament_id"`
+	Status       string                         `json:"status,omitempty"`
+	ScoreUnit    string                         `json:"score_unit,omitempty"`
+	Players      []leaderboardModel.PlayerScore `json:"players"`
+	Error        string                         `json:"error,omitempty"`
+	Code         string                         `json:"code,omitempty"`
+}
+
+type TournamentRankData struct {
+	TournamentID string                           `json:"tournament_id"`
+	Status       string                           `json:"status"`
+	Rank         *leaderboardModel.PlayerRankData `json:"rank"`
+	Entry        *Entry                           `json:"entry,omitempty"`
+}
+
+type TournamentRankResponse struct {
+	Success bool                `json:"success"`
+	Data    *TournamentRankData `json:"data,omitempty"`
+	Error   string              `json:"error,omitempty"`
+	Code    string              `json:"code,omitempty"`
+}
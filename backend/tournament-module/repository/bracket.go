@@ -0,0 +1,330 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	leaderboardConstants "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type bracketRow struct {
+	GameID       string     `gorm:"column:game_id;primaryKey"`
+	TournamentID string     `gorm:"column:tournament_id;primaryKey"`
+	Format       string     `gorm:"column:format"`
+	SeedBoardID  string     `gorm:"column:seed_board_id"`
+	Size         int        `gorm:"column:size"`
+	Participants int        `gorm:"column:participants"`
+	ChampionID   *int64     `gorm:"column:champion_id"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	CompletedAt  *time.Time `gorm:"column:completed_at"`
+}
+
+func (bracketRow) TableName() string {
+	return "gaming.brackets"
+}
+
+type matchRow struct {
+	GameID           string     `gorm:"column:game_id;primaryKey"`
+	TournamentID     string     `gorm:"column:tournament_id;primaryKey"`
+	ID               int        `gorm:"column:id;primaryKey;autoIncrement:false"`
+	Side             string     `gorm:"column:side"`
+	Round            int        `gorm:"column:round"`
+	Position         int        `gorm:"column:position"`
+	Player1ID        *int64     `gorm:"column:player1_id"`
+	Player1Seed      *int       `gorm:"column:player1_seed"`
+	Player1Score     *int64     `gorm:"column:player1_score"`
+	Player1SessionID *int64     `gorm:"column:player1_session_id"`
+	Player2ID        *int64     `gorm:"column:player2_id"`
+	Player2Seed      *int       `gorm:"column:player2_seed"`
+	Player2Score     *int64     `gorm:"column:player2_score"`
+	Player2SessionID *int64     `gorm:"column:player2_session_id"`
+	WinnerID         *int64     `gorm:"column:winner_id"`
+	Status           string     `gorm:"column:status"`
+	NextMatchID      *int       `gorm:"column:next_match_id"`
+	NextSlot         *int       `gorm:"column:next_slot"`
+	LoserMatchID     *int       `gorm:"column:loser_match_id"`
+	LoserSlot        *int       `gorm:"column:loser_slot"`
+	ScheduledAt      *time.Time `gorm:"column:scheduled_at"`
+	CompletedAt      *time.Time `gorm:"column:completed_at"`
+}
+
+func (matchRow) TableName() string {
+	return "gaming.bracket_matches"
+}
+
+// GameSession is the part of a game session a match result can refer to.
+type GameSession struct {
+	UserID int64 `gorm:"column:user_id"`
+	Score  int64 `gorm:"column:score"`
+}
+
+// BracketUpdate changes a bracket's matches and returns the ids of the ones
+// it changed. It runs while the bracket is locked.
+type BracketUpdate func(bracket *model.Bracket, matches []model.Match) ([]int, error)
+
+// ListSeeds returns the tournament's entrants ordered by their current rank on
+// the seed board, best first. Entrants without a score on it follow in
+// registration order.
+func (r *TournamentRepository) ListSeeds(
+	ctx context.Context,
+	tournamentID string,
+	boardID string,
+	sortOrder string,
+	periodStart time.Time,
+	limit int,
+) ([]int64, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	direction := "DESC"
+	if sortOrder == leaderboardConstants.SortAscending {
+		direction = "ASC"
+	}
+
+	var userIDs []int64
+	if err := r.db.WithContext(ctx).Raw(fmt.Sprintf(`
+		SELECT e.user_id
+		FROM gaming.tournament_entries e
+		LEFT JOIN (
+			SELECT user_id, RANK() OVER (ORDER BY total_score %s) AS rank
+			FROM gaming.leaderboard
+			WHERE game_id = ? AND board_id = ? AND period_start = ?
+		) ranked ON ranked.user_id = e.user_id
+		WHERE e.game_id = ? AND e.tournament_id = ?
+		ORDER BY ranked.rank NULLS LAST, e.registered_at, e.user_id
+		LIMIT ?
+	`, direction), gameID, boardID, periodStart, gameID, tournamentID, limit).Scan(&userIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list seeds: %w", err)
+	}
+	return userIDs, nil
+}
+
+// CreateBracket stores a bracket with all of its matches. A tournament has at
+// most one bracket; creating another fails with ErrBracketExists.
+func (r *TournamentRepository) CreateBracket(ctx context.Context, bracket *model.Bracket, matches []model.Match) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row := newBracketRow(gameID, bracket)
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row)
+		if result.Error != nil {
+			return fmt.Errorf("failed to create bracket: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.New(constants.ErrBracketExists)
+		}
+
+		rows := make([]matchRow, 0, len(matches))
+		for i := range matches {
+			rows = append(rows, newMatchRow(gameID, bracket.TournamentID, &matches[i]))
+		}
+		if err := tx.CreateInBatches(&rows, 500).Error; err != nil {
+			return fmt.Errorf("failed to create bracket matches: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *TournamentRepository) GetBracket(ctx context.Context, tournamentID string) (*model.Bracket, []model.Match, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return loadBracket(r.db.WithContext(ctx), gameID, tournamentID, false)
+}
+
+// UpdateBracket locks a bracket, applies the update to its matches and saves
+// the matches it changed together with the bracket itself.
+func (r *TournamentRepository) UpdateBracket(ctx context.Context, tournamentID string, update BracketUpdate) (*model.Bracket, []model.Match, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		bracket *model.Bracket
+		matches []model.Match
+	)
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		bracket, matches, err = loadBracket(tx, gameID, tournamentID, true)
+		if err != nil {
+			return err
+		}
+
+		changed, err := update(bracket, matches)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range changed {
+			row := newMatchRow(gameID, tournamentID, &matches[id-1])
+			if err := tx.Save(&row).Error; err != nil {
+				return fmt.Errorf("failed to update match %d: %w", id, err)
+			}
+		}
+
+		row := newBracketRow(gameID, bracket)
+		if err := tx.Save(&row).Error; err != nil {
+			return fmt.Errorf("failed to update bracket: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return bracket, matches, nil
+}
+
+// GetGameSession returns a session of the game, or ErrInvalidResult if there is none.
+func (r *TournamentRepository) GetGameSession(ctx context.Context, sessionID int64) (*GameSession, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []GameSession
+	if err := r.db.WithContext(ctx).Raw(
+		`SELECT user_id, score FROM gaming.game_sessions WHERE game_id = ? AND id = ?`,
+		gameID,
+		sessionID,
+	).Scan(&sessions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get game session: %w", err)
+	}
+	if len(sessions) == 0 {
+		return nil, errors.New(constants.ErrInvalidResult)
+	}
+	return &sessions[0], nil
+}
+
+func loadBracket(db *gorm.DB, gameID, tournamentID string, lock bool) (*model.Bracket, []model.Match, error) {
+	query := db.Where("game_id = ? AND tournament_id = ?", gameID, tournamentID)
+	if lock {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	var brackets []bracketRow
+	if err := query.Find(&brackets).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to get bracket: %w", err)
+	}
+	if len(brackets) == 0 {
+		return nil, nil, errors.New(constants.ErrBracketNotFound)
+	}
+
+	var rows []matchRow
+	if err := db.
+		Where("game_id = ? AND tournament_id = ?", gameID, tournamentID).
+		Order("id").
+		Find(&rows).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to get bracket matches: %w", err)
+	}
+
+	matches := make([]model.Match, 0, len(rows))
+	for _, row := range rows {
+		matches = append(matches, row.toModel())
+	}
+	return brackets[0].toModel(), matches, nil
+}
+
+func newBracketRow(gameID string, bracket *model.Bracket) bracketRow {
+	return bracketRow{
+		GameID:       gameID,
+		TournamentID: bracket.TournamentID,
+		Format:       bracket.Format,
+		SeedBoardID:  bracket.SeedBoardID,
+		Size:         bracket.Size,
+		Participants: bracket.Participants,
+		ChampionID:   bracket.ChampionID,
+		CreatedAt:    bracket.CreatedAt,
+		CompletedAt:  bracket.CompletedAt,
+	}
+}
+
+func (row bracketRow) toModel() *model.Bracket {
+	return &model.Bracket{
+		TournamentID: row.TournamentID,
+		Format:       row.Format,
+		SeedBoardID:  row.SeedBoardID,
+		Size:         row.Size,
+		Participants: row.Participants,
+		ChampionID:   row.ChampionID,
+		CreatedAt:    row.CreatedAt,
+		CompletedAt:  row.CompletedAt,
+	}
+}
+
+func newMatchRow(gameID, tournamentID string, m *model.Match) matchRow {
+	row := matchRow{
+		GameID:       gameID,
+		TournamentID: tournamentID,
+		ID:           m.ID,
+		Side:         m.Side,
+		Round:        m.Round,
+		Position:     m.Position,
+		WinnerID:     m.WinnerID,
+		Status:       m.Status,
+		NextMatchID:  m.NextMatchID,
+		LoserMatchID: m.LoserMatchID,
+		ScheduledAt:  m.ScheduledAt,
+		CompletedAt:  m.CompletedAt,
+	}
+	if m.NextMatchID != nil {
+		row.NextSlot = &m.NextSlot
+	}
+	if m.LoserMatchID != nil {
+		row.LoserSlot = &m.LoserSlot
+	}
+	if p := m.Player1; p != nil {
+		row.Player1ID, row.Player1Seed, row.Player1Score, row.Player1SessionID = &p.UserID, &p.Seed, p.Score, p.SessionID
+	}
+	if p := m.Player2; p != nil {
+		row.Player2ID, row.Player2Seed, row.Player2Score, row.Player2SessionID = &p.UserID, &p.Seed, p.Score, p.SessionID
+	}
+	return row
+}
+
+func (row matchRow) toModel() model.Match {
+	m := model.Match{
+		ID:           row.ID,
+		Side:         row.Side,
+		Round:        row.Round,
+		Position:     row.Position,
+		WinnerID:     row.WinnerID,
+		Status:       row.Status,
+		NextMatchID:  row.NextMatchID,
+		LoserMatchID: row.LoserMatchID,
+		ScheduledAt:  row.ScheduledAt,
+		CompletedAt:  row.CompletedAt,
+	}
+	if row.NextSlot != nil {
+		m.NextSlot = *row.NextSlot
+	}
+	if row.LoserSlot != nil {
+		m.LoserSlot = *row.LoserSlot
+	}
+	if row.Player1ID != nil {
+		m.Player1 = &model.MatchPlayer{UserID: *row.Player1ID, Score: row.Player1Score, SessionID: row.Player1SessionID}
+		if row.Player1Seed != nil {
+			m.Player1.Seed = *row.Player1Seed
+		}
+	}
+	if row.Player2ID != nil {
+		m.Player2 = &model.MatchPlayer{UserID: *row.Player2ID, Score: row.Player2Score, SessionID: row.Player2SessionID}
+		if row.Player2Seed != nil {
+			m.Player2.Seed = *row.Player2Seed
+		}
+	}
+	return m
+}
@@ -0,0 +1,435 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	leaderboardConstants "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type tournamentRow struct {
+	GameID              string     `gorm:"column:game_id"`
+	ID                  string     `gorm:"column:id"`
+	Name                string     `gorm:"column:name"`
+	BoardID             string     `gorm:"column:board_id"`
+	RegistrationOpensAt time.Time  `gorm:"column:registration_opens_at"`
+	StartsAt            time.Time  `gorm:"column:starts_at"`
+	EndsAt              time.Time  `gorm:"column:ends_at"`
+	MaxAttempts         int        `gorm:"column:max_attempts"`
+	MaxEntrants         int        `gorm:"column:max_entrants"`
+	Entrants            int        `gorm:"column:entrants"`
+	FinalizedAt         *time.Time `gorm:"column:finalized_at"`
+	CreatedAt           time.Time  `gorm:"column:created_at"`
+}
+
+func (tournamentRow) TableName() string {
+	return "gaming.tournaments"
+}
+
+type entryRow struct {
+	GameID       string    `gorm:"column:game_id"`
+	TournamentID string    `gorm:"column:tournament_id"`
+	UserID       int64     `gorm:"column:user_id"`
+	Attempts     int       `gorm:"column:attempts"`
+	RegisteredAt time.Time `gorm:"column:registered_at"`
+	FinalRank    *int      `gorm:"column:final_rank"`
+	FinalScore   *int64    `gorm:"column:final_score"`
+}
+
+func (entryRow) TableName() string {
+	return "gaming.tournament_entries"
+}
+
+type ITournamentRepository interface {
+	UserExists(ctx context.Context, userID int64) (bool, error)
+	CreateTournament(ctx context.Context, tournament *model.Tournament) error
+	GetTournament(ctx context.Context, tournamentID string) (*model.Tournament, error)
+	GetTournamentByBoard(ctx context.Context, boardID string) (*model.Tournament, error)
+	ListTournaments(ctx context.Context, limit int) ([]model.Tournament, error)
+	ListUnfinalized(ctx context.Context, endedBefore time.Time) ([]model.Tournament, error)
+	Register(ctx context.Context, tournamentID string, userID int64, at time.Time) (*model.Entry, error)
+	GetEntry(ctx context.Context, tournamentID string, userID int64) (*model.Entry, error)
+	ReserveAttempt(ctx context.Context, boardID string, userID int64, at time.Time) error
+	ReleaseAttempt(ctx context.Context, boardID string, userID int64) error
+	Finalize(ctx context.Context, tournament *model.Tournament, periodStart, at time.Time) (bool, error)
+}
+
+type TournamentRepository struct {
+	db     *gorm.DB
+	logger *providers.ConsoleLogger
+}
+
+func NewTournamentRepository(db *gorm.DB, logger *providers.ConsoleLogger) *TournamentRepository {
+	return &TournamentRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *TournamentRepository) UserExists(ctx context.Context, userID int64) (bool, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	if err := r.db.WithContext(ctx).Raw(
+		`SELECT EXISTS (SELECT 1 FROM gaming.users WHERE game_id = ? AND id = ?)`,
+		gameID,
+		userID,
+	).Scan(&exists).Error; err != nil {
+		return false, fmt.Errorf("failed to check user: %w", err)
+	}
+	return exists, nil
+}
+
+// CreateTournament stores a new tournament. Its board must already exist.
+// Creating an id that already exists fails with ErrTournamentExists.
+func (r *TournamentRepository) CreateTournament(ctx context.Context, tournament *model.Tournament) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	row := tournamentRow{
+		GameID:              gameID,
+		ID:                  tournament.ID,
+		Name:                tournament.Name,
+		BoardID:             tournament.BoardID,
+		RegistrationOpensAt: tournament.RegistrationOpensAt,
+		StartsAt:            tournament.StartsAt,
+		EndsAt:              tournament.EndsAt,
+		MaxAttempts:         tournament.MaxAttempts,
+		MaxEntrants:         tournament.MaxEntrants,
+		CreatedAt:           tournament.CreatedAt,
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&row)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create tournament: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(constants.ErrTournamentExists)
+	}
+	return nil
+}
+
+func (r *TournamentRepository) GetTournament(ctx context.Context, tournamentID string) (*model.Tournament, error) {
+	return r.findTournament(ctx, "id = ?", tournamentID)
+}
+
+func (r *TournamentRepository) GetTournamentByBoard(ctx context.Context, boardID string) (*model.Tournament, error) {
+	return r.findTournament(ctx, "board_id = ?", boardID)
+}
+
+func (r *TournamentRepository) findTournament(ctx context.Context, condition string, value interface{}) (*model.Tournament, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []tournamentRow
+	if err := r.db.WithContext(ctx).
+		Where("game_id = ?", gameID).
+		Where(condition, value).
+		Limit(1).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New(constants.ErrTournamentNotFound)
+	}
+	return rows[0].toModel(), nil
+}
+
+// ListTournaments returns the most recent tournaments first.
+func (r *TournamentRepository) ListTournaments(ctx context.Context, limit int) ([]model.Tournament, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []tournamentRow
+	if err := r.db.WithContext(ctx).
+		Where("game_id = ?", gameID).
+		Order("starts_at DESC, id").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list tournaments: %w", err)
+	}
+
+	tournaments := make([]model.Tournament, 0, len(rows))
+	for _, row := range rows {
+		tournaments = append(tournaments, *row.toModel())
+	}
+	return tournaments, nil
+}
+
+// ListUnfinalized returns the tournaments that ended before the given time
+// but have no final standings yet.
+func (r *TournamentRepository) ListUnfinalized(ctx context.Context, endedBefore time.Time) ([]model.Tournament, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []tournamentRow
+	if err := r.db.WithContext(ctx).
+		Where("game_id = ? AND finalized_at IS NULL AND ends_at <= ?", gameID, endedBefore).
+		Order("ends_at").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list unfinalized tournaments: %w", err)
+	}
+
+	tournaments := make([]model.Tournament, 0, len(rows))
+	for _, row := range rows {
+		tournaments = append(tournaments, *row.toModel())
+	}
+	return tournaments, nil
+}
+
+// Register enters a player into a tournament whose registration window is
+// open. The tournament row is locked so max_entrants cannot be exceeded by
+// concurrent registrations.
+func (r *TournamentRepository) Register(ctx context.Context, tournamentID string, userID int64, at time.Time) (*model.Entry, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := entryRow{
+		GameID:       gameID,
+		TournamentID: tournamentID,
+		UserID:       userID,
+		RegisteredAt: at,
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rows []tournamentRow
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("game_id = ? AND id = ?", gameID, tournamentID).
+			Find(&rows).Error; err != nil {
+			return fmt.Errorf("failed to lock tournament: %w", err)
+		}
+		if len(rows) == 0 {
+			return errors.New(constants.ErrTournamentNotFound)
+		}
+
+		t := rows[0]
+		if t.FinalizedAt != nil || at.Before(t.RegistrationOpensAt) || !at.Before(t.EndsAt) {
+			return errors.New(constants.ErrRegistrationClosed)
+		}
+		if t.MaxEntrants > 0 && t.Entrants >= t.MaxEntrants {
+			return errors.New(constants.ErrTournamentFull)
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entry)
+		if result.Error != nil {
+			return fmt.Errorf("failed to register entry: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.New(constants.ErrAlreadyRegistered)
+		}
+
+		if err := tx.Exec(
+			`UPDATE gaming.tournaments SET entrants = entrants + 1 WHERE game_id = ? AND id = ?`,
+			gameID,
+			tournamentID,
+		).Error; err != nil {
+			return fmt.Errorf("failed to count entrant: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.toModel(), nil
+}
+
+func (r *TournamentRepository) GetEntry(ctx context.Context, tournamentID string, userID int64) (*model.Entry, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []entryRow
+	if err := r.db.WithContext(ctx).
+		Where("game_id = ? AND tournament_id = ? AND user_id = ?", gameID, tournamentID, userID).
+		Limit(1).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get entry: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New(constants.ErrNotRegistered)
+	}
+	return rows[0].toModel(), nil
+}
+
+// ReserveAttempt counts a submit against the player's entry in the tournament
+// scored on the board. The entry is only incremented while the tournament is
+// running and attempts remain, so concurrent submits cannot exceed the limit.
+// It fails with ErrTournamentNotActive, ErrNotRegistered or ErrAttemptsExhausted.
+func (r *TournamentRepository) ReserveAttempt(ctx context.Context, boardID string, userID int64, at time.Time) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := r.db.WithContext(ctx).Exec(`
+		UPDATE gaming.tournament_entries e
+		SET attempts = e.attempts + 1
+		FROM gaming.tournaments t
+		WHERE t.game_id = ?
+		  AND t.board_id = ?
+		  AND e.game_id = t.game_id
+		  AND e.tournament_id = t.id
+		  AND e.user_id = ?
+		  AND t.finalized_at IS NULL
+		  AND t.starts_at <= ?
+		  AND t.ends_at > ?
+		  AND (t.max_attempts = 0 OR e.attempts < t.max_attempts)
+	`, gameID, boardID, userID, at, at)
+	if result.Error != nil {
+		return fmt.Errorf("failed to reserve attempt: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	// Work out why nothing was reserved
+	tournament, err := r.GetTournamentByBoard(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	if tournament.FinalizedAt != nil || at.Before(tournament.StartsAt) || !at.Before(tournament.EndsAt) {
+		return errors.New(constants.ErrTournamentNotActive)
+	}
+	if _, err := r.GetEntry(ctx, tournament.ID, userID); err != nil {
+		return err
+	}
+	return errors.New(constants.ErrAttemptsExhausted)
+}
+
+// ReleaseAttempt gives back an attempt reserved for a submit that was not stored.
+func (r *TournamentRepository) ReleaseAttempt(ctx context.Context, boardID string, userID int64) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Exec(`
+		UPDATE gaming.tournament_entries e
+		SET attempts = e.attempts - 1
+		FROM gaming.tournaments t
+		WHERE t.game_id = ?
+		  AND t.board_id = ?
+		  AND e.game_id = t.game_id
+		  AND e.tournament_id = t.id
+		  AND e.user_id = ?
+		  AND e.attempts > 0
+	`, gameID, boardID, userID).Error; err != nil {
+		return fmt.Errorf("failed to release attempt: %w", err)
+	}
+	return nil
+}
+
+// Finalize writes every entrant's final rank and score from the tournament's
+// board and closes the tournament. It returns false if the tournament was
+// already finalized, e.g. by another instance.
+func (r *TournamentRepository) Finalize(ctx context.Context, tournament *model.Tournament, periodStart, at time.Time) (bool, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	finalized := false
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(
+			`UPDATE gaming.tournaments SET finalized_at = ? WHERE game_id = ? AND id = ? AND finalized_at IS NULL`,
+			at,
+			gameID,
+			tournament.ID,
+		)
+		if result.Error != nil {
+			return fmt.Errorf("failed to close tournament: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		var sortOrder string
+		if err := tx.Raw(
+			`SELECT sort_order FROM gaming.leaderboards WHERE game_id = ? AND id = ?`,
+			gameID,
+			tournament.BoardID,
+		).Scan(&sortOrder).Error; err != nil {
+			return fmt.Errorf("failed to get board sort order: %w", err)
+		}
+
+		direction := "DESC"
+		if sortOrder == leaderboardConstants.SortAscending {
+			direction = "ASC"
+		}
+
+		if err := tx.Exec(fmt.Sprintf(`
+			UPDATE gaming.tournament_entries e
+			SET final_rank = ranked.rank, final_score = ranked.total_score
+			FROM (
+				SELECT user_id, total_score, RANK() OVER (ORDER BY total_score %s) AS rank
+				FROM gaming.leaderboard
+				WHERE game_id = ? AND board_id = ? AND period_start = ?
+			) ranked
+			WHERE e.game_id = ?
+			  AND e.tournament_id = ?
+			  AND e.user_id = ranked.user_id
+		`, direction), gameID, tournament.BoardID, periodStart, gameID, tournament.ID).Error; err != nil {
+			return fmt.Errorf("failed to write final standings: %w", err)
+		}
+
+		finalized = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return finalized, nil
+}
+
+func (row tournamentRow) toModel() *model.Tournament {
+	return &model.Tournament{
+		ID:                  row.ID,
+		Name:                row.Name,
+		BoardID:             row.BoardID,
+		RegistrationOpensAt: row.RegistrationOpensAt,
+		StartsAt:            row.StartsAt,
+		EndsAt:              row.EndsAt,
+		MaxAttempts:         row.MaxAttempts,
+		MaxEntrants:         row.MaxEntrants,
+		Entrants:            row.Entrants,
+		FinalizedAt:         row.FinalizedAt,
+		CreatedAt:           row.CreatedAt,
+	}
+}
+
+func (row entryRow) toModel() *model.Entry {
+	return &model.Entry{
+		TournamentID: row.TournamentID,
+		UserID:       row.UserID,
+		Attempts:     row.Attempts,
+		RegisteredAt: row.RegisteredAt,
+		FinalRank:    row.FinalRank,
+		FinalScore:   row.FinalScore,
+	}
+}
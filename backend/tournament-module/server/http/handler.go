@@ -0,0 +1,499 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"go.uber.org/zap"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/core"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/tournament-module/model"
+)
+
+type TournamentHandler struct {
+	core     *core.TournamentCore
+	logger   *providers.ConsoleLogger
+	newrelic *newrelic.Application
+}
+
+func NewTournamentHandler(core *core.TournamentCore, logger *providers.ConsoleLogger, newrelic *newrelic.Application) *TournamentHandler {
+	return &TournamentHandler{
+		core:     core,
+		logger:   logger,
+		newrelic: newrelic,
+	}
+}
+
+func (h *TournamentHandler) CreateTournament(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	ctx := r.Context()
+
+	var req model.CreateTournamentRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid request payload",
+			constants.ErrInvalidTournament,
+		)
+		return
+	}
+
+	resp, err := h.core.CreateTournament(ctx, &req)
+	if err != nil {
+		h.logger.Error(
+			"CreateTournament failed",
+			zap.String("tournament_id", req.ID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to create tournament",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		status := http.StatusBadRequest
+		if resp.Code == constants.ErrTournamentExists {
+			status = http.StatusConflict
+		}
+
+		h.respondWithJSON(w, status, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, resp)
+}
+
+func (h *TournamentHandler) ListTournaments(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", constants.StatusScheduled, constants.StatusOpen, constants.StatusRunning,
+		constants.StatusFinalizing, constants.StatusClosed:
+	default:
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"status must be one of scheduled, open, running, finalizing or closed",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	resp, err := h.core.ListTournaments(r.Context(), status)
+	if err != nil {
+		h.logger.Error(
+			"ListTournaments failed",
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch tournaments",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *TournamentHandler) GetTournament(w http.ResponseWriter, r *http.Request) {
+	tournamentID := mux.Vars(r)["id"]
+
+	resp, err := h.core.GetTournament(r.Context(), tournamentID)
+	if err != nil {
+		h.logger.Error(
+			"GetTournament failed",
+			zap.String("tournament_id", tournamentID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch tournament",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *TournamentHandler) Register(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	ctx := r.Context()
+	tournamentID := mux.Vars(r)["id"]
+
+	var req model.RegisterRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil || req.UserID <= 0 {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid request payload",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	resp, err := h.core.Register(ctx, tournamentID, req.UserID)
+	if err != nil {
+		h.logger.Error(
+			"Register failed",
+			zap.String("tournament_id", tournamentID),
+			zap.Int64("user_id", req.UserID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to register for tournament",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		status := http.StatusConflict
+		switch resp.Code {
+		case constants.ErrTournamentNotFound, constants.ErrUserNotFound:
+			status = http.StatusNotFound
+		case constants.ErrRegistrationClosed:
+			status = http.StatusForbidden
+		}
+
+		h.respondWithJSON(w, status, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, resp)
+}
+
+func (h *TournamentHandler) GetTopPlayers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tournamentID := mux.Vars(r)["id"]
+
+	// Default to top 10 players if limit is not specified or invalid
+	limit := 10
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		if l, err := strconv.Atoi(limitParam); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	resp, err := h.core.GetTopPlayers(ctx, tournamentID, limit)
+	if err != nil {
+		h.logger.Error(
+			"GetTournamentTopPlayers failed",
+			zap.String("tournament_id", tournamentID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch tournament leaderboard",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *TournamentHandler) GetPlayerRank(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+
+	userID, err := strconv.ParseInt(vars["user_id"], 10, 64)
+	if err != nil || userID <= 0 {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid user ID",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	resp, err := h.core.GetPlayerRank(ctx, tournamentID, userID)
+	if err != nil {
+		h.logger.Error(
+			"GetTournamentPlayerRank failed",
+			zap.String("tournament_id", tournamentID),
+			zap.Int64("user_id", userID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch tournament rank",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *TournamentHandler) CreateBracket(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	ctx := r.Context()
+	tournamentID := mux.Vars(r)["id"]
+
+	var req model.CreateBracketRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid request payload",
+			constants.ErrInvalidBracket,
+		)
+		return
+	}
+
+	resp, err := h.core.CreateBracket(ctx, tournamentID, &req)
+	if err != nil {
+		h.logger.Error(
+			"CreateBracket failed",
+			zap.String("tournament_id", tournamentID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to create bracket",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		status := http.StatusBadRequest
+		switch resp.Code {
+		case constants.ErrTournamentNotFound:
+			status = http.StatusNotFound
+		case constants.ErrBracketExists:
+			status = http.StatusConflict
+		}
+
+		h.respondWithJSON(w, status, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, resp)
+}
+
+func (h *TournamentHandler) GetBracket(w http.ResponseWriter, r *http.Request) {
+	tournamentID := mux.Vars(r)["id"]
+
+	resp, err := h.core.GetBracket(r.Context(), tournamentID)
+	if err != nil {
+		h.logger.Error(
+			"GetBracket failed",
+			zap.String("tournament_id", tournamentID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch bracket",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *TournamentHandler) ReportResult(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+
+	matchID, err := strconv.Atoi(vars["match_id"])
+	if err != nil || matchID <= 0 {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid match ID",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	var req model.ReportResultRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid request payload",
+			constants.ErrInvalidResult,
+		)
+		return
+	}
+
+	resp, err := h.core.ReportResult(ctx, tournamentID, matchID, &req)
+	if err != nil {
+		h.logger.Error(
+			"ReportResult failed",
+			zap.String("tournament_id", tournamentID),
+			zap.Int("match_id", matchID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to report match result",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, matchErrorStatus(resp.Code), resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *TournamentHandler) ScheduleMatch(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+
+	matchID, err := strconv.Atoi(vars["match_id"])
+	if err != nil || matchID <= 0 {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid match ID",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	var req model.ScheduleMatchRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil || req.ScheduledAt.IsZero() {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid request payload",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	resp, err := h.core.ScheduleMatch(ctx, tournamentID, matchID, req.ScheduledAt)
+	if err != nil {
+		h.logger.Error(
+			"ScheduleMatch failed",
+			zap.String("tournament_id", tournamentID),
+			zap.Int("match_id", matchID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to schedule match",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, matchErrorStatus(resp.Code), resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func matchErrorStatus(code string) int {
+	switch code {
+	case constants.ErrTournamentNotFound, constants.ErrBracketNotFound, constants.ErrMatchNotFound:
+		return http.StatusNotFound
+	case constants.ErrMatchNotReady:
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
+
+func (h *TournamentHandler) respondWithJSON(
+	w http.ResponseWriter,
+	status int,
+	payload interface{},
+) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func (h *TournamentHandler) respondWithError(
+	w http.ResponseWriter,
+	status int,
+	message string,
+	code string,
+) {
+	h.respondWithJSON(w, status, map[string]interface{}{
+		"success": false,
+		"error":   message,
+		"code":    code,
+	})
+}
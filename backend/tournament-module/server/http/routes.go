@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+func (h *TournamentHandler) RegisterRoutes(router *mux.Router) {
+	// Tournament definition endpoints
+	_, createHandler := newrelic.WrapHandle(h.newrelic, "api/tournaments/create", http.HandlerFunc(h.CreateTournament))
+	router.Handle("/api/tournaments", createHandler).Methods(http.MethodPost)
+
+	_, listHandler := newrelic.WrapHandle(h.newrelic, "api/tournaments", http.HandlerFunc(h.ListTournaments))
+	router.Handle("/api/tournaments", listHandler).Methods(http.MethodGet)
+
+	_, getHandler := newrelic.WrapHandle(h.newrelic, "api/tournaments/{id}", http.HandlerFunc(h.GetTournament))
+	router.Handle("/api/tournaments/{id}", getHandler).Methods(http.MethodGet)
+
+	// Registration endpoint
+	_, registerHandler := newrelic.WrapHandle(h.newrelic, "api/tournaments/{id}/entries", http.HandlerFunc(h.Register))
+	router.Handle("/api/tournaments/{id}/entries", registerHandler).Methods(http.MethodPost)
+
+	// Event-scoped standings endpoints
+	_, topHandler := newrelic.WrapHandle(h.newrelic, "api/tournaments/{id}/top", http.HandlerFunc(h.GetTopPlayers))
+	router.Handle("/api/tournaments/{id}/top", topHandler).Methods(http.MethodGet)
+
+	_, rankHandler := newrelic.WrapHandle(h.newrelic, "api/tournaments/{id}/rank/{user_id}", http.HandlerFunc(h.GetPlayerRank))
+	router.Handle("/api/tournaments/{id}/rank/{user_id}", rankHandler).Methods(http.MethodGet)
+
+	// Bracket endpoints
+	_, createBracketHandler := newrelic.WrapHandle(h.newrelic, "api/tournaments/{id}/bracket/create", http.HandlerFunc(h.CreateBracket))
+	router.Handle("/api/tournaments/{id}/bracket", createBracketHandler).Methods(http.MethodPost)
+
+	_, bracketHandler := newrelic.WrapHandle(h.newrelic, "api/tournaments/{id}/bracket", http.HandlerFunc(h.GetBracket))
+	router.Handle("/api/tournaments/{id}/bracket", bracketHandler).Methods(http.MethodGet)
+
+	_, resultHandler := newrelic.WrapHandle(h.newrelic, "api/tournaments/{id}/bracket/matches/{match_id}/result", http.HandlerFunc(h.ReportResult))
+	router.Handle("/api/tournaments/{id}/bracket/matches/{match_id}/result", resultHandler).Methods(http.MethodPost)
+
+	_, scheduleHandler := newrelic.WrapHandle(h.newrelic, "api/tournaments/{id}/bracket/matches/{match_id}/schedule", http.HandlerFunc(h.ScheduleMatch))
+	router.Handle("/api/tournaments/{id}/bracket/matches/{match_id}/schedule", scheduleHandler).Methods(http.MethodPost)
+}
@@ -1 +1,9 @@
 package constants
+
+// Friendship statuses, always from the point of view of the row's user_id.
+const (
+	FriendshipRequested = "requested"
+	FriendshipPending   = "pending"
+	FriendshipAccepted  = "accepted"
+	FriendshipBlocked   = "blocked"
+)
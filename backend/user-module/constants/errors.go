@@ -1 +1,11 @@
 package constants
+
+const (
+	ErrUserNotFound   = "USER_NOT_FOUND"
+	ErrInternalServer = "INTERNAL_SERVER_ERROR"
+	ErrInvalidRequest = "INVALID_REQUEST"
+
+	ErrFriendshipNotFound = "FRIENDSHIP_NOT_FOUND"
+	ErrAlreadyFriends     = "ALREADY_FRIENDS"
+	ErrUserBlocked        = "USER_BLOCKED"
+)
@@ -1,15 +1,25 @@
 package core
 
 import (
+	"context"
 	"fmt"
+	"math"
 
 	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/constants"
 	userDataMapper "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/datamapper"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/model"
 	userRepository "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/repository"
 	"gorm.io/gorm"
 )
 
 type ICore interface {
+	GetPlayerStats(ctx context.Context, userID int64) (*model.PlayerStatsResponse, error)
+	RequestFriendship(ctx context.Context, userID, friendID int64) (*model.FriendshipResponse, error)
+	AcceptFriendship(ctx context.Context, userID, friendID int64) (*model.FriendshipResponse, error)
+	RemoveFriendship(ctx context.Context, userID, friendID int64) (*model.FriendshipResponse, error)
+	BlockUser(ctx context.Context, userID, friendID int64) (*model.FriendshipResponse, error)
+	ListFriends(ctx context.Context, userID int64, status string) (*model.FriendsResponse, error)
 }
 
 type Core struct {
@@ -40,3 +50,67 @@ func NewCore(db *gorm.DB, logger providers.LoggerInterface) (*Core, error) {
 		Logger: &logger,
 	}, nil
 }
+
+// GetPlayerStats returns the aggregated statistics and per-mode breakdown for a user.
+func (c *Core) GetPlayerStats(ctx context.Context, userID int64) (*model.PlayerStatsResponse, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID")
+	}
+
+	repo := *c.Repository
+
+	stats, err := repo.GetPlayerStats(ctx, userID)
+	if err != nil {
+		if err.Error() == constants.ErrUserNotFound {
+			return &model.PlayerStatsResponse{
+				Success: false,
+				Error:   "User not found",
+				Code:    constants.ErrUserNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+
+	modeRows, err := repo.GetPlayerModeStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	modes := make([]model.ModeStats, 0, len(modeRows))
+	for _, row := range modeRows {
+		modes = append(modes, model.ModeStats{
+			GameMode:     row.GameMode,
+			GamesPlayed:  row.GamesPlayed,
+			TotalScore:   row.TotalScore,
+			BestScore:    row.BestScore,
+			AverageScore: ratio(float64(row.TotalScore), row.GamesPlayed),
+			Wins:         row.Wins,
+			WinRate:      ratio(float64(row.Wins), row.DecidedGames),
+		})
+	}
+
+	return &model.PlayerStatsResponse{
+		Success: true,
+		Data: &model.PlayerStats{
+			UserID:        stats.UserID,
+			GamesPlayed:   stats.GamesPlayed,
+			TotalScore:    stats.TotalScore,
+			BestScore:     stats.BestScore,
+			AverageScore:  ratio(float64(stats.TotalScore), stats.GamesPlayed),
+			Wins:          stats.Wins,
+			WinRate:       ratio(float64(stats.Wins), stats.DecidedGames),
+			CurrentStreak: stats.CurrentStreak,
+			LongestStreak: stats.LongestStreak,
+			LastPlayedAt:  stats.LastPlayedAt,
+			Modes:         modes,
+		},
+	}, nil
+}
+
+// ratio divides and rounds to two decimals, returning 0 when there is nothing to divide by.
+func ratio(value float64, count int) float64 {
+	if count <= 0 {
+		return 0
+	}
+	return math.Round(value/float64(count)*100) / 100
+}
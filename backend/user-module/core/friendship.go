@@ -0,0 +1,151 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/model"
+)
+
+var friendshipErrors = map[string]string{
+	constants.ErrFriendshipNotFound: "Friendship not found",
+	constants.ErrAlreadyFriends:     "Users are already friends",
+	constants.ErrUserBlocked:        "Friendship is blocked",
+	constants.ErrUserNotFound:       "User not found",
+}
+
+func (c *Core) RequestFriendship(ctx context.Context, userID, friendID int64) (*model.FriendshipResponse, error) {
+	if resp, err := c.checkPair(ctx, userID, friendID); resp != nil || err != nil {
+		return resp, err
+	}
+
+	status, err := (*c.Repository).RequestFriendship(ctx, userID, friendID)
+	if err != nil {
+		return friendshipFailure(err)
+	}
+
+	message := "Friend request sent"
+	if status == constants.FriendshipAccepted {
+		message = "Friend request accepted"
+	}
+
+	return &model.FriendshipResponse{
+		Success: true,
+		Status:  status,
+		Message: message,
+	}, nil
+}
+
+func (c *Core) AcceptFriendship(ctx context.Context, userID, friendID int64) (*model.FriendshipResponse, error) {
+	if err := (*c.Repository).AcceptFriendship(ctx, userID, friendID); err != nil {
+		return friendshipFailure(err)
+	}
+
+	return &model.FriendshipResponse{
+		Success: true,
+		Status:  constants.FriendshipAccepted,
+		Message: "Friend request accepted",
+	}, nil
+}
+
+func (c *Core) RemoveFriendship(ctx context.Context, userID, friendID int64) (*model.FriendshipResponse, error) {
+	if err := (*c.Repository).RemoveFriendship(ctx, userID, friendID); err != nil {
+		return friendshipFailure(err)
+	}
+
+	return &model.FriendshipResponse{
+		Success: true,
+		Message: "Friendship removed",
+	}, nil
+}
+
+func (c *Core) BlockUser(ctx context.Context, userID, friendID int64) (*model.FriendshipResponse, error) {
+	if resp, err := c.checkPair(ctx, userID, friendID); resp != nil || err != nil {
+		return resp, err
+	}
+
+	if err := (*c.Repository).BlockUser(ctx, userID, friendID); err != nil {
+		return nil, err
+	}
+
+	return &model.FriendshipResponse{
+		Success: true,
+		Status:  constants.FriendshipBlocked,
+		Message: "User blocked",
+	}, nil
+}
+
+func (c *Core) ListFriends(ctx context.Context, userID int64, status string) (*model.FriendsResponse, error) {
+	switch status {
+	case constants.FriendshipAccepted, constants.FriendshipPending,
+		constants.FriendshipRequested, constants.FriendshipBlocked:
+	default:
+		return &model.FriendsResponse{
+			Success: false,
+			Error:   "Unknown friendship status",
+			Code:    constants.ErrInvalidRequest,
+		}, nil
+	}
+
+	rows, err := (*c.Repository).ListFriends(ctx, userID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	friends := make([]model.Friend, 0, len(rows))
+	for _, row := range rows {
+		friends = append(friends, model.Friend{
+			UserID:    row.FriendID,
+			Username:  row.Username,
+			Status:    row.Status,
+			UpdatedAt: row.UpdatedAt,
+		})
+	}
+
+	return &model.FriendsResponse{
+		Success: true,
+		Friends: friends,
+	}, nil
+}
+
+// checkPair rejects self-friendships and unknown users before touching the graph.
+func (c *Core) checkPair(ctx context.Context, userID, friendID int64) (*model.FriendshipResponse, error) {
+	if userID == friendID {
+		return &model.FriendshipResponse{
+			Success: false,
+			Error:   "Users cannot befriend themselves",
+			Code:    constants.ErrInvalidRequest,
+		}, nil
+	}
+
+	for _, id := range []int64{userID, friendID} {
+		exists, err := (*c.Repository).UserExists(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return &model.FriendshipResponse{
+				Success: false,
+				Error:   fmt.Sprintf("User %d not found", id),
+				Code:    constants.ErrUserNotFound,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// friendshipFailure turns known repository errors into unsuccessful responses.
+func friendshipFailure(err error) (*model.FriendshipResponse, error) {
+	message, ok := friendshipErrors[err.Error()]
+	if !ok {
+		return nil, err
+	}
+
+	return &model.FriendshipResponse{
+		Success: false,
+		Error:   message,
+		Code:    err.Error(),
+	}, nil
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+type FriendRequest struct {
+	FriendID int64 `json:"friend_id"`
+}
+
+type Friend struct {
+	UserID    int64     `json:"user_id"`
+	Username  string    `json:"username"`
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type FriendsResponse struct {
+	Success bool     `json:"success"`
+	Friends []Friend `json:"friends"`
+	Error   string   `json:"error,omitempty"`
+	Code    string   `json:"code,omitempty"`
+}
+
+type FriendshipResponse struct {
+	Success bool   `json:"success"`
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+type PlayerStats struct {
+	UserID        int64       `json:"user_id"`
+	GamesPlayed   int         `json:"games_played"`
+	TotalScore    int64       `json:"total_score"`
+	BestScore     int64       `json:"best_score"`
+	AverageScore  float64     `json:"average_score"`
+	Wins          int         `json:"wins"`
+	WinRate       float64     `json:"win_rate"`
+	CurrentStreak int         `json:"current_streak"`
+	LongestStreak int         `json:"longest_streak"`
+	LastPlayedAt  *time.Time  `json:"last_played_at,omitempty"`
+	Modes         []ModeStats `json:"modes"`
+}
+
+type ModeStats struct {
+	GameMode     string  `json:"game_mode"`
+	GamesPlayed  int     `json:"games_played"`
+	TotalScore   int64   `json:"total_score"`
+	BestScore    int64   `json:"best_score"`
+	AverageScore float64 `json:"average_score"`
+	Wins         int     `json:"wins"`
+	WinRate      float64 `json:"win_rate"`
+}
+
+type PlayerStatsResponse struct {
+	Success bool         `json:"success"`
+	Data    *PlayerStats `json:"data,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Code    string       `json:"code,omitempty"`
+}
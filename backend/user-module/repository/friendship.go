@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/constants"
+	"gorm.io/gorm"
+)
+
+type FriendRow struct {
+	FriendID  int64     `gorm:"column:friend_id"`
+	Username  string    `gorm:"column:username"`
+	Status    string    `gorm:"column:status"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+type friendshipEdge struct {
+	UserID   int64  `gorm:"column:user_id"`
+	FriendID int64  `gorm:"column:friend_id"`
+	Status   string `gorm:"column:status"`
+}
+
+func (r *Repository) UserExists(ctx context.Context, userID int64) (bool, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	if err := r.DB.WithContext(ctx).Raw(
+		`SELECT EXISTS (SELECT 1 FROM gaming.users WHERE game_id = ? AND id = ?)`,
+		gameID,
+		userID,
+	).Scan(&exists).Error; err != nil {
+		return false, fmt.Errorf("failed to check user: %w", err)
+	}
+	return exists, nil
+}
+
+// RequestFriendship sends a request from userID to friendID and returns the
+// resulting status. A request towards someone who already asked us is
+// accepted straight away; blocks in either direction reject the request.
+func (r *Repository) RequestFriendship(ctx context.Context, userID, friendID int64) (string, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	status := constants.FriendshipRequested
+
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The pair may have no rows yet for FOR UPDATE to lock, so requests
+		// in both directions are serialized on a lock of the pair itself;
+		// otherwise two crossing requests would both end up pending
+		if err := lockPair(tx, gameID, userID, friendID); err != nil {
+			return err
+		}
+
+		var edges []friendshipEdge
+		if err := tx.Raw(`
+			SELECT user_id, friend_id, status
+			FROM gaming.friendships
+			WHERE game_id = ?
+				AND ((user_id = ? AND friend_id = ?) OR (user_id = ? AND friend_id = ?))
+			FOR UPDATE
+		`, gameID, userID, friendID, friendID, userID).Scan(&edges).Error; err != nil {
+			return err
+		}
+
+		var own string
+		for _, edge := range edges {
+			if edge.Status == constants.FriendshipBlocked {
+				return errors.New(constants.ErrUserBlocked)
+			}
+			if edge.UserID == userID {
+				own = edge.Status
+			}
+		}
+
+		switch own {
+		case constants.FriendshipAccepted:
+			return errors.New(constants.ErrAlreadyFriends)
+		case constants.FriendshipRequested:
+			return nil
+		case constants.FriendshipPending:
+			status = constants.FriendshipAccepted
+			return setMutualStatus(tx, gameID, userID, friendID, constants.FriendshipAccepted)
+		}
+
+		return tx.Exec(`
+			INSERT INTO gaming.friendships (game_id, user_id, friend_id, status)
+			VALUES (?, ?, ?, ?), (?, ?, ?, ?)
+			ON CONFLICT (user_id, friend_id) DO NOTHING
+		`, gameID, userID, friendID, constants.FriendshipRequested,
+			gameID, friendID, userID, constants.FriendshipPending).Error
+	})
+	if err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+// AcceptFriendship accepts a pending request friendID sent to userID.
+func (r *Repository) AcceptFriendship(ctx context.Context, userID, friendID int64) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`
+			UPDATE gaming.friendships
+			SET status = ?, updated_at = NOW()
+			WHERE game_id = ? AND user_id = ? AND friend_id = ? AND status = ?
+		`, constants.FriendshipAccepted, gameID, userID, friendID, constants.FriendshipPending)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New(constants.ErrFriendshipNotFound)
+		}
+
+		return tx.Exec(`
+			UPDATE gaming.friendships
+			SET status = ?, updated_at = NOW()
+			WHERE game_id = ? AND user_id = ? AND friend_id = ? AND status = ?
+		`, constants.FriendshipAccepted, gameID, friendID, userID, constants.FriendshipRequested).Error
+	})
+}
+
+// RemoveFriendship removes whatever relationship userID has with friendID:
+// unfriend, cancel or decline a request, or lift a block. Blocks placed by
+// friendID stay in place.
+func (r *Repository) RemoveFriendship(ctx context.Context, userID, friendID int64) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`
+			DELETE FROM gaming.friendships WHERE game_id = ? AND user_id = ? AND friend_id = ?
+		`, gameID, userID, friendID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New(constants.ErrFriendshipNotFound)
+		}
+
+		return tx.Exec(`
+			DELETE FROM gaming.friendships
+			WHERE game_id = ? AND user_id = ? AND friend_id = ? AND status <> ?
+		`, gameID, friendID, userID, constants.FriendshipBlocked).Error
+	})
+}
+
+// BlockUser blocks friendID for userID and drops any friendship or request
+// between them.
+func (r *Repository) BlockUser(ctx context.Context, userID, friendID int64) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialized with requests, which could otherwise slip in between
+		if err := lockPair(tx, gameID, userID, friendID); err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`
+			INSERT INTO gaming.friendships (game_id, user_id, friend_id, status)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (user_id, friend_id)
+			DO UPDATE SET status = EXCLUDED.status, updated_at = NOW()
+		`, gameID, userID, friendID, constants.FriendshipBlocked).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			DELETE FROM gaming.friendships
+			WHERE game_id = ? AND user_id = ? AND friend_id = ? AND status <> ?
+		`, gameID, friendID, userID, constants.FriendshipBlocked).Error
+	})
+}
+
+func (r *Repository) ListFriends(ctx context.Context, userID int64, status string) ([]FriendRow, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []FriendRow
+	err = r.DB.WithContext(ctx).Raw(`
+		SELECT f.friend_id, u.username, f.status, f.updated_at
+		FROM gaming.friendships f
+		JOIN gaming.users u ON u.game_id = f.game_id AND u.id = f.friend_id
+		WHERE f.game_id = ? AND f.user_id = ? AND f.status = ?
+		ORDER BY u.username
+	`, gameID, userID, status).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list friends: %w", err)
+	}
+	return rows, nil
+}
+
+func setMutualStatus(tx *gorm.DB, gameID string, userID, friendID int64, status string) error {
+	return tx.Exec(`
+		UPDATE gaming.friendships SET status = ?, updated_at = NOW()
+		WHERE game_id = ?
+			AND ((user_id = ? AND friend_id = ?) OR (user_id = ? AND friend_id = ?))
+	`, status, gameID, userID, friendID, friendID, userID).Error
+}
+
+// lockPair takes a transaction-scoped lock on the friendship of two users,
+// keyed the same whichever of them is userID.
+func lockPair(tx *gorm.DB, gameID string, userID, friendID int64) error {
+	low, high := min(userID, friendID), max(userID, friendID)
+	return tx.Exec(
+		`SELECT pg_advisory_xact_lock(hashtextextended(?, 0))`,
+		fmt.Sprintf("friendship:%s:%d:%d", gameID, low, high),
+	).Error
+}
@@ -1,16 +1,53 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
 	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
 
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/constants"
 	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/model"
 	"gorm.io/gorm"
 )
 
+// StatsRow mirrors a gaming.player_stats row; users without sessions get zero values.
+type StatsRow struct {
+	UserID        int64      `gorm:"column:user_id"`
+	GamesPlayed   int        `gorm:"column:games_played"`
+	TotalScore    int64      `gorm:"column:total_score"`
+	BestScore     int64      `gorm:"column:best_score"`
+	Wins          int        `gorm:"column:wins"`
+	DecidedGames  int        `gorm:"column:decided_games"`
+	CurrentStreak int        `gorm:"column:current_streak"`
+	LongestStreak int        `gorm:"column:longest_streak"`
+	LastPlayedAt  *time.Time `gorm:"column:last_played_at"`
+}
+
+// ModeStatsRow mirrors a gaming.player_mode_stats row.
+type ModeStatsRow struct {
+	GameMode     string `gorm:"column:game_mode"`
+	GamesPlayed  int    `gorm:"column:games_played"`
+	TotalScore   int64  `gorm:"column:total_score"`
+	BestScore    int64  `gorm:"column:best_score"`
+	Wins         int    `gorm:"column:wins"`
+	DecidedGames int    `gorm:"column:decided_games"`
+}
+
 // IUserRepository defines the interface for user repository
-type IUserRepository interface{}
+type IUserRepository interface {
+	GetPlayerStats(ctx context.Context, userID int64) (*StatsRow, error)
+	GetPlayerModeStats(ctx context.Context, userID int64) ([]ModeStatsRow, error)
+	UserExists(ctx context.Context, userID int64) (bool, error)
+	RequestFriendship(ctx context.Context, userID, friendID int64) (string, error)
+	AcceptFriendship(ctx context.Context, userID, friendID int64) error
+	RemoveFriendship(ctx context.Context, userID, friendID int64) error
+	BlockUser(ctx context.Context, userID, friendID int64) error
+	ListFriends(ctx context.Context, userID int64, status string) ([]FriendRow, error)
+}
 
 // Repository implements IUserRepository
 type Repository struct {
@@ -40,3 +77,57 @@ func NewRepository(db *gorm.DB, logger providers.LoggerInterface) IUserRepositor
 		Logger: &logger,
 	}
 }
+
+// GetPlayerStats reads the maintained stats row for a user. The users table
+// drives the query so that existing players without sessions return zeros.
+func (r *Repository) GetPlayerStats(ctx context.Context, userID int64) (*StatsRow, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []StatsRow
+	err = r.DB.WithContext(ctx).Raw(`
+		SELECT
+			u.id AS user_id,
+			COALESCE(ps.games_played, 0)   AS games_played,
+			COALESCE(ps.total_score, 0)    AS total_score,
+			COALESCE(ps.best_score, 0)     AS best_score,
+			COALESCE(ps.wins, 0)           AS wins,
+			COALESCE(ps.decided_games, 0)  AS decided_games,
+			COALESCE(ps.current_streak, 0) AS current_streak,
+			COALESCE(ps.longest_streak, 0) AS longest_streak,
+			ps.last_played_at
+		FROM gaming.users u
+		LEFT JOIN gaming.player_stats ps ON ps.game_id = u.game_id AND ps.user_id = u.id
+		WHERE u.game_id = ? AND u.id = ?
+	`, gameID, userID).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player stats: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New(constants.ErrUserNotFound)
+	}
+
+	return &rows[0], nil
+}
+
+func (r *Repository) GetPlayerModeStats(ctx context.Context, userID int64) ([]ModeStatsRow, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []ModeStatsRow
+	err = r.DB.WithContext(ctx).
+		Table("gaming.player_mode_stats").
+		Select("game_mode, games_played, total_score, best_score, wins, decided_games").
+		Where("game_id = ? AND user_id = ?", gameID, userID).
+		Order("game_mode").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player mode stats: %w", err)
+	}
+
+	return rows, nil
+}
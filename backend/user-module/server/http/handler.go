@@ -1,9 +1,13 @@
 package http
 
 import (
+	"encoding/json"
 	"net/http"
+	"strconv"
 
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/constants"
 	core "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/core"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/user-module/model"
 	"github.com/gorilla/mux"
 )
 
@@ -26,6 +30,13 @@ func NewUserHttpExtension(router *mux.Router, core *core.Core) *UserHttpExtensio
 // Init initializes the handler (to be called from main.go)
 func (he *UserHttpExtension) Init() {
 	he.Router.HandleFunc("/ping", he.Ping).Methods("GET")
+	he.Router.HandleFunc("/api/users/{id}/stats", he.GetPlayerStats).Methods("GET")
+
+	he.Router.HandleFunc("/api/users/{id}/friends", he.ListFriends).Methods("GET")
+	he.Router.HandleFunc("/api/users/{id}/friends/requests", he.RequestFriendship).Methods("POST")
+	he.Router.HandleFunc("/api/users/{id}/friends/{friend_id}/accept", he.AcceptFriendship).Methods("POST")
+	he.Router.HandleFunc("/api/users/{id}/friends/{friend_id}/block", he.BlockUser).Methods("POST")
+	he.Router.HandleFunc("/api/users/{id}/friends/{friend_id}", he.RemoveFriendship).Methods("DELETE")
 }
 
 // Ping handles the ping endpoint
@@ -34,3 +45,171 @@ func (he *UserHttpExtension) Ping(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte(`{"status": "ok", "message": "pong"}`))
 }
+
+// GetPlayerStats handles GET /api/users/{id}/stats
+func (he *UserHttpExtension) GetPlayerStats(w http.ResponseWriter, r *http.Request) {
+	userID, ok := he.parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := he.Core.GetPlayerStats(r.Context(), userID)
+	if err != nil {
+		(*he.Core.Logger).Errorf("GetPlayerStats failed | user_id=%d error=%v", userID, err)
+		he.respondWithError(w, http.StatusInternalServerError, "Failed to fetch player stats", constants.ErrInternalServer)
+		return
+	}
+
+	if !resp.Success {
+		he.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	he.respondWithJSON(w, http.StatusOK, resp)
+}
+
+// ListFriends handles GET /api/users/{id}/friends?status=accepted|pending|requested|blocked
+func (he *UserHttpExtension) ListFriends(w http.ResponseWriter, r *http.Request) {
+	userID, ok := he.parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = constants.FriendshipAccepted
+	}
+
+	resp, err := he.Core.ListFriends(r.Context(), userID, status)
+	if err != nil {
+		(*he.Core.Logger).Errorf("ListFriends failed | user_id=%d error=%v", userID, err)
+		he.respondWithError(w, http.StatusInternalServerError, "Failed to list friends", constants.ErrInternalServer)
+		return
+	}
+
+	if !resp.Success {
+		he.respondWithJSON(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	he.respondWithJSON(w, http.StatusOK, resp)
+}
+
+// RequestFriendship handles POST /api/users/{id}/friends/requests
+func (he *UserHttpExtension) RequestFriendship(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	userID, ok := he.parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.FriendRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil || req.FriendID <= 0 {
+		he.respondWithError(w, http.StatusBadRequest, "Invalid request payload", constants.ErrInvalidRequest)
+		return
+	}
+
+	resp, err := he.Core.RequestFriendship(r.Context(), userID, req.FriendID)
+	he.respondWithFriendship(w, "RequestFriendship", userID, resp, err)
+}
+
+// AcceptFriendship handles POST /api/users/{id}/friends/{friend_id}/accept
+func (he *UserHttpExtension) AcceptFriendship(w http.ResponseWriter, r *http.Request) {
+	userID, friendID, ok := he.parseFriendPair(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := he.Core.AcceptFriendship(r.Context(), userID, friendID)
+	he.respondWithFriendship(w, "AcceptFriendship", userID, resp, err)
+}
+
+// BlockUser handles POST /api/users/{id}/friends/{friend_id}/block
+func (he *UserHttpExtension) BlockUser(w http.ResponseWriter, r *http.Request) {
+	userID, friendID, ok := he.parseFriendPair(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := he.Core.BlockUser(r.Context(), userID, friendID)
+	he.respondWithFriendship(w, "BlockUser", userID, resp, err)
+}
+
+// RemoveFriendship handles DELETE /api/users/{id}/friends/{friend_id}
+func (he *UserHttpExtension) RemoveFriendship(w http.ResponseWriter, r *http.Request) {
+	userID, friendID, ok := he.parseFriendPair(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := he.Core.RemoveFriendship(r.Context(), userID, friendID)
+	he.respondWithFriendship(w, "RemoveFriendship", userID, resp, err)
+}
+
+func (he *UserHttpExtension) respondWithFriendship(
+	w http.ResponseWriter,
+	operation string,
+	userID int64,
+	resp *model.FriendshipResponse,
+	err error,
+) {
+	if err != nil {
+		(*he.Core.Logger).Errorf("%s failed | user_id=%d error=%v", operation, userID, err)
+		he.respondWithError(w, http.StatusInternalServerError, "Failed to update friendship", constants.ErrInternalServer)
+		return
+	}
+
+	if !resp.Success {
+		status := http.StatusBadRequest
+		switch resp.Code {
+		case constants.ErrUserNotFound, constants.ErrFriendshipNotFound:
+			status = http.StatusNotFound
+		case constants.ErrAlreadyFriends, constants.ErrUserBlocked:
+			status = http.StatusConflict
+		}
+		he.respondWithJSON(w, status, resp)
+		return
+	}
+
+	he.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (he *UserHttpExtension) parseFriendPair(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	userID, ok := he.parseUserID(w, r)
+	if !ok {
+		return 0, 0, false
+	}
+
+	friendID, err := strconv.ParseInt(mux.Vars(r)["friend_id"], 10, 64)
+	if err != nil || friendID <= 0 {
+		he.respondWithError(w, http.StatusBadRequest, "Invalid friend ID", constants.ErrInvalidRequest)
+		return 0, 0, false
+	}
+	return userID, friendID, true
+}
+
+func (he *UserHttpExtension) parseUserID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	userID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || userID <= 0 {
+		he.respondWithError(w, http.StatusBadRequest, "Invalid user ID", constants.ErrInvalidRequest)
+		return 0, false
+	}
+	return userID, true
+}
+
+func (he *UserHttpExtension) respondWithJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func (he *UserHttpExtension) respondWithError(w http.ResponseWriter, status int, message string, code string) {
+	he.respondWithJSON(w, status, map[string]interface{}{
+		"success": false,
+		"error":   message,
+		"code":    code,
+	})
+}
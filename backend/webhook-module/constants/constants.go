@@ -0,0 +1,35 @@
+package constants
+
+// Event types webhooks can subscribe to.
+const (
+	EventEnteredTop  = "player.entered_top"
+	EventSeasonEnded = "season.ended"
+	// EventTest is sent on request to check a receiver; it needs no subscription.
+	EventTest = "webhook.test"
+)
+
+// Delivery states. A delivery that failed MaxDeliveryAttempts times is dead
+// and only retried on request.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+	DeliveryAll       = "all"
+)
+
+const (
+	DefaultTopN = 10
+	MaxTopN     = 1000
+
+	// MaxWebhooksPerGame caps the subscriptions of a single game.
+	MaxWebhooksPerGame = 20
+
+	MaxDeliveryAttempts  = 8
+	MaxDeliveriesPerPage = 100
+	DeliveryBatchSize    = 50
+
+	// SeasonStandingsSize is how many final standings a season.ended event carries.
+	SeasonStandingsSize = 10
+
+	MinSecretLength = 16
+)
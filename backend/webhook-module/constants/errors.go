@@ -0,0 +1,12 @@
+package constants
+
+const (
+	ErrWebhookNotFound  = "WEBHOOK_NOT_FOUND"
+	ErrInvalidWebhook   = "INVALID_WEBHOOK"
+	ErrTooManyWebhooks  = "TOO_MANY_WEBHOOKS"
+	ErrDeliveryNotFound = "DELIVERY_NOT_FOUND"
+	ErrDeliveryNotDead  = "DELIVERY_NOT_DEAD"
+	ErrBoardNotFound    = "BOARD_NOT_FOUND"
+	ErrInternalServer   = "INTERNAL_SERVER_ERROR"
+	ErrInvalidRequest   = "INVALID_REQUEST"
+)
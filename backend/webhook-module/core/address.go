@@ -0,0 +1,85 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// errPrivateAddress refuses a connection to an address inside the network
+// the service runs in.
+var errPrivateAddress = errors.New("webhook receivers must have a public address")
+
+// sharedAddressSpace is the carrier-grade NAT range, which some clouds use
+// for internal endpoints and net.IP does not count as private.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// publicAddress reports whether deliveries may be sent to ip. Loopback,
+// private, link-local (including cloud metadata endpoints), unspecified and
+// multicast addresses are refused.
+func publicAddress(ip net.IP) bool {
+	return !(ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() ||
+		sharedAddressSpace.Contains(ip))
+}
+
+// dialControl refuses connections to non-public addresses. It runs after
+// name resolution, for every address dialed, so a receiver whose name later
+// resolves somewhere else cannot reach inside either.
+func dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !publicAddress(ip) {
+		return fmt.Errorf("%w: %s", errPrivateAddress, host)
+	}
+	return nil
+}
+
+// newDeliveryClient returns the client deliveries are sent with. Unless
+// allowPrivate is set, it only dials public addresses and never goes through
+// a proxy, whose address the check would see instead of the receiver's. It
+// does not follow redirects: a 3xx answer counts as a failed delivery.
+func newDeliveryClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if !allowPrivate {
+		dialer.Control = dialControl
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkReceiverHost returns a human readable problem with a receiver host, or
+// "". Only IP literals are checked here; names are checked when deliveries
+// dial them, so creating a webhook does not depend on DNS.
+func checkReceiverHost(host string, allowPrivate bool) string {
+	if allowPrivate {
+		return ""
+	}
+	if ip := net.ParseIP(host); ip != nil && !publicAddress(ip) {
+		return "url must not point to a private, loopback or link-local address"
+	}
+	return ""
+}
@@ -0,0 +1,416 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	leaderboardConstants "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	leaderboardModel "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/repository"
+)
+
+type IWebhookCore interface {
+	CreateWebhook(ctx context.Context, req *model.CreateWebhookRequest) (*model.WebhookResponse, error)
+	ListWebhooks(ctx context.Context) (*model.WebhooksResponse, error)
+	GetWebhook(ctx context.Context, webhookID int64) (*model.WebhookResponse, error)
+	UpdateWebhook(ctx context.Context, webhookID int64, req *model.UpdateWebhookRequest) (*model.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, webhookID int64) (*model.WebhookResponse, error)
+	SendTestEvent(ctx context.Context, webhookID int64) (*model.DeliveryResponse, error)
+	ListDeliveries(ctx context.Context, webhookID int64, status string) (*model.DeliveriesResponse, error)
+	RetryDelivery(ctx context.Context, webhookID, deliveryID int64) (*model.DeliveryResponse, error)
+	Publish(ctx context.Context, event *leaderboardModel.DomainEvent) error
+}
+
+// subscribableEvents are the event types a webhook can list.
+var subscribableEvents = map[string]bool{
+	constants.EventEnteredTop:  true,
+	constants.EventSeasonEnded: true,
+}
+
+type WebhookCore struct {
+	repo   *repository.WebhookRepository
+	logger *providers.ConsoleLogger
+	// allowPrivateReceivers lets webhooks point at loopback and private
+	// addresses, e.g. a receiver on localhost during development.
+	allowPrivateReceivers bool
+}
+
+func NewWebhookCore(repo *repository.WebhookRepository, logger *providers.ConsoleLogger, allowPrivateReceivers bool) *WebhookCore {
+	return &WebhookCore{
+		repo:                  repo,
+		logger:                logger,
+		allowPrivateReceivers: allowPrivateReceivers,
+	}
+}
+
+// CreateWebhook subscribes a URL to leaderboard events. The secret that signs
+// its deliveries is returned only in this response.
+func (c *WebhookCore) CreateWebhook(ctx context.Context, req *model.CreateWebhookRequest) (*model.WebhookResponse, error) {
+	now := time.Now().UTC()
+	webhook := &model.Webhook{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		BoardID:    req.BoardID,
+		TopN:       req.TopN,
+		Active:     true,
+		Secret:     req.Secret,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if webhook.TopN == 0 {
+		webhook.TopN = constants.DefaultTopN
+	}
+
+	if message := validateWebhook(webhook, c.allowPrivateReceivers); message != "" {
+		return invalidWebhook(message), nil
+	}
+	if webhook.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		webhook.Secret = secret
+	} else if len(webhook.Secret) < constants.MinSecretLength {
+		return invalidWebhook(fmt.Sprintf("secret must be at least %d characters", constants.MinSecretLength)), nil
+	}
+
+	if resp, err := c.checkBoard(ctx, webhook.BoardID); resp != nil || err != nil {
+		return resp, err
+	}
+
+	count, err := c.repo.CountWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if count >= constants.MaxWebhooksPerGame {
+		return &model.WebhookResponse{
+			Success: false,
+			Error:   fmt.Sprintf("A game can have at most %d webhooks", constants.MaxWebhooksPerGame),
+			Code:    constants.ErrTooManyWebhooks,
+		}, nil
+	}
+
+	if err := c.repo.CreateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return &model.WebhookResponse{
+		Success: true,
+		Data:    webhook,
+	}, nil
+}
+
+func (c *WebhookCore) ListWebhooks(ctx context.Context) (*model.WebhooksResponse, error) {
+	webhooks, err := c.repo.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.WebhooksResponse{
+		Success:  true,
+		Webhooks: webhooks,
+	}, nil
+}
+
+func (c *WebhookCore) GetWebhook(ctx context.Context, webhookID int64) (*model.WebhookResponse, error) {
+	webhook, err := c.repo.GetWebhook(ctx, webhookID)
+	if err != nil {
+		if err.Error() == constants.ErrWebhookNotFound {
+			return webhookNotFound(), nil
+		}
+		return nil, err
+	}
+
+	return &model.WebhookResponse{
+		Success: true,
+		Data:    webhook,
+	}, nil
+}
+
+// UpdateWebhook changes the fields set in the request. Pausing a webhook
+// keeps its queued deliveries until it is active again.
+func (c *WebhookCore) UpdateWebhook(ctx context.Context, webhookID int64, req *model.UpdateWebhookRequest) (*model.WebhookResponse, error) {
+	webhook, err := c.repo.GetWebhook(ctx, webhookID)
+	if err != nil {
+		if err.Error() == constants.ErrWebhookNotFound {
+			return webhookNotFound(), nil
+		}
+		return nil, err
+	}
+
+	if req.URL != nil {
+		webhook.URL = *req.URL
+	}
+	if req.EventTypes != nil {
+		webhook.EventTypes = req.EventTypes
+	}
+	if req.BoardID != nil {
+		webhook.BoardID = *req.BoardID
+	}
+	if req.TopN != nil {
+		webhook.TopN = *req.TopN
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+	webhook.UpdatedAt = time.Now().UTC()
+
+	if message := validateWebhook(webhook, c.allowPrivateReceivers); message != "" {
+		return invalidWebhook(message), nil
+	}
+	if req.BoardID != nil {
+		if resp, err := c.checkBoard(ctx, webhook.BoardID); resp != nil || err != nil {
+			return resp, err
+		}
+	}
+
+	if err := c.repo.UpdateWebhook(ctx, webhook); err != nil {
+		if err.Error() == constants.ErrWebhookNotFound {
+			return webhookNotFound(), nil
+		}
+		return nil, err
+	}
+
+	return &model.WebhookResponse{
+		Success: true,
+		Data:    webhook,
+	}, nil
+}
+
+// DeleteWebhook removes a webhook together with its delivery log.
+func (c *WebhookCore) DeleteWebhook(ctx context.Context, webhookID int64) (*model.WebhookResponse, error) {
+	if err := c.repo.DeleteWebhook(ctx, webhookID); err != nil {
+		if err.Error() == constants.ErrWebhookNotFound {
+			return webhookNotFound(), nil
+		}
+		return nil, err
+	}
+
+	return &model.WebhookResponse{Success: true}, nil
+}
+
+// SendTestEvent queues a webhook.test delivery, e.g. to check a receiver
+// and its signature verification before real events arrive.
+func (c *WebhookCore) SendTestEvent(ctx context.Context, webhookID int64) (*model.DeliveryResponse, error) {
+	if _, err := c.repo.GetWebhook(ctx, webhookID); err != nil {
+		if err.Error() == constants.ErrWebhookNotFound {
+			return &model.DeliveryResponse{
+				Success: false,
+				Error:   "Webhook not found",
+				Code:    constants.ErrWebhookNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	payload, err := json.Marshal(&model.TestEvent{Message: "This is a test delivery"})
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%s:%d", constants.EventTest, now.UnixNano())
+	deliveryID, err := c.repo.EnqueueDelivery(ctx, webhookID, constants.EventTest, key, payload, now)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := c.repo.GetDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DeliveryResponse{
+		Success: true,
+		Data:    delivery,
+	}, nil
+}
+
+// ListDeliveries returns the delivery log of a webhook, newest first.
+func (c *WebhookCore) ListDeliveries(ctx context.Context, webhookID int64, status string) (*model.DeliveriesResponse, error) {
+	if _, err := c.repo.GetWebhook(ctx, webhookID); err != nil {
+		if err.Error() == constants.ErrWebhookNotFound {
+			return &model.DeliveriesResponse{
+				Success:   false,
+				WebhookID: webhookID,
+				Error:     "Webhook not found",
+				Code:      constants.ErrWebhookNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+
+	deliveries, err := c.repo.ListDeliveries(ctx, webhookID, status, constants.MaxDeliveriesPerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DeliveriesResponse{
+		Success:    true,
+		WebhookID:  webhookID,
+		Deliveries: deliveries,
+	}, nil
+}
+
+// RetryDelivery requeues a dead-lettered delivery with a fresh attempt budget.
+func (c *WebhookCore) RetryDelivery(ctx context.Context, webhookID, deliveryID int64) (*model.DeliveryResponse, error) {
+	delivery, err := c.repo.RetryDelivery(ctx, webhookID, deliveryID, time.Now().UTC())
+	if err != nil {
+		switch err.Error() {
+		case constants.ErrDeliveryNotFound:
+			return &model.DeliveryResponse{
+				Success: false,
+				Error:   "Delivery not found",
+				Code:    constants.ErrDeliveryNotFound,
+			}, nil
+		case constants.ErrDeliveryNotDead:
+			return &model.DeliveryResponse{
+				Success: false,
+				Error:   "Only dead deliveries can be retried",
+				Code:    constants.ErrDeliveryNotDead,
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &model.DeliveryResponse{
+		Success: true,
+		Data:    delivery,
+	}, nil
+}
+
+// Publish receives the events of the leaderboard outbox and queues
+// player.entered_top for every webhook whose top N a RankChanged event moved
+// the player into. Deliveries are keyed on the outbox event, so an event the
+// relay hands over again queues nothing twice; an error has it retried.
+func (c *WebhookCore) Publish(ctx context.Context, event *leaderboardModel.DomainEvent) error {
+	if event.Type != leaderboardConstants.EventRankChanged {
+		return nil
+	}
+
+	var change leaderboardModel.RankChangedEvent
+	if err := json.Unmarshal(event.Payload, &change); err != nil {
+		// Retrying cannot fix a malformed event
+		c.logger.Warnf("Dropped malformed rank change event | id=%d error=%v", event.ID, err)
+		return nil
+	}
+
+	// Nobody can have entered a top N the player was already in or is still outside of
+	if change.Rank == 0 || change.Rank > constants.MaxTopN ||
+		(change.PreviousRank != 0 && change.PreviousRank <= change.Rank) {
+		return nil
+	}
+
+	ctx = global.WithGameID(ctx, event.GameID)
+	subscribers, err := c.repo.ListSubscribers(ctx, constants.EventEnteredTop, change.BoardID)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("%s:%d", constants.EventEnteredTop, event.ID)
+	for _, subscriber := range subscribers {
+		if change.Rank > subscriber.TopN || (change.PreviousRank != 0 && change.PreviousRank <= subscriber.TopN) {
+			continue
+		}
+
+		payload, err := json.Marshal(&model.EnteredTopEvent{
+			UserID:       change.UserID,
+			BoardID:      change.BoardID,
+			PeriodStart:  change.PeriodStart,
+			TopN:         subscriber.TopN,
+			Rank:         change.Rank,
+			PreviousRank: change.PreviousRank,
+			Score:        change.Score,
+			OccurredAt:   change.Timestamp,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := c.repo.EnqueueDelivery(ctx, subscriber.ID, constants.EventEnteredTop, key, payload, time.Now().UTC()); err != nil {
+			return fmt.Errorf("failed to queue webhook delivery for webhook %d: %w", subscriber.ID, err)
+		}
+	}
+	return nil
+}
+
+func (c *WebhookCore) checkBoard(ctx context.Context, boardID string) (*model.WebhookResponse, error) {
+	if boardID == "" {
+		return nil, nil
+	}
+
+	exists, err := c.repo.BoardExists(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &model.WebhookResponse{
+			Success: false,
+			Error:   "Leaderboard not found",
+			Code:    constants.ErrBoardNotFound,
+		}, nil
+	}
+	return nil, nil
+}
+
+// validateWebhook returns a human readable problem with the webhook, or "".
+func validateWebhook(webhook *model.Webhook, allowPrivateReceivers bool) string {
+	target, err := url.Parse(webhook.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" {
+		return "url must be an absolute http or https URL"
+	}
+	if problem := checkReceiverHost(target.Hostname(), allowPrivateReceivers); problem != "" {
+		return problem
+	}
+
+	if len(webhook.EventTypes) == 0 {
+		return "event_types must not be empty"
+	}
+	seen := make(map[string]bool, len(webhook.EventTypes))
+	for _, eventType := range webhook.EventTypes {
+		if !subscribableEvents[eventType] {
+			return fmt.Sprintf("unknown event type %q: expected %s or %s", eventType, constants.EventEnteredTop, constants.EventSeasonEnded)
+		}
+		if seen[eventType] {
+			return fmt.Sprintf("event type %q is listed twice", eventType)
+		}
+		seen[eventType] = true
+	}
+
+	if webhook.TopN < 1 || webhook.TopN > constants.MaxTopN {
+		return fmt.Sprintf("top_n must be between 1 and %d", constants.MaxTopN)
+	}
+	return ""
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.New("failed to generate webhook secret")
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func invalidWebhook(message string) *model.WebhookResponse {
+	return &model.WebhookResponse{
+		Success: false,
+		Error:   message,
+		Code:    constants.ErrInvalidWebhook,
+	}
+}
+
+func webhookNotFound() *model.WebhookResponse {
+	return &model.WebhookResponse{
+		Success: false,
+		Error:   "Webhook not found",
+		Code:    constants.ErrWebhookNotFound,
+	}
+}
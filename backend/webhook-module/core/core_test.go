@@ -0,0 +1,125 @@
+package core
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestDeliveryBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     time.Duration
+		attempts int
+		want     time.Duration
+	}{
+		{name: "before any attempt", base: time.Minute, attempts: 0, want: time.Minute},
+		{name: "first attempt", base: time.Minute, attempts: 1, want: time.Minute},
+		{name: "second attempt", base: time.Minute, attempts: 2, want: 2 * time.Minute},
+		{name: "fifth attempt", base: time.Minute, attempts: 5, want: 16 * time.Minute},
+		{name: "capped", base: time.Minute, attempts: 10, want: deliveryMaxBackoff},
+		{name: "many attempts do not overflow", base: time.Minute, attempts: 1000, want: deliveryMaxBackoff},
+		{name: "base above cap", base: 12 * time.Hour, attempts: 1, want: deliveryMaxBackoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deliveryBackoff(tt.base, tt.attempts); got != tt.want {
+				t.Errorf("deliveryBackoff(%v, %d) = %v, want %v", tt.base, tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	// Computed independently with HMAC-SHA256 over "1700000000.<body>"
+	const want = "sha256=1e6da341e70f47a68fa4611489f67be7dc01471528bbc84e611de452ad8dd9f0"
+
+	if got := Sign("secret", 1700000000, []byte(`{"event":"entered_top"}`)); got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"event":"entered_top"}`)
+	signature := Sign("secret", 1700000000, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{name: "valid", secret: "secret", timestamp: 1700000000, body: body, signature: signature, want: true},
+		{name: "wrong secret", secret: "other", timestamp: 1700000000, body: body, signature: signature},
+		{name: "replayed with another timestamp", secret: "secret", timestamp: 1700000001, body: body, signature: signature},
+		{name: "tampered body", secret: "secret", timestamp: 1700000000, body: []byte(`{"event":"entered_top "}`), signature: signature},
+		{name: "missing prefix", secret: "secret", timestamp: 1700000000, body: body, signature: signature[len("sha256="):]},
+		{name: "empty signature", secret: "secret", timestamp: 1700000000, body: body, signature: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifySignature(tt.secret, tt.timestamp, tt.body, tt.signature); got != tt.want {
+				t.Errorf("VerifySignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicAddress(t *testing.T) {
+	tests := []struct {
+		name string
+		ip   string
+		want bool
+	}{
+		{name: "public v4", ip: "8.8.8.8", want: true},
+		{name: "public v6", ip: "2001:4860:4860::8888", want: true},
+		{name: "loopback", ip: "127.0.0.1"},
+		{name: "loopback v6", ip: "::1"},
+		{name: "private", ip: "10.1.2.3"},
+		{name: "private v6", ip: "fd00::1"},
+		{name: "metadata endpoint", ip: "169.254.169.254"},
+		{name: "link-local v6", ip: "fe80::1"},
+		{name: "shared address space", ip: "100.64.0.1"},
+		{name: "unspecified", ip: "0.0.0.0"},
+		{name: "multicast", ip: "224.0.0.1"},
+		{name: "v4-mapped loopback", ip: "::ffff:127.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := publicAddress(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("publicAddress(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckReceiverHost(t *testing.T) {
+	tests := []struct {
+		name         string
+		host         string
+		allowPrivate bool
+		wantProblem  bool
+	}{
+		{name: "public address", host: "8.8.8.8"},
+		{name: "name is checked when dialed", host: "localhost"},
+		{name: "loopback", host: "127.0.0.1", wantProblem: true},
+		{name: "metadata endpoint", host: "169.254.169.254", wantProblem: true},
+		{name: "private v6", host: "fd00::1", wantProblem: true},
+		{name: "loopback allowed", host: "127.0.0.1", allowPrivate: true},
+		{name: "private allowed", host: "10.0.0.5", allowPrivate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := checkReceiverHost(tt.host, tt.allowPrivate)
+			if (problem != "") != tt.wantProblem {
+				t.Errorf("checkReceiverHost(%q, %v) = %q, want problem %v", tt.host, tt.allowPrivate, problem, tt.wantProblem)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/repository"
+)
+
+// Headers sent with every delivery. The signature is the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the webhook's secret, prefixed "sha256=".
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+)
+
+const (
+	// deliveryLease is how long a claimed delivery is reserved for one
+	// dispatcher; it must outlast the request timeout.
+	deliveryLease = 2 * time.Minute
+	// deliveryMaxBackoff caps the delay between attempts.
+	deliveryMaxBackoff = 6 * time.Hour
+	// maxErrorLength caps the error kept in the delivery log.
+	maxErrorLength = 512
+	// maxAnswerLength caps how much of a receiver's answer is read, only so
+	// the connection can be reused; the answer itself is never stored.
+	maxAnswerLength = 4096
+	// deliveryPruneInterval is how often finished deliveries are cleaned up.
+	deliveryPruneInterval = time.Hour
+)
+
+// Sign computes the signature header value of a delivery body.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks a received signature header in constant time.
+// Receivers should also reject timestamps too far from their own clock.
+func VerifySignature(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// WebhookDeliveryJob sends queued deliveries. Failed attempts are retried with
+// exponential backoff; after MaxDeliveryAttempts a delivery is dead until it
+// is retried through the API. A delivery is only marked delivered after a 2xx
+// answer, so receivers may see one more than once and should deduplicate on
+// its id.
+type WebhookDeliveryJob struct {
+	repo      *repository.WebhookRepository
+	logger    *providers.ConsoleLogger
+	client    *http.Client
+	interval  time.Duration
+	backoff   time.Duration
+	retention time.Duration
+}
+
+func NewWebhookDeliveryJob(
+	repo *repository.WebhookRepository,
+	logger *providers.ConsoleLogger,
+	interval time.Duration,
+	timeout time.Duration,
+	backoff time.Duration,
+	retention time.Duration,
+	allowPrivateReceivers bool,
+) *WebhookDeliveryJob {
+	return &WebhookDeliveryJob{
+		repo:      repo,
+		logger:    logger,
+		client:    newDeliveryClient(timeout, allowPrivateReceivers),
+		interval:  interval,
+		backoff:   backoff,
+		retention: retention,
+	}
+}
+
+// Run blocks until ctx is cancelled, sending due deliveries on every interval.
+func (j *WebhookDeliveryJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	lastPrune := time.Time{}
+	for {
+		now := time.Now().UTC()
+		j.runOnce(ctx)
+		if now.Sub(lastPrune) >= deliveryPruneInterval {
+			j.prune(ctx, now)
+			lastPrune = now
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *WebhookDeliveryJob) runOnce(ctx context.Context) {
+	// Keep going while full batches come back, so a backlog drains quickly
+	for ctx.Err() == nil {
+		now := time.Now().UTC()
+		deliveries, err := j.repo.ClaimDeliveries(ctx, now, now.Add(deliveryLease), constants.DeliveryBatchSize)
+		if err != nil {
+			j.logger.Errorf("Webhook delivery failed to claim deliveries | error=%v", err)
+			return
+		}
+
+		// Receivers are independent, so one slow endpoint does not hold up the rest
+		var wg sync.WaitGroup
+		for i := range deliveries {
+			wg.Add(1)
+			go func(delivery *repository.ClaimedDelivery) {
+				defer wg.Done()
+				j.deliver(ctx, delivery)
+			}(&deliveries[i])
+		}
+		wg.Wait()
+
+		if len(deliveries) < constants.DeliveryBatchSize {
+			return
+		}
+	}
+}
+
+func (j *WebhookDeliveryJob) deliver(ctx context.Context, delivery *repository.ClaimedDelivery) {
+	statusCode, err := j.send(ctx, delivery)
+	now := time.Now().UTC()
+
+	if err == nil {
+		if err := j.repo.MarkDelivered(ctx, delivery.ID, statusCode, now); err != nil {
+			// The lease runs out and the delivery is sent again
+			j.logger.Warnf("Failed to record webhook delivery | delivery_id=%d error=%v", delivery.ID, err)
+		}
+		return
+	}
+
+	var nextAttempt *time.Time
+	if delivery.Attempts < constants.MaxDeliveryAttempts {
+		retryAt := now.Add(deliveryBackoff(j.backoff, delivery.Attempts))
+		nextAttempt = &retryAt
+	}
+
+	cause := err.Error()
+	if len(cause) > maxErrorLength {
+		cause = cause[:maxErrorLength]
+	}
+	if nextAttempt == nil {
+		j.logger.Warnf("Webhook delivery dead-lettered | webhook_id=%d delivery_id=%d attempts=%d error=%s", delivery.WebhookID, delivery.ID, delivery.Attempts, cause)
+	} else {
+		j.logger.Warnf("Webhook delivery failed | webhook_id=%d delivery_id=%d attempts=%d error=%s", delivery.WebhookID, delivery.ID, delivery.Attempts, cause)
+	}
+
+	if err := j.repo.MarkFailed(ctx, delivery.ID, statusCode, cause, nextAttempt); err != nil {
+		j.logger.Warnf("Failed to record webhook delivery failure | delivery_id=%d error=%v", delivery.ID, err)
+	}
+}
+
+// send POSTs one delivery and returns the receiver's status code, or 0 when
+// no answer arrived.
+func (j *WebhookDeliveryJob) send(ctx context.Context, delivery *repository.ClaimedDelivery) (int, error) {
+	body, err := json.Marshal(&model.WebhookEnvelope{
+		ID:        delivery.ID,
+		Type:      delivery.EventType,
+		GameID:    delivery.GameID,
+		CreatedAt: delivery.CreatedAt,
+		Data:      json.RawMessage(delivery.Payload),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, body))
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Receivers are untrusted, so only the status of their answer is recorded
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxAnswerLength))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver answered %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return resp.StatusCode, nil
+}
+
+func (j *WebhookDeliveryJob) prune(ctx context.Context, now time.Time) {
+	pruned, err := j.repo.PruneDeliveries(ctx, now.Add(-j.retention))
+	if err != nil {
+		j.logger.Errorf("Webhook delivery prune failed | error=%v", err)
+		return
+	}
+	if pruned > 0 {
+		j.logger.Infof("Webhook deliveries pruned | deliveries=%d", pruned)
+	}
+}
+
+// deliveryBackoff doubles the delay with every failed attempt, up to deliveryMaxBackoff.
+func deliveryBackoff(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < deliveryMaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, deliveryMaxBackoff)
+}
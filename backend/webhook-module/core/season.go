@@ -0,0 +1,137 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	leaderboardCore "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/core"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/model"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/repository"
+)
+
+// GameLister lists the games (tenants) background jobs have to visit.
+type GameLister interface {
+	ListGameIDs(ctx context.Context) ([]string, error)
+}
+
+// SeasonEndJob queues season.ended for the latest closed period of every
+// board that resets. Webhooks created after a period closed are not told
+// about it, and each run only looks at the latest closed period, so periods
+// that closed while the job was down for longer than a whole period are
+// skipped.
+type SeasonEndJob struct {
+	repo     *repository.WebhookRepository
+	games    GameLister
+	logger   *providers.ConsoleLogger
+	interval time.Duration
+}
+
+func NewSeasonEndJob(
+	repo *repository.WebhookRepository,
+	games GameLister,
+	logger *providers.ConsoleLogger,
+	interval time.Duration,
+) *SeasonEndJob {
+	return &SeasonEndJob{
+		repo:     repo,
+		games:    games,
+		logger:   logger,
+		interval: interval,
+	}
+}
+
+// Run blocks until ctx is cancelled, checking for closed seasons on every interval.
+func (j *SeasonEndJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.runOnce(ctx, time.Now().UTC())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			j.runOnce(ctx, now.UTC())
+		}
+	}
+}
+
+func (j *SeasonEndJob) runOnce(ctx context.Context, now time.Time) {
+	gameIDs, err := j.games.ListGameIDs(ctx)
+	if err != nil {
+		j.logger.Errorf("Season end webhooks failed to list games | error=%v", err)
+		return
+	}
+
+	for _, gameID := range gameIDs {
+		gameCtx := global.WithGameID(ctx, gameID)
+
+		boards, err := j.repo.ListSeasonalBoards(gameCtx)
+		if err != nil {
+			j.logger.Errorf("Season end webhooks failed to list boards | game_id=%s error=%v", gameID, err)
+			continue
+		}
+
+		for i := range boards {
+			if err := j.queueSeasonEnd(gameCtx, &boards[i], now); err != nil {
+				j.logger.Errorf("Season end webhooks failed | game_id=%s board_id=%s error=%v", gameID, boards[i].ID, err)
+			}
+		}
+	}
+}
+
+// queueSeasonEnd queues the board's latest closed period for the webhooks
+// that have not been sent it yet. Queueing is keyed by the period, so
+// overlapping runs never queue it twice.
+func (j *SeasonEndJob) queueSeasonEnd(ctx context.Context, board *repository.Board, now time.Time) error {
+	periodEnd := leaderboardCore.PeriodStart(board.ResetSchedule, now)
+	periodStart := leaderboardCore.PeriodStart(board.ResetSchedule, periodEnd.Add(-time.Nanosecond))
+	key := fmt.Sprintf("%s:%s:%d", constants.EventSeasonEnded, board.ID, periodStart.Unix())
+
+	subscribers, err := j.repo.ListSubscribersWithout(ctx, constants.EventSeasonEnded, board.ID, key, periodEnd)
+	if err != nil || len(subscribers) == 0 {
+		return err
+	}
+
+	players, err := j.repo.CountPlayers(ctx, board.ID, periodStart)
+	if err != nil {
+		return err
+	}
+	standings, err := j.repo.GetFinalStandings(ctx, board, periodStart, constants.SeasonStandingsSize)
+	if err != nil {
+		return err
+	}
+
+	event := &model.SeasonEndedEvent{
+		BoardID:     board.ID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Players:     players,
+		Standings:   make([]model.SeasonStanding, 0, len(standings)),
+	}
+	for _, standing := range standings {
+		event.Standings = append(event.Standings, model.SeasonStanding{
+			UserID: standing.UserID,
+			Rank:   standing.Rank,
+			Score:  standing.Score,
+		})
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	for _, subscriber := range subscribers {
+		if _, err := j.repo.EnqueueDelivery(ctx, subscriber.ID, constants.EventSeasonEnded, key, payload, now); err != nil {
+			return err
+		}
+	}
+	j.logger.Infof("Season end webhooks queued | board_id=%s period_start=%s webhooks=%d", board.ID, periodStart.Format(time.RFC3339), len(subscribers))
+	return nil
+}
@@ -0,0 +1,128 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook is a partner subscription to leaderboard events. BoardID limits it
+// to the events of one board; TopN is the top-N size player.entered_top
+// watches. Secret is only returned when the webhook is created.
+type Webhook struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	BoardID    string    `json:"board_id,omitempty"`
+	TopN       int       `json:"top_n"`
+	Active     bool      `json:"active"`
+	Secret     string    `json:"secret,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// CreateWebhookRequest creates a webhook. A secret is generated when none is given.
+type CreateWebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	BoardID    string   `json:"board_id,omitempty"`
+	TopN       int      `json:"top_n,omitempty"`
+	Secret     string   `json:"secret,omitempty"`
+}
+
+// UpdateWebhookRequest changes the fields that are set. An empty BoardID
+// removes the board filter.
+type UpdateWebhookRequest struct {
+	URL        *string  `json:"url,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	BoardID    *string  `json:"board_id,omitempty"`
+	TopN       *int     `json:"top_n,omitempty"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
+type WebhookResponse struct {
+	Success bool     `json:"success"`
+	Data    *Webhook `json:"data,omitempty"`
+	Error   string   `json:"error,omitempty"`
+	Code    string   `json:"code,omitempty"`
+}
+
+type WebhooksResponse struct {
+	Success  bool      `json:"success"`
+	Webhooks []Webhook `json:"webhooks"`
+	Error    string    `json:"error,omitempty"`
+	Code     string    `json:"code,omitempty"`
+}
+
+// WebhookDelivery is one event queued for a webhook, with the outcome of the
+// latest attempt. NextAttemptAt is only set while the delivery is pending.
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhook_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+type DeliveriesResponse struct {
+	Success    bool              `json:"success"`
+	WebhookID  int64             `json:"webhook_id"`
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Error      string            `json:"error,omitempty"`
+	Code       string            `json:"code,omitempty"`
+}
+
+type DeliveryResponse struct {
+	Success bool             `json:"success"`
+	Data    *WebhookDelivery `json:"data,omitempty"`
+	Error   string           `json:"error,omitempty"`
+	Code    string           `json:"code,omitempty"`
+}
+
+// WebhookEnvelope is the body of every delivery. ID is the delivery id,
+// which stays the same across retries.
+type WebhookEnvelope struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	GameID    string          `json:"game_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// EnteredTopEvent is sent when a player moves into the webhook's top N.
+// PreviousRank is 0 for a player new to the period.
+type EnteredTopEvent struct {
+	UserID       int64     `json:"user_id"`
+	BoardID      string    `json:"board_id"`
+	PeriodStart  time.Time `json:"period_start"`
+	TopN         int       `json:"top_n"`
+	Rank         int       `json:"rank"`
+	PreviousRank int       `json:"previous_rank,omitempty"`
+	Score        int64     `json:"score"`
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+// SeasonEndedEvent is sent once a board period closes, with its final top standings.
+type SeasonEndedEvent struct {
+	BoardID     string           `json:"board_id"`
+	PeriodStart time.Time        `json:"period_start"`
+	PeriodEnd   time.Time        `json:"period_end"`
+	Players     int64            `json:"players"`
+	Standings   []SeasonStanding `json:"standings"`
+}
+
+type SeasonStanding struct {
+	UserID int64 `json:"user_id"`
+	Rank   int   `json:"rank"`
+	Score  int64 `json:"score"`
+}
+
+// TestEvent is the payload of webhook.test deliveries.
+type TestEvent struct {
+	Message string `json:"message"`
+}
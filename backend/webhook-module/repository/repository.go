@@ -0,0 +1,649 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/global"
+	leaderboardConstants "github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/leader-board-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/model"
+	"gorm.io/gorm"
+)
+
+// Board is the part of a leaderboard definition season events need.
+type Board struct {
+	ID            string `gorm:"column:id"`
+	SortOrder     string `gorm:"column:sort_order"`
+	ResetSchedule string `gorm:"column:reset_schedule"`
+}
+
+// Standing is a player's final position in a closed board period.
+type Standing struct {
+	UserID int64 `gorm:"column:user_id"`
+	Score  int64 `gorm:"column:total_score"`
+	Rank   int   `gorm:"column:rank"`
+}
+
+// Subscriber is an active webhook an event has to be queued for.
+type Subscriber struct {
+	ID        int64     `gorm:"column:id"`
+	TopN      int       `gorm:"column:top_n"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+// ClaimedDelivery is a pending delivery leased to a dispatcher, with what it
+// needs to send it. Attempts includes the current one.
+type ClaimedDelivery struct {
+	ID        int64     `gorm:"column:id"`
+	GameID    string    `gorm:"column:game_id"`
+	WebhookID int64     `gorm:"column:webhook_id"`
+	URL       string    `gorm:"column:url"`
+	Secret    string    `gorm:"column:secret"`
+	EventType string    `gorm:"column:event_type"`
+	Payload   string    `gorm:"column:payload"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	Attempts  int       `gorm:"column:attempts"`
+}
+
+type webhookRow struct {
+	ID         int64     `gorm:"column:id"`
+	URL        string    `gorm:"column:url"`
+	EventTypes string    `gorm:"column:event_types"`
+	BoardID    *string   `gorm:"column:board_id"`
+	TopN       int       `gorm:"column:top_n"`
+	Active     bool      `gorm:"column:active"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+}
+
+type deliveryRow struct {
+	ID             int64      `gorm:"column:id"`
+	WebhookID      int64      `gorm:"column:webhook_id"`
+	EventType      string     `gorm:"column:event_type"`
+	Payload        string     `gorm:"column:payload"`
+	Status         string     `gorm:"column:status"`
+	Attempts       int        `gorm:"column:attempts"`
+	NextAttemptAt  time.Time  `gorm:"column:next_attempt_at"`
+	LastStatusCode *int       `gorm:"column:last_status_code"`
+	LastError      *string    `gorm:"column:last_error"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at"`
+}
+
+const webhookColumns = `id, url, event_types::text AS event_types, board_id, top_n, active, created_at, updated_at`
+
+const deliveryColumns = `id, webhook_id, event_type, payload::text AS payload, status, attempts,
+	next_attempt_at, last_status_code, last_error, created_at, delivered_at`
+
+type IWebhookRepository interface {
+	BoardExists(ctx context.Context, boardID string) (bool, error)
+	CountWebhooks(ctx context.Context) (int64, error)
+	CreateWebhook(ctx context.Context, webhook *model.Webhook) error
+	ListWebhooks(ctx context.Context) ([]model.Webhook, error)
+	GetWebhook(ctx context.Context, webhookID int64) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *model.Webhook) error
+	DeleteWebhook(ctx context.Context, webhookID int64) error
+	ListSubscribers(ctx context.Context, eventType, boardID string) ([]Subscriber, error)
+	EnqueueDelivery(ctx context.Context, webhookID int64, eventType, eventKey string, payload []byte, now time.Time) (int64, error)
+	GetDelivery(ctx context.Context, webhookID, deliveryID int64) (*model.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, webhookID int64, status string, limit int) ([]model.WebhookDelivery, error)
+	RetryDelivery(ctx context.Context, webhookID, deliveryID int64, now time.Time) (*model.WebhookDelivery, error)
+}
+
+type WebhookRepository struct {
+	db     *gorm.DB
+	logger *providers.ConsoleLogger
+}
+
+func NewWebhookRepository(db *gorm.DB, logger *providers.ConsoleLogger) *WebhookRepository {
+	return &WebhookRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+/* ============================
+   Subscriptions
+============================ */
+
+func (r *WebhookRepository) BoardExists(ctx context.Context, boardID string) (bool, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	if err := r.db.WithContext(ctx).Raw(
+		`SELECT EXISTS (SELECT 1 FROM gaming.leaderboards WHERE game_id = ? AND id = ?)`,
+		gameID,
+		boardID,
+	).Scan(&exists).Error; err != nil {
+		return false, fmt.Errorf("failed to check board: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *WebhookRepository) CountWebhooks(ctx context.Context) (int64, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Raw(
+		`SELECT COUNT(*) FROM gaming.webhooks WHERE game_id = ?`,
+		gameID,
+	).Scan(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count webhooks: %w", err)
+	}
+	return count, nil
+}
+
+// CreateWebhook stores a webhook, including its secret, and fills in its id.
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		return fmt.Errorf("failed to encode event types: %w", err)
+	}
+
+	var ids []int64
+	if err := r.db.WithContext(ctx).Raw(`
+		INSERT INTO gaming.webhooks (game_id, url, event_types, board_id, top_n, secret, active, created_at, updated_at)
+		VALUES (?, ?, ?::jsonb, NULLIF(?, ''), ?, ?, ?, ?, ?)
+		RETURNING id
+	`, gameID, webhook.URL, string(eventTypes), webhook.BoardID, webhook.TopN, webhook.Secret,
+		webhook.Active, webhook.CreatedAt, webhook.UpdatedAt).Scan(&ids).Error; err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	if len(ids) == 0 {
+		return errors.New("failed to create webhook: no id returned")
+	}
+
+	webhook.ID = ids[0]
+	return nil
+}
+
+func (r *WebhookRepository) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []webhookRow
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT `+webhookColumns+`
+		FROM gaming.webhooks
+		WHERE game_id = ?
+		ORDER BY id
+	`, gameID).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	webhooks := make([]model.Webhook, 0, len(rows))
+	for _, row := range rows {
+		webhook, err := row.toModel()
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+	return webhooks, nil
+}
+
+// GetWebhook returns a webhook without its secret, or ErrWebhookNotFound.
+func (r *WebhookRepository) GetWebhook(ctx context.Context, webhookID int64) (*model.Webhook, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []webhookRow
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT `+webhookColumns+`
+		FROM gaming.webhooks
+		WHERE game_id = ? AND id = ?
+	`, gameID, webhookID).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New(constants.ErrWebhookNotFound)
+	}
+	return rows[0].toModel()
+}
+
+// UpdateWebhook saves every field of the webhook except its secret.
+func (r *WebhookRepository) UpdateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		return fmt.Errorf("failed to encode event types: %w", err)
+	}
+
+	result := r.db.WithContext(ctx).Exec(`
+		UPDATE gaming.webhooks
+		SET url = ?, event_types = ?::jsonb, board_id = NULLIF(?, ''), top_n = ?, active = ?, updated_at = ?
+		WHERE game_id = ? AND id = ?
+	`, webhook.URL, string(eventTypes), webhook.BoardID, webhook.TopN, webhook.Active, webhook.UpdatedAt,
+		gameID, webhook.ID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update webhook: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(constants.ErrWebhookNotFound)
+	}
+	return nil
+}
+
+// DeleteWebhook removes a webhook together with its delivery log.
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := r.db.WithContext(ctx).Exec(
+		`DELETE FROM gaming.webhooks WHERE game_id = ? AND id = ?`,
+		gameID,
+		webhookID,
+	)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(constants.ErrWebhookNotFound)
+	}
+	return nil
+}
+
+/* ============================
+   Events
+============================ */
+
+// ListSubscribers returns the active webhooks subscribed to an event of the
+// board, including those without a board filter.
+func (r *WebhookRepository) ListSubscribers(ctx context.Context, eventType, boardID string) ([]Subscriber, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscribers []Subscriber
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT id, top_n, created_at
+		FROM gaming.webhooks
+		WHERE game_id = ?
+		  AND active
+		  AND event_types @> ?::jsonb
+		  AND (board_id IS NULL OR board_id = ?)
+		ORDER BY id
+	`, gameID, eventTypeFilter(eventType), boardID).Scan(&subscribers).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscribers: %w", err)
+	}
+	return subscribers, nil
+}
+
+// ListSubscribersWithout is ListSubscribers limited to webhooks created
+// before the given time that have not been queued the event yet.
+func (r *WebhookRepository) ListSubscribersWithout(
+	ctx context.Context,
+	eventType string,
+	boardID string,
+	eventKey string,
+	createdBefore time.Time,
+) ([]Subscriber, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscribers []Subscriber
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT w.id, w.top_n, w.created_at
+		FROM gaming.webhooks w
+		WHERE w.game_id = ?
+		  AND w.active
+		  AND w.event_types @> ?::jsonb
+		  AND (w.board_id IS NULL OR w.board_id = ?)
+		  AND w.created_at < ?
+		  AND NOT EXISTS (
+			SELECT 1 FROM gaming.webhook_deliveries d
+			WHERE d.webhook_id = w.id AND d.event_key = ?
+		  )
+		ORDER BY w.id
+	`, gameID, eventTypeFilter(eventType), boardID, createdBefore, eventKey).Scan(&subscribers).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscribers: %w", err)
+	}
+	return subscribers, nil
+}
+
+// EnqueueDelivery queues an event for a webhook and returns the delivery id.
+// It returns 0 when the event was already queued for the webhook, so
+// producers may safely repeat themselves.
+func (r *WebhookRepository) EnqueueDelivery(
+	ctx context.Context,
+	webhookID int64,
+	eventType string,
+	eventKey string,
+	payload []byte,
+	now time.Time,
+) (int64, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var ids []int64
+	if err := r.db.WithContext(ctx).Raw(`
+		INSERT INTO gaming.webhook_deliveries (
+			game_id, webhook_id, event_type, event_key, payload, status, next_attempt_at, created_at
+		)
+		VALUES (?, ?, ?, ?, ?::jsonb, ?, ?, ?)
+		ON CONFLICT (webhook_id, event_key) DO NOTHING
+		RETURNING id
+	`, gameID, webhookID, eventType, eventKey, string(payload), constants.DeliveryPending, now, now).Scan(&ids).Error; err != nil {
+		return 0, fmt.Errorf("failed to queue webhook delivery: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return ids[0], nil
+}
+
+// GetDelivery returns a delivery of the webhook, or ErrDeliveryNotFound.
+func (r *WebhookRepository) GetDelivery(ctx context.Context, webhookID, deliveryID int64) (*model.WebhookDelivery, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []deliveryRow
+	if err := r.db.WithContext(ctx).
+		Table("gaming.webhook_deliveries").
+		Select(deliveryColumns).
+		Where("game_id = ? AND webhook_id = ? AND id = ?", gameID, webhookID, deliveryID).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New(constants.ErrDeliveryNotFound)
+	}
+	delivery := rows[0].toModel()
+	return &delivery, nil
+}
+
+// ListSeasonalBoards returns the boards that reset, and so have seasons.
+func (r *WebhookRepository) ListSeasonalBoards(ctx context.Context) ([]Board, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var boards []Board
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT id, sort_order, reset_schedule
+		FROM gaming.leaderboards
+		WHERE game_id = ? AND reset_schedule <> ?
+		ORDER BY id
+	`, gameID, leaderboardConstants.ResetNever).Scan(&boards).Error; err != nil {
+		return nil, fmt.Errorf("failed to list seasonal boards: %w", err)
+	}
+	return boards, nil
+}
+
+func (r *WebhookRepository) CountPlayers(ctx context.Context, boardID string, periodStart time.Time) (int64, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Raw(
+		`SELECT COUNT(*) FROM gaming.leaderboard WHERE game_id = ? AND board_id = ? AND period_start = ?`,
+		gameID,
+		boardID,
+		periodStart,
+	).Scan(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count players: %w", err)
+	}
+	return count, nil
+}
+
+// GetFinalStandings ranks a closed period and returns its top limit players.
+// Tied players share a rank.
+func (r *WebhookRepository) GetFinalStandings(ctx context.Context, board *Board, periodStart time.Time, limit int) ([]Standing, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	direction := "DESC"
+	if board.SortOrder == leaderboardConstants.SortAscending {
+		direction = "ASC"
+	}
+
+	var standings []Standing
+	if err := r.db.WithContext(ctx).Raw(fmt.Sprintf(`
+		SELECT user_id, total_score, RANK() OVER (ORDER BY total_score %s) AS rank
+		FROM gaming.leaderboard
+		WHERE game_id = ? AND board_id = ? AND period_start = ?
+		ORDER BY rank, user_id
+		LIMIT ?
+	`, direction), gameID, board.ID, periodStart, limit).Scan(&standings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get final standings: %w", err)
+	}
+	return standings, nil
+}
+
+/* ============================
+   Deliveries
+============================ */
+
+// ListDeliveries returns the latest deliveries of a webhook, newest first,
+// optionally only those in one status.
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID int64, status string, limit int) ([]model.WebhookDelivery, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.db.WithContext(ctx).
+		Table("gaming.webhook_deliveries").
+		Select(deliveryColumns).
+		Where("game_id = ? AND webhook_id = ?", gameID, webhookID)
+	if status != constants.DeliveryAll {
+		query = query.Where("status = ?", status)
+	}
+
+	var rows []deliveryRow
+	if err := query.Order("id DESC").Limit(limit).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]model.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, row.toModel())
+	}
+	return deliveries, nil
+}
+
+// RetryDelivery moves a dead delivery back to pending with a fresh attempt
+// budget. It fails with ErrDeliveryNotFound or, for deliveries that are not
+// dead, ErrDeliveryNotDead.
+func (r *WebhookRepository) RetryDelivery(ctx context.Context, webhookID, deliveryID int64, now time.Time) (*model.WebhookDelivery, error) {
+	gameID, err := global.GameID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []deliveryRow
+	if err := r.db.WithContext(ctx).Raw(`
+		UPDATE gaming.webhook_deliveries
+		SET status = ?, attempts = 0, next_attempt_at = ?
+		WHERE game_id = ? AND webhook_id = ? AND id = ? AND status = ?
+		RETURNING `+deliveryColumns,
+		constants.DeliveryPending, now, gameID, webhookID, deliveryID, constants.DeliveryDead,
+	).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to retry webhook delivery: %w", err)
+	}
+	if len(rows) > 0 {
+		delivery := rows[0].toModel()
+		return &delivery, nil
+	}
+
+	var exists bool
+	if err := r.db.WithContext(ctx).Raw(
+		`SELECT EXISTS (SELECT 1 FROM gaming.webhook_deliveries WHERE game_id = ? AND webhook_id = ? AND id = ?)`,
+		gameID,
+		webhookID,
+		deliveryID,
+	).Scan(&exists).Error; err != nil {
+		return nil, fmt.Errorf("failed to check webhook delivery: %w", err)
+	}
+	if !exists {
+		return nil, errors.New(constants.ErrDeliveryNotFound)
+	}
+	return nil, errors.New(constants.ErrDeliveryNotDead)
+}
+
+// ClaimDeliveries leases up to limit due deliveries of active webhooks,
+// oldest first, until leaseUntil. Deliveries are dispatched for all games at
+// once, so unlike most reads this is not scoped to the context's game.
+func (r *WebhookRepository) ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]ClaimedDelivery, error) {
+	var deliveries []ClaimedDelivery
+	if err := r.db.WithContext(ctx).Raw(`
+		UPDATE gaming.webhook_deliveries d
+		SET next_attempt_at = ?, attempts = d.attempts + 1
+		FROM gaming.webhooks w
+		WHERE w.id = d.webhook_id
+		  AND d.id IN (
+			SELECT pending.id
+			FROM gaming.webhook_deliveries pending
+			JOIN gaming.webhooks hook ON hook.id = pending.webhook_id
+			WHERE pending.status = ? AND pending.next_attempt_at <= ? AND hook.active
+			ORDER BY pending.id
+			LIMIT ?
+			FOR UPDATE OF pending SKIP LOCKED
+		  )
+		RETURNING d.id, d.game_id, d.webhook_id, w.url, w.secret, d.event_type,
+			d.payload::text AS payload, d.created_at, d.attempts
+	`, leaseUntil, constants.DeliveryPending, now, limit).Scan(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	// RETURNING does not keep the subquery's order
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID < deliveries[j].ID })
+	return deliveries, nil
+}
+
+// MarkDelivered records a successful attempt.
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, deliveryID int64, statusCode int, at time.Time) error {
+	if err := r.db.WithContext(ctx).Exec(`
+		UPDATE gaming.webhook_deliveries
+		SET status = ?, last_status_code = ?, last_error = NULL, delivered_at = ?
+		WHERE id = ?
+	`, constants.DeliveryDelivered, statusCode, at, deliveryID).Error; err != nil {
+		return fmt.Errorf("failed to mark webhook delivery delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt. A nil nextAttempt dead-letters the
+// delivery; statusCode is 0 when no response arrived.
+func (r *WebhookRepository) MarkFailed(ctx context.Context, deliveryID int64, statusCode int, cause string, nextAttempt *time.Time) error {
+	status := constants.DeliveryPending
+	query := `
+		UPDATE gaming.webhook_deliveries
+		SET status = ?, last_status_code = NULLIF(?, 0), last_error = ?, next_attempt_at = ?
+		WHERE id = ?
+	`
+	args := []interface{}{status, statusCode, cause, nextAttempt, deliveryID}
+	if nextAttempt == nil {
+		query = `
+			UPDATE gaming.webhook_deliveries
+			SET status = ?, last_status_code = NULLIF(?, 0), last_error = ?
+			WHERE id = ?
+		`
+		args = []interface{}{constants.DeliveryDead, statusCode, cause, deliveryID}
+	}
+
+	if err := r.db.WithContext(ctx).Exec(query, args...).Error; err != nil {
+		return fmt.Errorf("failed to record webhook delivery failure: %w", err)
+	}
+	return nil
+}
+
+// PruneDeliveries deletes finished deliveries of all games created before
+// the given time.
+func (r *WebhookRepository) PruneDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		DELETE FROM gaming.webhook_deliveries
+		WHERE status IN (?, ?) AND created_at < ?
+	`, constants.DeliveryDelivered, constants.DeliveryDead, before)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to prune webhook deliveries: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// eventTypeFilter matches event_types arrays containing the event type.
+func eventTypeFilter(eventType string) string {
+	filter, _ := json.Marshal([]string{eventType})
+	return string(filter)
+}
+
+func (row webhookRow) toModel() (*model.Webhook, error) {
+	webhook := &model.Webhook{
+		ID:        row.ID,
+		URL:       row.URL,
+		TopN:      row.TopN,
+		Active:    row.Active,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+	if row.BoardID != nil {
+		webhook.BoardID = *row.BoardID
+	}
+	if err := json.Unmarshal([]byte(row.EventTypes), &webhook.EventTypes); err != nil {
+		return nil, fmt.Errorf("failed to decode event types of webhook %d: %w", row.ID, err)
+	}
+	return webhook, nil
+}
+
+func (row deliveryRow) toModel() model.WebhookDelivery {
+	delivery := model.WebhookDelivery{
+		ID:          row.ID,
+		WebhookID:   row.WebhookID,
+		EventType:   row.EventType,
+		Payload:     json.RawMessage(row.Payload),
+		Status:      row.Status,
+		Attempts:    row.Attempts,
+		CreatedAt:   row.CreatedAt,
+		DeliveredAt: row.DeliveredAt,
+	}
+	if row.Status == constants.DeliveryPending {
+		next := row.NextAttemptAt
+		delivery.NextAttemptAt = &next
+	}
+	if row.LastStatusCode != nil {
+		delivery.LastStatusCode = *row.LastStatusCode
+	}
+	if row.LastError != nil {
+		delivery.LastError = *row.LastError
+	}
+	return delivery
+}
@@ -0,0 +1,371 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"go.uber.org/zap"
+
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/providers"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/constants"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/core"
+	"github.com/ShreyaKesarwani1922/Gaming-Leaderboard/backend/webhook-module/model"
+)
+
+type WebhookHandler struct {
+	core     *core.WebhookCore
+	logger   *providers.ConsoleLogger
+	newrelic *newrelic.Application
+}
+
+func NewWebhookHandler(core *core.WebhookCore, logger *providers.ConsoleLogger, newrelic *newrelic.Application) *WebhookHandler {
+	return &WebhookHandler{
+		core:     core,
+		logger:   logger,
+		newrelic: newrelic,
+	}
+}
+
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	ctx := r.Context()
+
+	var req model.CreateWebhookRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid request payload",
+			constants.ErrInvalidWebhook,
+		)
+		return
+	}
+
+	resp, err := h.core.CreateWebhook(ctx, &req)
+	if err != nil {
+		h.logger.Error(
+			"CreateWebhook failed",
+			zap.String("url", req.URL),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to create webhook",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, webhookErrorStatus(resp.Code), resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, resp)
+}
+
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.core.ListWebhooks(r.Context())
+	if err != nil {
+		h.logger.Error(
+			"ListWebhooks failed",
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch webhooks",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.core.GetWebhook(r.Context(), webhookID)
+	if err != nil {
+		h.logger.Error(
+			"GetWebhook failed",
+			zap.Int64("webhook_id", webhookID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch webhook",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	ctx := r.Context()
+
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.UpdateWebhookRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid request payload",
+			constants.ErrInvalidWebhook,
+		)
+		return
+	}
+
+	resp, err := h.core.UpdateWebhook(ctx, webhookID, &req)
+	if err != nil {
+		h.logger.Error(
+			"UpdateWebhook failed",
+			zap.Int64("webhook_id", webhookID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to update webhook",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, webhookErrorStatus(resp.Code), resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.core.DeleteWebhook(r.Context(), webhookID)
+	if err != nil {
+		h.logger.Error(
+			"DeleteWebhook failed",
+			zap.Int64("webhook_id", webhookID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to delete webhook",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *WebhookHandler) SendTestEvent(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.core.SendTestEvent(r.Context(), webhookID)
+	if err != nil {
+		h.logger.Error(
+			"SendTestEvent failed",
+			zap.Int64("webhook_id", webhookID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to queue test event",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusAccepted, resp)
+}
+
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = constants.DeliveryAll
+	case constants.DeliveryAll, constants.DeliveryPending, constants.DeliveryDelivered, constants.DeliveryDead:
+	default:
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"status must be one of all, pending, delivered or dead",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	resp, err := h.core.ListDeliveries(r.Context(), webhookID, status)
+	if err != nil {
+		h.logger.Error(
+			"ListDeliveries failed",
+			zap.Int64("webhook_id", webhookID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to fetch deliveries",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, http.StatusNotFound, resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *WebhookHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	deliveryID, err := strconv.ParseInt(mux.Vars(r)["delivery_id"], 10, 64)
+	if err != nil || deliveryID <= 0 {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid delivery ID",
+			constants.ErrInvalidRequest,
+		)
+		return
+	}
+
+	resp, err := h.core.RetryDelivery(r.Context(), webhookID, deliveryID)
+	if err != nil {
+		h.logger.Error(
+			"RetryDelivery failed",
+			zap.Int64("webhook_id", webhookID),
+			zap.Int64("delivery_id", deliveryID),
+			zap.Error(err),
+		)
+
+		h.respondWithError(
+			w,
+			http.StatusInternalServerError,
+			"Failed to retry delivery",
+			constants.ErrInternalServer,
+		)
+		return
+	}
+
+	if !resp.Success {
+		h.respondWithJSON(w, webhookErrorStatus(resp.Code), resp)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, resp)
+}
+
+// webhookID parses the webhook_id path variable, answering 400 when it is invalid.
+func (h *WebhookHandler) webhookID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	webhookID, err := strconv.ParseInt(mux.Vars(r)["webhook_id"], 10, 64)
+	if err != nil || webhookID <= 0 {
+		h.respondWithError(
+			w,
+			http.StatusBadRequest,
+			"Invalid webhook ID",
+			constants.ErrInvalidRequest,
+		)
+		return 0, false
+	}
+	return webhookID, true
+}
+
+func webhookErrorStatus(code string) int {
+	switch code {
+	case constants.ErrWebhookNotFound, constants.ErrDeliveryNotFound, constants.ErrBoardNotFound:
+		return http.StatusNotFound
+	case constants.ErrTooManyWebhooks, constants.ErrDeliveryNotDead:
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
+
+func (h *WebhookHandler) respondWithJSON(
+	w http.ResponseWriter,
+	status int,
+	payload interface{},
+) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func (h *WebhookHandler) respondWithError(
+	w http.ResponseWriter,
+	status int,
+	message string,
+	code string,
+) {
+	h.respondWithJSON(w, status, map[string]interface{}{
+		"success": false,
+		"error":   message,
+		"code":    code,
+	})
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+func (h *WebhookHandler) RegisterRoutes(router *mux.Router) {
+	// Subscription endpoints
+	_, createHandler := newrelic.WrapHandle(h.newrelic, "api/webhooks/create", http.HandlerFunc(h.CreateWebhook))
+	router.Handle("/api/webhooks", createHandler).Methods(http.MethodPost)
+
+	_, listHandler := newrelic.WrapHandle(h.newrelic, "api/webhooks", http.HandlerFunc(h.ListWebhooks))
+	router.Handle("/api/webhooks", listHandler).Methods(http.MethodGet)
+
+	_, getHandler := newrelic.WrapHandle(h.newrelic, "api/webhooks/{webhook_id}", http.HandlerFunc(h.GetWebhook))
+	router.Handle("/api/webhooks/{webhook_id}", getHandler).Methods(http.MethodGet)
+
+	_, updateHandler := newrelic.WrapHandle(h.newrelic, "api/webhooks/{webhook_id}/update", http.HandlerFunc(h.UpdateWebhook))
+	router.Handle("/api/webhooks/{webhook_id}", updateHandler).Methods(http.MethodPatch)
+
+	_, deleteHandler := newrelic.WrapHandle(h.newrelic, "api/webhooks/{webhook_id}/delete", http.HandlerFunc(h.DeleteWebhook))
+	router.Handle("/api/webhooks/{webhook_id}", deleteHandler).Methods(http.MethodDelete)
+
+	_, testHandler := newrelic.WrapHandle(h.newrelic, "api/webhooks/{webhook_id}/test", http.HandlerFunc(h.SendTestEvent))
+	router.Handle("/api/webhooks/{webhook_id}/test", testHandler).Methods(http.MethodPost)
+
+	// Delivery log endpoints
+	_, deliveriesHandler := newrelic.WrapHandle(h.newrelic, "api/webhooks/{webhook_id}/deliveries", http.HandlerFunc(h.ListDeliveries))
+	router.Handle("/api/webhooks/{webhook_id}/deliveries", deliveriesHandler).Methods(http.MethodGet)
+
+	_, retryHandler := newrelic.WrapHandle(h.newrelic, "api/webhooks/{webhook_id}/deliveries/{delivery_id}/retry", http.HandlerFunc(h.RetryDelivery))
+	router.Handle("/api/webhooks/{webhook_id}/deliveries/{delivery_id}/retry", retryHandler).Methods(http.MethodPost)
+}
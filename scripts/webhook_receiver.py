@@ -0,0 +1,50 @@
+import hashlib
+import hmac
+import json
+import os
+import time
+from http.server import BaseHTTPRequestHandler, HTTPServer
+
+# Secret returned when the webhook was created
+WEBHOOK_SECRET = os.environ.get("WEBHOOK_SECRET", "")
+# The server only delivers to a receiver on localhost when started with
+# WEBHOOK_ALLOW_PRIVATE_RECEIVERS=true
+PORT = int(os.environ.get("PORT", "9000"))
+# Reject deliveries signed too long ago, so captured requests cannot be replayed
+MAX_SKEW_SECONDS = 300
+
+# Answer this status instead of 200, e.g. 500 to watch retries and dead-lettering
+FAIL_WITH = int(os.environ.get("FAIL_WITH", "0"))
+
+
+def verify(timestamp, body, signature):
+    mac = hmac.new(WEBHOOK_SECRET.encode(), f"{timestamp}.".encode() + body, hashlib.sha256)
+    return hmac.compare_digest(f"sha256={mac.hexdigest()}", signature)
+
+
+class WebhookReceiver(BaseHTTPRequestHandler):
+    def do_POST(self):
+        body = self.rfile.read(int(self.headers.get("Content-Length", 0)))
+        timestamp = self.headers.get("X-Webhook-Timestamp", "0")
+        signature = self.headers.get("X-Webhook-Signature", "")
+
+        if abs(time.time() - int(timestamp)) > MAX_SKEW_SECONDS or not verify(timestamp, body, signature):
+            print("Rejected delivery with an invalid signature")
+            self.send_response(401)
+            self.end_headers()
+            return
+
+        event = json.loads(body)
+        print(f"{self.headers.get('X-Webhook-Event')} (delivery {event['id']}):")
+        print(json.dumps(event["data"], indent=2))
+
+        self.send_response(FAIL_WITH or 200)
+        self.end_headers()
+
+    def log_message(self, format, *args):
+        pass
+
+
+if __name__ == "__main__":
+    print(f"Listening for webhooks on port {PORT}...")
+    HTTPServer(("", PORT), WebhookReceiver).serve_forever()